@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
@@ -10,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 
 	"github.com/IntelliXLabs/wasmvm-tee/wasm"
 	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/verify"
 )
 
 var (
@@ -26,6 +29,47 @@ var (
 	httpPort   = flag.Int("http-port", 8080, "HTTP server port")
 	enableHTTP = flag.Bool("enable-http", true, "Enable HTTP/REST API gateway")
 	enableGRPC = flag.Bool("enable-grpc", true, "Enable gRPC server")
+
+	maxTimeout      = flag.Duration("max-timeout", wasm.DefaultMaxTimeout, "Default and maximum execution time, module loading and AOT compilation included")
+	maxMemoryPages  = flag.Uint("max-memory-pages", 4096, "Default and maximum guest linear memory in 64 KiB pages (0 = WasmEdge default)")
+	defaultGasLimit = flag.Uint64("default-gas-limit", 0, "Gas budget of executions without gas_limit (0 = -max-gas-limit)")
+	maxGasLimit     = flag.Uint64("max-gas-limit", 0, "Maximum gas budget of an execution (0 = uncapped)")
+	aotCacheDir     = flag.String("aot-cache-dir", "", "TEE-private directory for AOT-compiled registered modules, cleared at startup (empty = interpreter only)")
+	aotCacheMax     = flag.Int64("aot-cache-max-bytes", wasm.DefaultAOTCacheMaxBytes, "Disk space of AOT-compiled modules, least recently used are removed beyond it")
+	moduleStoreDir  = flag.String("module-store-dir", "", "Directory for uploaded modules (empty = in-memory)")
+	secretStoreDir  = flag.String("secret-store-dir", "", "Directory for uploaded sealed secrets (empty = in-memory)")
+	maxRecvMsgSize  = flag.Int("max-recv-msg-size", 64<<20, "Maximum gRPC request size in bytes, bounds module uploads")
+
+	attesterName = flag.String("attester", "sev-snp", "Attestation provider: sev-snp, tdx or mock (mock evidence is not trustworthy)")
+	receiptsOnly = flag.Bool("receipts-only", false, "Sign results with the attested receipt key instead of attesting each execution")
+	batchWindow  = flag.Duration("batch-window", 0, "Attest the executions finishing within this window together through a Merkle root (0 = attest each execution)")
+	batchMaxSize = flag.Int("batch-max-size", 0, "Attest a batch as soon as it holds this many executions (0 = window only)")
+
+	maxClockSkew   = flag.Duration("max-clock-skew", 0, "Reject executions whose Unix timestamp is further than this from the server clock (0 = unchecked)")
+	requireNonce   = flag.Bool("require-nonce", false, "Reject executions without a nonce")
+	idempotencyTTL = flag.Duration("idempotency-ttl", 0, "Cache results by request_id for this long and serve retries from the cache (0 = disabled)")
+	idempotencyMax = flag.Int("idempotency-max-entries", wasm.DefaultIdempotencyMaxEntries, "Results cached by request_id before the oldest are evicted")
+
+	maxConcurrentExecutions = flag.Int("max-concurrent-executions", 0, "WASM executions running at once, further executions queue (0 = number of CPUs)")
+	executionQueueSize      = flag.Int("execution-queue-size", wasm.DefaultExecutionQueueSize, "Executions waiting for a worker before Execute fails with RESOURCE_EXHAUSTED")
+
+	maxBatchItems = flag.Int("max-batch-items", wasm.DefaultMaxBatchItems, "Input sets accepted by a single ExecuteBatch request")
+
+	jobWorkers     = flag.Int("job-workers", 0, "Submitted executions running at once (0 = number of CPUs)")
+	jobQueueSize   = flag.Int("job-queue-size", wasm.DefaultJobQueueSize, "Submitted executions waiting for a worker before SubmitExecution is rejected")
+	jobRetention   = flag.Duration("job-retention", wasm.DefaultJobRetention, "How long finished submitted executions and their results are kept")
+	jobMaxRetained = flag.Int("job-max-retained", wasm.DefaultJobMaxRetained, "Finished submitted executions kept before the oldest are dropped ahead of their retention")
+
+	amdProductLine = flag.String("amd-product-line", "Milan", "AMD product line of the verification certificates")
+	amdCertChain   = flag.String("amd-cert-chain", "", "PEM file with the AMD ASK and ARK for VerifyExecution (empty = embedded AMD roots)")
+	amdVCEK        = flag.String("amd-vcek", "", "VCEK certificate used when attestations do not carry one")
+
+	egressPolicy = flag.String("egress-policy", "", "JSON file restricting guest HTTP destinations (empty = public http/https only)")
+	tlsConfig    = flag.String("tls-config", "", "JSON file with the CA bundles, client certificates and SPKI pins of guest HTTP calls (empty = system roots, no mTLS)")
+
+	httpMaxRequestBody  = flag.Int64("http-max-request-body", wasm.DefaultHttpLimits.MaxRequestBodyBytes, "Maximum request body a guest may send in bytes")
+	httpMaxResponseBody = flag.Int64("http-max-response-body", wasm.DefaultHttpLimits.MaxResponseBodyBytes, "Maximum response body handed to a guest in bytes, larger bodies are truncated")
+	httpMaxHeaders      = flag.Int("http-max-headers", wasm.DefaultHttpLimits.MaxHeaders, "Maximum number of request or response headers of a guest HTTP call")
 )
 
 func main() {
@@ -42,16 +86,18 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	wasmServer := newWASMServer()
+
 	// Start gRPC server if enabled
 	if *enableGRPC {
-		go startGRPCServer(ctx, *grpcPort)
+		go startGRPCServer(ctx, *grpcPort, wasmServer)
 	}
 
 	// Start HTTP server if enabled
 	if *enableHTTP {
 		// Wait a moment for gRPC server to start
 		time.Sleep(100 * time.Millisecond)
-		go startHTTPServer(ctx, *httpPort, *grpcPort)
+		go startHTTPServer(ctx, *httpPort, *grpcPort, wasmServer)
 	}
 
 	// Wait for shutdown signal
@@ -64,18 +110,86 @@ func main() {
 	log.Println("Server shutdown complete")
 }
 
+// newWASMServer creates the WASMVM service from the command line flags
+func newWASMServer() *wasm.Server {
+	verifyBundle, err := loadVerifyBundle()
+	if err != nil {
+		log.Fatalf("Failed to load verification certificates: %v", err)
+	}
+
+	attester, err := wasm.NewAttester(*attesterName)
+	if err != nil {
+		log.Fatalf("Failed to create attester: %v", err)
+	}
+	if *attesterName == "mock" {
+		log.Printf("⚠️  Using the mock attester, attestations are signed by a local key and prove nothing")
+	}
+
+	var egress *wasm.EgressConfig
+	if *egressPolicy != "" {
+		if egress, err = wasm.LoadEgressConfig(*egressPolicy); err != nil {
+			log.Fatalf("Failed to load egress policy: %v", err)
+		}
+	}
+
+	var outboundTLS *wasm.TLSConfig
+	if *tlsConfig != "" {
+		if outboundTLS, err = wasm.LoadTLSConfig(*tlsConfig); err != nil {
+			log.Fatalf("Failed to load TLS config: %v", err)
+		}
+	}
+
+	// Register DTVM TEE service
+	wasmServer, err := wasm.NewServer(wasm.Config{
+		MaxMemoryPages:   uint32(*maxMemoryPages),
+		MaxTimeout:       *maxTimeout,
+		DefaultGasLimit:  *defaultGasLimit,
+		MaxGasLimit:      *maxGasLimit,
+		AOTCacheDir:      *aotCacheDir,
+		AOTCacheMaxBytes: *aotCacheMax,
+		ModuleStoreDir:   *moduleStoreDir,
+		SecretStoreDir:   *secretStoreDir,
+		ReceiptsOnly:     *receiptsOnly,
+		BatchWindow:      *batchWindow,
+		BatchMaxSize:     *batchMaxSize,
+		MaxClockSkew:     *maxClockSkew,
+		RequireNonce:     *requireNonce,
+		IdempotencyTTL:   *idempotencyTTL,
+
+		IdempotencyMaxEntries:   *idempotencyMax,
+		MaxConcurrentExecutions: *maxConcurrentExecutions,
+		ExecutionQueueSize:      *executionQueueSize,
+		MaxBatchItems:           *maxBatchItems,
+		JobWorkers:              *jobWorkers,
+		JobQueueSize:            *jobQueueSize,
+		JobRetention:            *jobRetention,
+		JobMaxRetained:          *jobMaxRetained,
+		VerifyBundle:            verifyBundle,
+		Attester:                attester,
+		Egress:                  egress,
+		HttpLimits: &wasm.HttpLimits{
+			MaxRequestBodyBytes:  *httpMaxRequestBody,
+			MaxResponseBodyBytes: *httpMaxResponseBody,
+			MaxHeaders:           *httpMaxHeaders,
+		},
+		TLS: outboundTLS,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create WASMVM server: %v", err)
+	}
+
+	return wasmServer
+}
+
 // startGRPCServer starts the gRPC server
-func startGRPCServer(ctx context.Context, port int) {
+func startGRPCServer(ctx context.Context, port int, wasmServer *wasm.Server) {
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port %d: %v", port, err)
 	}
 
 	// Create gRPC server instance
-	grpcServer := grpc.NewServer()
-
-	// Register DTVM TEE service
-	wasmServer := &wasm.Server{}
+	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(*maxRecvMsgSize))
 	types.RegisterWASMVMTeeServiceServer(grpcServer, wasmServer)
 
 	log.Printf("✅ gRPC server listening at %v", listener.Addr())
@@ -93,8 +207,32 @@ func startGRPCServer(ctx context.Context, port int) {
 	grpcServer.GracefulStop()
 }
 
+// loadVerifyBundle reads the offline AMD certificates used by VerifyExecution
+func loadVerifyBundle() (*verify.Bundle, error) {
+	if *amdCertChain == "" {
+		return nil, nil
+	}
+
+	certChain, err := os.ReadFile(*amdCertChain)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := verify.NewBundle(*amdProductLine, certChain)
+	if err != nil {
+		return nil, err
+	}
+
+	if *amdVCEK != "" {
+		if bundle.VCEK, err = os.ReadFile(*amdVCEK); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, nil
+}
+
 // startHTTPServer starts the HTTP server with grpc-gateway
-func startHTTPServer(ctx context.Context, httpPort, grpcPort int) {
+func startHTTPServer(ctx context.Context, httpPort, grpcPort int, wasmServer *wasm.Server) {
 	// Create grpc-gateway mux with custom options
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
@@ -106,7 +244,10 @@ func startHTTPServer(ctx context.Context, httpPort, grpcPort int) {
 	)
 
 	// Setup gRPC connection options
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(*maxRecvMsgSize), grpc.MaxCallRecvMsgSize(*maxRecvMsgSize)),
+	}
 	grpcServerEndpoint := fmt.Sprintf("localhost:%d", grpcPort)
 
 	// Register DTVM service handler
@@ -120,24 +261,41 @@ func startHTTPServer(ctx context.Context, httpPort, grpcPort int) {
 
 	// Register grpc-gateway routes
 	httpMux.Handle("/", corsHandler(mux))
+	httpMux.Handle("/v1/dtvm/execute-stream", corsHandler(streamHandler(mux)))
 
 	// Add health check endpoint
-	httpMux.HandleFunc("/health", corsHandlerFunc(healthCheckHandler))
+	httpMux.HandleFunc("/health", corsHandlerFunc(healthCheckHandler(wasmServer)))
 
 	// Add API info endpoint
 	httpMux.HandleFunc("/api/info", corsHandlerFunc(apiInfoHandler))
 
-	// Create HTTP server
+	// Create HTTP server, unary executions must be able to run for -max-timeout before the response is dropped
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", httpPort),
 		Handler:      httpMux,
 		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: httpWriteTimeout(*maxTimeout),
 	}
 
 	log.Printf("✅ HTTP server listening at http://localhost:%d", httpPort)
 	log.Printf("📋 API endpoints available:")
 	log.Printf("   POST http://localhost:%d/v1/dtvm/execute", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/execute-stream (NDJSON, or SSE with Accept: text/event-stream)", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/execute-batch", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/verify", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/modules", httpPort)
+	log.Printf("   GET  http://localhost:%d/v1/dtvm/modules", httpPort)
+	log.Printf("   GET  http://localhost:%d/v1/dtvm/modules/{hash}", httpPort)
+	log.Printf("   DEL  http://localhost:%d/v1/dtvm/modules/{hash}", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/secrets/key", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/secrets", httpPort)
+	log.Printf("   GET  http://localhost:%d/v1/dtvm/secrets", httpPort)
+	log.Printf("   DEL  http://localhost:%d/v1/dtvm/secrets/{name}", httpPort)
+	log.Printf("   GET  http://localhost:%d/v1/dtvm/attested-key", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/executions", httpPort)
+	log.Printf("   GET  http://localhost:%d/v1/dtvm/executions", httpPort)
+	log.Printf("   GET  http://localhost:%d/v1/dtvm/executions/{id}", httpPort)
+	log.Printf("   POST http://localhost:%d/v1/dtvm/executions/{id}/cancel", httpPort)
 	log.Printf("   GET  http://localhost:%d/health", httpPort)
 	log.Printf("   GET  http://localhost:%d/api/info", httpPort)
 
@@ -160,6 +318,15 @@ func startHTTPServer(ctx context.Context, httpPort, grpcPort int) {
 	}
 }
 
+// httpWriteTimeout returns the write timeout of the gateway: 30 seconds, raised to leave an execution of
+// maxTimeout 30 seconds for queuing, attestation and the response
+func httpWriteTimeout(maxTimeout time.Duration) time.Duration {
+	if maxTimeout <= 0 {
+		maxTimeout = wasm.DefaultMaxTimeout
+	}
+	return max(30*time.Second, maxTimeout+30*time.Second)
+}
+
 // corsHandler adds CORS headers to support cross-origin requests for Handlers
 func corsHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -181,6 +348,89 @@ func corsHandler(next http.Handler) http.Handler {
 	})
 }
 
+// streamHandler serves the execution event stream
+// The stream outlives the server write timeout, and clients accepting text/event-stream get the
+// newline delimited JSON messages of the gateway as server-sent events
+func streamHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			log.Printf("Failed to lift the write deadline of the event stream: %v", err)
+		}
+
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			sse := &sseWriter{ResponseWriter: w}
+			// Errors raised before the stream starts are not newline terminated
+			defer sse.Close()
+			w = sse
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sseWriter turns each line written by the gateway into a server-sent event
+type sseWriter struct {
+	http.ResponseWriter
+	line        []byte
+	wroteHeader bool
+}
+
+// WriteHeader sends the status with the event stream content type
+func (w *sseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Framing the data as events changes the length
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write buffers the current line and sends every completed line as a data event
+func (w *sseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+
+	w.line = append(w.line, p...)
+	for {
+		end := bytes.IndexByte(w.line, '\n')
+		if end < 0 {
+			return len(p), nil
+		}
+		if err := w.event(w.line[:end]); err != nil {
+			return 0, err
+		}
+		w.line = w.line[end+1:]
+	}
+}
+
+// Close sends the last line if it was not terminated
+func (w *sseWriter) Close() error {
+	if len(w.line) == 0 {
+		return nil
+	}
+	defer func() { w.line = nil }()
+	return w.event(w.line)
+}
+
+// event sends data as a single server-sent event
+func (w *sseWriter) event(data []byte) error {
+	_, err := fmt.Fprintf(w.ResponseWriter, "data: %s\n\n", data)
+	return err
+}
+
+// Flush sends the events written so far
+func (w *sseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer
+func (w *sseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // corsHandlerFunc adds CORS headers to support cross-origin requests for HandlerFuncs
 func corsHandlerFunc(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -202,11 +452,20 @@ func corsHandlerFunc(fn http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// healthCheckHandler provides a simple health check endpoint
-func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, `{"status":"healthy","service":"wasmvm-tee","timestamp":"%s"}`, time.Now().Format(time.RFC3339))
+// healthCheckHandler provides a health check endpoint reporting the load of the execution queue
+func healthCheckHandler(wasmServer *wasm.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		health, _ := json.Marshal(map[string]any{
+			"status":     "healthy",
+			"service":    "wasmvm-tee",
+			"timestamp":  time.Now().Format(time.RFC3339),
+			"executions": wasmServer.PoolStats(),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(health)
+	}
 }
 
 // apiInfoHandler provides API documentation
@@ -231,13 +490,7 @@ func apiInfoHandler(w http.ResponseWriter, r *http.Request) {
 						"fn_name":    "fib",
 						"inputs":     []string{"5"},
 						"timestamp":  0,
-					},
-					"runtime_config": map[string]any{
-						"mode": 0,
-						"gas_limit": map[string]any{
-							"use_gas_limit": false,
-							"limit":         0,
-						},
+						"gas_limit":  1000000,
 					},
 				},
 			},
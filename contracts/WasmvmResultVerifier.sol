@@ -0,0 +1,109 @@
+pragma solidity ^0.8.20;
+
+/// @title WasmvmResultVerifier
+/// @notice Reference verifier of execution results signed by a wasmvm-tee receipt key.
+/// Trusted signers are the addresses of GetAttestedKey responses whose attestation was verified
+/// off chain, and results are only accepted for allowed module hashes. Each request id is accepted
+/// once, so a signed result cannot be replayed. See docs/evm.md.
+contract WasmvmResultVerifier {
+    bytes32 public constant DOMAIN_TYPEHASH =
+        keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)");
+    bytes32 public constant RESULT_TYPEHASH =
+        keccak256("ExecutionResult(string requestId,bytes32 moduleHash,bytes32 reportDigest,bytes outputs)");
+
+    // Signatures with s above half the curve order are malleable and rejected
+    uint256 private constant HALF_ORDER = 0x7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a0;
+
+    address public owner;
+    mapping(address => bool) public trustedSigners;
+    mapping(bytes32 => bool) public allowedModules;
+    /// @notice Request ids of accepted results, by keccak256 of the id
+    mapping(bytes32 => bool) public consumedRequests;
+
+    event SignerUpdated(address indexed signer, bool trusted);
+    event ModuleUpdated(bytes32 indexed moduleHash, bool allowed);
+    event ResultConsumed(bytes32 indexed requestIdHash, bytes32 indexed moduleHash, bytes32 reportDigest);
+
+    constructor() {
+        owner = msg.sender;
+    }
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "not owner");
+        _;
+    }
+
+    /// @notice Trusts or distrusts a receipt key, keys change whenever the server restarts
+    function setSigner(address signer, bool trusted) external onlyOwner {
+        trustedSigners[signer] = trusted;
+        emit SignerUpdated(signer, trusted);
+    }
+
+    /// @notice Allows or disallows results of a module, by SHA-256 of its bytecode
+    function setModule(bytes32 moduleHash, bool allowed) external onlyOwner {
+        allowedModules[moduleHash] = allowed;
+        emit ModuleUpdated(moduleHash, allowed);
+    }
+
+    /// @notice EIP-712 domain separator, request results with this contract and chain id
+    function domainSeparator() public view returns (bytes32) {
+        return keccak256(
+            abi.encode(DOMAIN_TYPEHASH, keccak256("wasmvm-tee"), keccak256("1"), block.chainid, address(this))
+        );
+    }
+
+    /// @notice Verifies an EIP-712 signed result, consumes its request id and returns its outputs, ready for abi.decode
+    function verifyEIP712(
+        string calldata requestId,
+        bytes32 moduleHash,
+        bytes32 reportDigest,
+        bytes calldata outputs,
+        bytes calldata signature
+    ) public returns (bytes calldata) {
+        bytes32 structHash = keccak256(
+            abi.encode(RESULT_TYPEHASH, keccak256(bytes(requestId)), moduleHash, reportDigest, keccak256(outputs))
+        );
+        bytes32 digest = keccak256(abi.encodePacked("\x19\x01", domainSeparator(), structHash));
+        _check(digest, moduleHash, signature);
+        _consume(requestId, moduleHash, reportDigest);
+        return outputs;
+    }
+
+    /// @notice Verifies an EIP-191 signed result given as the encoded field of an EvmResult and consumes its request id
+    function verifyEIP191(bytes calldata encoded, bytes calldata signature)
+        public
+        returns (string memory requestId, bytes32 moduleHash, bytes32 reportDigest, bytes memory outputs)
+    {
+        bytes32 digest = keccak256(abi.encodePacked("\x19Ethereum Signed Message:\n32", keccak256(encoded)));
+        (requestId, moduleHash, reportDigest, outputs) = abi.decode(encoded, (string, bytes32, bytes32, bytes));
+        _check(digest, moduleHash, signature);
+        _consume(requestId, moduleHash, reportDigest);
+    }
+
+    function _check(bytes32 digest, bytes32 moduleHash, bytes calldata signature) internal view {
+        require(allowedModules[moduleHash], "module not allowed");
+        require(trustedSigners[_recover(digest, signature)], "untrusted signer");
+    }
+
+    // Request ids are signed, so a result is bound to its id and accepted only once
+    function _consume(string memory requestId, bytes32 moduleHash, bytes32 reportDigest) internal {
+        require(bytes(requestId).length > 0, "missing request id");
+        bytes32 requestIdHash = keccak256(bytes(requestId));
+        require(!consumedRequests[requestIdHash], "request already consumed");
+        consumedRequests[requestIdHash] = true;
+        emit ResultConsumed(requestIdHash, moduleHash, reportDigest);
+    }
+
+    function _recover(bytes32 digest, bytes calldata signature) internal pure returns (address) {
+        require(signature.length == 65, "invalid signature length");
+        bytes32 r = bytes32(signature[0:32]);
+        bytes32 s = bytes32(signature[32:64]);
+        uint8 v = uint8(signature[64]);
+        require(uint256(s) <= HALF_ORDER, "invalid signature s");
+        require(v == 27 || v == 28, "invalid signature v");
+
+        address signer = ecrecover(digest, v, r, s);
+        require(signer != address(0), "invalid signature");
+        return signer;
+    }
+}
@@ -0,0 +1,261 @@
+package wasm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+)
+
+// ErrArtifactModified is returned when a compiled artifact on disk no longer matches the one this process compiled
+var ErrArtifactModified = errors.New("AOT artifact modified on disk")
+
+// DefaultAOTCacheMaxBytes is the disk space of compiled artifacts when Config.AOTCacheMaxBytes is zero
+const DefaultAOTCacheMaxBytes = 1 << 30
+
+// AOTCache compiles modules ahead of time with the WasmEdge compiler
+// and keeps the native artifacts on disk for reuse across the executions of this process
+// The artifacts run as native code, so only artifacts compiled by this process are loaded, and only while
+// their SHA-256 still matches the one recorded at compile time. The directory should nevertheless live on
+// storage private to the TEE: a file swapped between the check and the load would go unnoticed.
+// The artifacts take at most maxBytes, beyond it the least recently used are removed.
+type AOTCache struct {
+	dir      string
+	maxBytes int64
+
+	// mu guards artifacts and the sizes and use order of the artifacts, compilations of different modules run concurrently
+	mu        sync.Mutex
+	artifacts map[string]*aotArtifact
+	size      int64  // Bytes of the compiled artifacts
+	clock     uint64 // Incremented on every use, orders the artifacts by last use
+}
+
+// aotArtifact is the compiled artifact of one cache key
+type aotArtifact struct {
+	// lock serializes the compilation of the key so a module is compiled only once, callers wait on it until ctx ends
+	lock     chan struct{}
+	compiled bool
+	digest   [32]byte // SHA-256 of the artifact file as compiled
+
+	// Guarded by AOTCache.mu
+	size     int64
+	lastUsed uint64
+}
+
+// NewAOTCache creates an AOT cache storing at most maxBytes of compiled artifacts in dir
+// A zero maxBytes uses DefaultAOTCacheMaxBytes. Artifacts left by earlier processes cannot be vouched for
+// and are removed.
+func NewAOTCache(dir string, maxBytes int64) (*AOTCache, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultAOTCacheMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create AOT cache directory: %w", err)
+	}
+
+	stale, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AOT cache directory: %w", err)
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale artifact: %w", err)
+		}
+	}
+
+	return &AOTCache{dir: dir, maxBytes: maxBytes, artifacts: make(map[string]*aotArtifact)}, nil
+}
+
+// CacheKey returns the cache key of a module: SHA-256 over the WasmEdge version and the bytecode
+// Upgrading WasmEdge therefore never loads artifacts produced by another compiler version
+func (c *AOTCache) CacheKey(wasmCode []byte) string {
+	h := sha256.New()
+	h.Write([]byte(wasmedge.GetVersion()))
+	h.Write([]byte{0})
+	h.Write(wasmCode)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Compile returns the path of the compiled artifact for wasmCode, compiling it on a cache miss
+// The artifact is checked against its recorded digest on every call, a modified artifact is compiled again.
+// ctx bounds the wait for a compilation of the same module in progress, a compilation once started runs to its end.
+func (c *AOTCache) Compile(ctx context.Context, wasmCode []byte) (string, error) {
+	if len(wasmCode) == 0 {
+		return "", fmt.Errorf("empty WASM module")
+	}
+
+	key := c.CacheKey(wasmCode)
+	path := filepath.Join(c.dir, key+".so")
+
+	artifact, err := c.acquire(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer func() { <-artifact.lock }()
+
+	if artifact.compiled {
+		err := checkArtifact(path, artifact.digest)
+		if err == nil {
+			return path, nil
+		}
+		log.Printf("Compiling %s again: %v", path, err)
+		artifact.compiled = false
+	}
+
+	digest, size, err := c.compile(wasmCode, path)
+	if err == nil {
+		err = checkArtifact(path, digest)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		// Forget the key so modules that never compile do not accumulate
+		c.size -= artifact.size
+		delete(c.artifacts, key)
+		return "", err
+	}
+	artifact.digest, artifact.compiled = digest, true
+	c.size += size - artifact.size
+	artifact.size = size
+	c.evict(artifact)
+
+	return path, nil
+}
+
+// acquire locks the artifact of key and marks it used, waiting for a compilation of the key in progress until ctx ends
+func (c *AOTCache) acquire(ctx context.Context, key string) (*aotArtifact, error) {
+	for {
+		c.mu.Lock()
+		artifact, ok := c.artifacts[key]
+		if !ok {
+			artifact = &aotArtifact{lock: make(chan struct{}, 1)}
+			c.artifacts[key] = artifact
+		}
+		c.clock++
+		artifact.lastUsed = c.clock
+		c.mu.Unlock()
+
+		select {
+		case artifact.lock <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if err := ctx.Err(); err != nil {
+			<-artifact.lock
+			return nil, err
+		}
+
+		c.mu.Lock()
+		current := c.artifacts[key] == artifact
+		c.mu.Unlock()
+		if current {
+			return artifact, nil
+		}
+		// The artifact was evicted or forgotten while we waited for it
+		<-artifact.lock
+	}
+}
+
+// evict removes the least recently used artifacts until the cache fits in maxBytes, c.mu must be held
+// Artifacts locked by a compilation or check in progress are skipped, as is keep, the artifact just compiled.
+func (c *AOTCache) evict(keep *aotArtifact) {
+	if c.size <= c.maxBytes {
+		return
+	}
+
+	keys := make([]string, 0, len(c.artifacts))
+	for key := range c.artifacts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return c.artifacts[keys[i]].lastUsed < c.artifacts[keys[j]].lastUsed })
+
+	for _, key := range keys {
+		if c.size <= c.maxBytes {
+			return
+		}
+		artifact := c.artifacts[key]
+		if artifact == keep {
+			continue
+		}
+		select {
+		case artifact.lock <- struct{}{}:
+		default:
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(c.dir, key+".so")); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Failed to remove evicted artifact %s: %v", key, err)
+		}
+		c.size -= artifact.size
+		delete(c.artifacts, key)
+		<-artifact.lock
+	}
+}
+
+// compile compiles wasmCode into path and returns the SHA-256 and the size of the artifact
+// Artifacts are compiled interruptible with cost measuring, so cancellation and gas metering work in AOT mode.
+func (c *AOTCache) compile(wasmCode []byte, path string) ([32]byte, int64, error) {
+	// Compile into a temporary file and rename so a partial artifact is never loaded
+	tmp, err := os.CreateTemp(c.dir, "compile-*.so")
+	if err != nil {
+		return [32]byte{}, 0, fmt.Errorf("failed to create temporary artifact: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if err := compileNative(wasmCode, tmpPath); err != nil {
+		return [32]byte{}, 0, err
+	}
+
+	digest, size, err := fileDigest(tmpPath)
+	if err != nil {
+		return [32]byte{}, 0, fmt.Errorf("failed to hash compiled artifact: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return [32]byte{}, 0, fmt.Errorf("failed to store compiled artifact: %w", err)
+	}
+
+	return digest, size, nil
+}
+
+// checkArtifact verifies that the artifact at path still has the expected SHA-256
+func checkArtifact(path string, expected [32]byte) error {
+	digest, _, err := fileDigest(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrArtifactModified, err)
+	}
+	if digest != expected {
+		return fmt.Errorf("%w: %s has SHA-256 %x, compiled as %x", ErrArtifactModified, path, digest, expected)
+	}
+
+	return nil
+}
+
+// fileDigest returns the SHA-256 and the size of the file at path
+func fileDigest(path string) ([32]byte, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return [32]byte{}, 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return [32]byte{}, 0, err
+	}
+
+	return [32]byte(h.Sum(nil)), size, nil
+}
@@ -0,0 +1,47 @@
+package wasm
+
+/*
+#cgo linux LDFLAGS: -lwasmedge
+#cgo darwin LDFLAGS: -lwasmedge
+
+#include <stdlib.h>
+#include <wasmedge/wasmedge.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// compileNative compiles wasmCode into a native artifact at path
+// WasmEdge-go does not expose the interruptible compiler option, so the compiler is driven through the C API.
+// Interruptible artifacts check the stop token in loops and calls, which is what lets Async.Cancel stop a guest
+// running natively, and cost measuring is compiled in for gas metering.
+func compileNative(wasmCode []byte, path string) error {
+	conf := C.WasmEdge_ConfigureCreate()
+	if conf == nil {
+		return errors.New("failed to create WasmEdge configuration")
+	}
+	defer C.WasmEdge_ConfigureDelete(conf)
+	C.WasmEdge_ConfigureStatisticsSetCostMeasuring(conf, true)
+	C.WasmEdge_ConfigureCompilerSetOutputFormat(conf, C.WasmEdge_CompilerOutputFormat_Native)
+	C.WasmEdge_ConfigureCompilerSetInterruptible(conf, true)
+
+	compiler := C.WasmEdge_CompilerCreate(conf)
+	if compiler == nil {
+		return errors.New("WasmEdge compiler is not available")
+	}
+	defer C.WasmEdge_CompilerDelete(compiler)
+
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	bytes := C.WasmEdge_BytesWrap((*C.uint8_t)(unsafe.Pointer(&wasmCode[0])), C.uint32_t(len(wasmCode)))
+	res := C.WasmEdge_CompilerCompileFromBytes(compiler, bytes, cpath)
+	if !C.WasmEdge_ResultOK(res) {
+		return fmt.Errorf("failed to compile WASM module: %s", C.GoString(C.WasmEdge_ResultGetMessage(res)))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package wasm
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestAOTCacheArtifacts - Verifies that stale artifacts are removed and modified artifacts are detected
+func TestAOTCacheArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "stale.so")
+	if err := os.WriteFile(stale, []byte("left by an earlier process"), 0o600); err != nil {
+		t.Fatalf("Failed to write artifact: %v", err)
+	}
+	if _, err := NewAOTCache(dir, 0); err != nil {
+		t.Fatalf("Failed to create AOT cache: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale artifact to be removed, got %v", err)
+	}
+
+	path := filepath.Join(dir, "module.so")
+	if err := os.WriteFile(path, []byte("native code"), 0o600); err != nil {
+		t.Fatalf("Failed to write artifact: %v", err)
+	}
+	digest := sha256.Sum256([]byte("native code"))
+	if err := checkArtifact(path, digest); err != nil {
+		t.Errorf("Expected the artifact to match, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("swapped code"), 0o600); err != nil {
+		t.Fatalf("Failed to write artifact: %v", err)
+	}
+	if err := checkArtifact(path, digest); !errors.Is(err, ErrArtifactModified) {
+		t.Errorf("Expected %v, got %v", ErrArtifactModified, err)
+	}
+	os.Remove(path)
+	if err := checkArtifact(path, digest); !errors.Is(err, ErrArtifactModified) {
+		t.Errorf("Expected %v for a removed artifact, got %v", ErrArtifactModified, err)
+	}
+}
+
+// TestAOTCacheCompileContext - Verifies that waiting for a compilation in progress ends with the context
+func TestAOTCacheCompileContext(t *testing.T) {
+	cache, err := NewAOTCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create AOT cache: %v", err)
+	}
+	wasmCode := []byte("\x00asm\x01\x00\x00\x00")
+
+	// Another execution is compiling the module
+	artifact := &aotArtifact{lock: make(chan struct{}, 1)}
+	artifact.lock <- struct{}{}
+	cache.artifacts[cache.CacheKey(wasmCode)] = artifact
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := cache.Compile(ctx, wasmCode); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// Modules are not loaded once the deadline has passed
+	if _, err := LoadModule(ctx, wasmCode, ExecuteOptions{AOTCache: cache}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+// TestAOTCacheEvict - Verifies that the least recently used artifacts are removed beyond the size limit
+func TestAOTCacheEvict(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewAOTCache(dir, 10)
+	if err != nil {
+		t.Fatalf("Failed to create AOT cache: %v", err)
+	}
+
+	// Artifacts of 4 bytes, from the least to the most recently used
+	keys := []string{"oldest", "locked", "older", "newest"}
+	for i, key := range keys {
+		if err := os.WriteFile(filepath.Join(dir, key+".so"), []byte("code"), 0o600); err != nil {
+			t.Fatalf("Failed to write artifact: %v", err)
+		}
+		cache.artifacts[key] = &aotArtifact{lock: make(chan struct{}, 1), compiled: true, size: 4, lastUsed: uint64(i)}
+		cache.size += 4
+	}
+	// An artifact in use is never removed
+	cache.artifacts["locked"].lock <- struct{}{}
+
+	cache.mu.Lock()
+	cache.evict(cache.artifacts["newest"])
+	cache.mu.Unlock()
+
+	if cache.size != 8 {
+		t.Errorf("Expected 8 bytes cached, got %d", cache.size)
+	}
+	for _, tc := range []struct {
+		key  string
+		kept bool
+	}{
+		{"oldest", false},
+		{"locked", true},
+		{"older", false},
+		{"newest", true},
+	} {
+		_, cached := cache.artifacts[tc.key]
+		_, err := os.Stat(filepath.Join(dir, tc.key+".so"))
+		if cached != tc.kept || (err == nil) != tc.kept {
+			t.Errorf("Artifact %s: expected kept %v, cached %v, file error %v", tc.key, tc.kept, cached, err)
+		}
+	}
+}
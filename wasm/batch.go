@@ -0,0 +1,117 @@
+package wasm
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// attestationBatcher attests the report data of concurrent executions with a single TEE report
+// Executions finishing within the batch window become leaves of a Merkle tree, and only the root is attested.
+// Each execution then carries the shared attestation and its inclusion proof.
+type attestationBatcher struct {
+	attester Attester
+	window   time.Duration
+	maxSize  int
+
+	mu      sync.Mutex
+	pending *pendingBatch
+}
+
+// pendingBatch collects leaves until it is sealed by its timer or by reaching the maximum size
+type pendingBatch struct {
+	leaves [][32]byte
+	timer  *time.Timer
+	done   chan struct{}
+
+	// Set once sealed, before done is closed
+	root        [32]byte
+	attestation []byte
+	err         error
+}
+
+// newAttestationBatcher creates a batcher sealing batches after window, or once they hold maxSize executions
+// A maxSize of zero leaves batches bounded by the window only.
+func newAttestationBatcher(attester Attester, window time.Duration, maxSize int) *attestationBatcher {
+	return &attestationBatcher{attester: attester, window: window, maxSize: maxSize}
+}
+
+// Attest adds reportData to the open batch, waits for the batch to be attested and returns
+// the batch attestation with the inclusion proof of reportData
+func (b *attestationBatcher) Attest(ctx context.Context, reportData [64]byte) ([]byte, *types.BatchProof, error) {
+	b.mu.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &pendingBatch{done: make(chan struct{})}
+		batch.timer = time.AfterFunc(b.window, func() { b.seal(batch) })
+		b.pending = batch
+	}
+	index := len(batch.leaves)
+	batch.leaves = append(batch.leaves, reportdata.BatchLeaf(reportData))
+	full := b.maxSize > 0 && len(batch.leaves) >= b.maxSize
+	b.mu.Unlock()
+
+	if full {
+		b.seal(batch)
+	}
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, nil, batch.err
+	}
+
+	// The leaves no longer change once the batch is sealed
+	proof, err := batchProof(batch.leaves, batch.root, index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return batch.attestation, proof, nil
+}
+
+// seal closes batch to new executions and attests its root, only the first call has any effect
+func (b *attestationBatcher) seal(batch *pendingBatch) {
+	b.mu.Lock()
+	if b.pending != batch {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	batch.timer.Stop()
+	batch.root = reportdata.MerkleRoot(batch.leaves)
+	batch.attestation, batch.err = b.attester.Attest(reportdata.BatchReportData(batch.root, uint64(len(batch.leaves))))
+	if batch.err != nil {
+		batch.err = fmt.Errorf("failed to attest batch of %d executions: %v", len(batch.leaves), batch.err)
+	}
+	close(batch.done)
+}
+
+// batchProof returns the inclusion proof of the leaf at index in the batch of leaves with the given root
+func batchProof(leaves [][32]byte, root [32]byte, index int) (*types.BatchProof, error) {
+	proof, err := reportdata.InclusionProof(leaves, index)
+	if err != nil {
+		return nil, err
+	}
+	hexProof := make([]string, len(proof))
+	for i, sibling := range proof {
+		hexProof[i] = hex.EncodeToString(sibling[:])
+	}
+
+	return &types.BatchProof{
+		Root:      hex.EncodeToString(root[:]),
+		LeafIndex: uint64(index),
+		BatchSize: uint64(len(leaves)),
+		Proof:     hexProof,
+	}, nil
+}
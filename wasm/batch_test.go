@@ -0,0 +1,95 @@
+package wasm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// recordingAttester records the report data it is asked to attest
+type recordingAttester struct {
+	mu      sync.Mutex
+	reports [][64]byte
+}
+
+func (r *recordingAttester) Provider() types.AttestationProvider {
+	return types.AttestationProvider_ATTESTATION_PROVIDER_MOCK
+}
+
+func (r *recordingAttester) Attest(reportData [64]byte) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, reportData)
+	return []byte(hex.EncodeToString(reportData[:])), nil
+}
+
+// TestAttestationBatcher - Verifies that concurrent executions share one attestation and each proves its inclusion
+func TestAttestationBatcher(t *testing.T) {
+	attester := &recordingAttester{}
+	batcher := newAttestationBatcher(attester, time.Minute, 5)
+
+	type attested struct {
+		reportData  [64]byte
+		attestation []byte
+		proof       *types.BatchProof
+		err         error
+	}
+	results := make([]attested, 5)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].reportData = [64]byte{byte(i)}
+			results[i].attestation, results[i].proof, results[i].err = batcher.Attest(context.Background(), results[i].reportData)
+		}()
+	}
+	wg.Wait()
+
+	// The batch is sealed by its size long before the window elapses
+	if len(attester.reports) != 1 {
+		t.Fatalf("Expected a single attestation, got %d", len(attester.reports))
+	}
+	for i, result := range results {
+		if result.err != nil {
+			t.Fatalf("Execution %d failed: %v", i, result.err)
+		}
+		if string(result.attestation) != hex.EncodeToString(attester.reports[0][:]) || result.proof.BatchSize != 5 {
+			t.Errorf("Execution %d got attestation %s of a batch of %d", i, result.attestation, result.proof.BatchSize)
+		}
+
+		proof := make([][32]byte, len(result.proof.Proof))
+		for j, sibling := range result.proof.Proof {
+			decoded, _ := hex.DecodeString(sibling)
+			proof[j] = [32]byte(decoded)
+		}
+		root, err := reportdata.InclusionRoot(reportdata.BatchLeaf(result.reportData), result.proof.LeafIndex, 5, proof)
+		if err != nil || hex.EncodeToString(root[:]) != result.proof.Root {
+			t.Errorf("Execution %d does not prove into %s: %v", i, result.proof.Root, err)
+		}
+		if reportdata.BatchReportData(root, 5) != attester.reports[0] {
+			t.Errorf("Execution %d root is not the attested one", i)
+		}
+	}
+
+	// A lone execution is attested once the window elapses
+	windowed := newAttestationBatcher(attester, 10*time.Millisecond, 0)
+	_, proof, err := windowed.Attest(context.Background(), [64]byte{9})
+	leaf := reportdata.BatchLeaf([64]byte{9})
+	if err != nil || proof.BatchSize != 1 || len(proof.Proof) != 0 || proof.Root != hex.EncodeToString(leaf[:]) {
+		t.Errorf("Unexpected batch of one %+v, %v", proof, err)
+	}
+
+	// Callers stop waiting when their context ends
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := newAttestationBatcher(attester, time.Minute, 0).Attest(ctx, [64]byte{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,112 @@
+package wasm
+
+import (
+	"time"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/verify"
+)
+
+// Config holds server-wide settings applied to every execution
+type Config struct {
+	// MaxMemoryPages caps guest linear memory in 64 KiB pages, 0 leaves WasmEdge's default.
+	// Requests may lower it through max_memory_pages but never raise it.
+	MaxMemoryPages uint32
+
+	// MaxTimeout caps the wall-clock time of an execution, module loading and AOT compilation included.
+	// 0 uses DefaultMaxTimeout. Requests may lower it through timeout_ms but never raise it.
+	MaxTimeout time.Duration
+
+	// DefaultGasLimit is the gas budget of executions without gas_limit, 0 uses MaxGasLimit.
+	DefaultGasLimit uint64
+
+	// MaxGasLimit caps the gas budget of an execution, 0 leaves it uncapped.
+	// Requests may lower it through gas_limit but never raise it.
+	MaxGasLimit uint64
+
+	// AOTCacheDir enables AOT execution, caching compiled modules in this directory.
+	// When empty every module is interpreted. Only registered modules are compiled, inline bytecode is interpreted.
+	AOTCacheDir string
+
+	// AOTCacheMaxBytes caps the disk space of compiled modules, the least recently used are removed beyond it.
+	// 0 uses DefaultAOTCacheMaxBytes.
+	AOTCacheMaxBytes int64
+
+	// ModuleStoreDir persists uploaded modules in this directory.
+	// When empty modules are kept in memory and lost on restart.
+	ModuleStoreDir string
+
+	// VerifyBundle holds the AMD certificates used by VerifyExecution.
+	// When nil attestations must carry their own chain, checked against the embedded AMD roots.
+	VerifyBundle *verify.Bundle
+
+	// Attester produces the TEE evidence attached to execution results.
+	// When nil the server requests SEV-SNP attestation reports.
+	Attester Attester
+
+	// Egress restricts the destinations guest code can reach through fetch and http.
+	// When nil only public addresses over http and https are allowed.
+	Egress *EgressConfig
+
+	// HttpLimits bounds the bodies and headers of fetch and http calls.
+	// Limits left at zero, or a nil value, use DefaultHttpLimits.
+	HttpLimits *HttpLimits
+
+	// TLS holds the CA bundles, client certificates and SPKI pins of fetch and http calls.
+	// When nil requests verify against the system roots and cannot use mTLS.
+	TLS *TLSConfig
+
+	// SecretStoreDir persists the sealed secrets uploaded through PutSecret in this directory.
+	// When empty secrets are kept in memory and lost on restart.
+	SecretStoreDir string
+
+	// ReceiptsOnly skips the attestation of each execution, results are only signed with the receipt key.
+	// Clients then verify the key once through GetAttestedKey and each result by its receipt signature.
+	ReceiptsOnly bool
+
+	// BatchWindow enables batch attestation: executions finishing within the window share one attestation
+	// of the Merkle root of their report data, and each result carries its inclusion proof.
+	// When zero every execution is attested on its own. Ignored with ReceiptsOnly.
+	BatchWindow time.Duration
+
+	// BatchMaxSize attests a batch as soon as it holds this many executions, 0 leaves batches bounded by BatchWindow only.
+	BatchMaxSize int
+
+	// MaxClockSkew rejects executions whose timestamp, in Unix seconds, is missing or further than this from the server clock.
+	// When zero timestamps are not checked.
+	MaxClockSkew time.Duration
+
+	// RequireNonce rejects executions without a nonce, so every attested result answers a client challenge.
+	RequireNonce bool
+
+	// IdempotencyTTL caches results by request_id for this long: a retried execution gets the cached result instead of
+	// running again, and a request_id reused for another execution is rejected. When zero nothing is cached.
+	IdempotencyTTL time.Duration
+
+	// IdempotencyMaxEntries bounds the cached results, beyond it the oldest are evicted before their ttl.
+	// 0 uses DefaultIdempotencyMaxEntries.
+	IdempotencyMaxEntries int
+
+	// MaxConcurrentExecutions bounds the WasmEdge VMs running at once, 0 uses the number of CPUs.
+	// Executions beyond it wait in a queue shared fairly between clients, see ExecutionQueueSize.
+	MaxConcurrentExecutions int
+
+	// ExecutionQueueSize bounds the executions waiting for a worker, beyond it Execute fails with RESOURCE_EXHAUSTED.
+	// 0 uses DefaultExecutionQueueSize. Submitted jobs wait in the job queue instead and are never rejected here.
+	ExecutionQueueSize int
+
+	// MaxBatchItems bounds the input sets of an ExecuteBatch request, 0 uses DefaultMaxBatchItems.
+	MaxBatchItems int
+
+	// JobWorkers bounds the executions submitted through SubmitExecution that run at once, 0 uses the number of CPUs.
+	JobWorkers int
+
+	// JobQueueSize bounds the submitted executions waiting for a worker, 0 uses DefaultJobQueueSize.
+	JobQueueSize int
+
+	// JobRetention is how long finished jobs and their results can be retrieved, 0 uses DefaultJobRetention.
+	JobRetention time.Duration
+
+	// JobMaxRetained bounds the finished jobs kept, beyond it the oldest are dropped before their retention ends.
+	// 0 uses DefaultJobMaxRetained.
+	JobMaxRetained int
+}
@@ -0,0 +1,345 @@
+package wasm
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Rules reported in EgressError.Rule
+const (
+	EgressRuleScheme  = "scheme"
+	EgressRuleMethod  = "method"
+	EgressRulePort    = "port"
+	EgressRuleHost    = "host"
+	EgressRuleAddress = "address"
+)
+
+// EgressError describes a request rejected by an EgressPolicy
+type EgressError struct {
+	Rule  string `json:"rule"`  // The rule that rejected the request, one of the EgressRule constants
+	Value string `json:"value"` // The offending scheme, method, port, host or address
+}
+
+func (e *EgressError) Error() string {
+	return fmt.Sprintf("egress policy denies %s %q", e.Rule, e.Value)
+}
+
+// EgressPolicy restricts the destinations guest code can reach through the fetch and http host functions
+// Empty lists place no restriction, except that addresses default to publicly routable ones
+type EgressPolicy struct {
+	// AllowedSchemes lists the permitted URL schemes, defaults to http and https
+	AllowedSchemes []string `json:"allowed_schemes,omitempty"`
+	// AllowedMethods lists the permitted HTTP methods
+	AllowedMethods []string `json:"allowed_methods,omitempty"`
+	// AllowedPorts lists the permitted destination ports
+	AllowedPorts []int `json:"allowed_ports,omitempty"`
+	// AllowedHosts lists the permitted host names, "*.example.com" matches any subdomain of example.com
+	AllowedHosts []string `json:"allowed_hosts,omitempty"`
+	// DeniedHosts lists rejected host names, taking precedence over AllowedHosts
+	DeniedHosts []string `json:"denied_hosts,omitempty"`
+	// AllowedCIDRs lists the address blocks connections may reach, replacing the public-only default.
+	// Use it to grant access to private networks.
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"`
+	// DeniedCIDRs lists address blocks that are never reached, taking precedence over AllowedCIDRs
+	DeniedCIDRs []string `json:"denied_cidrs,omitempty"`
+}
+
+// defaultEgressPolicy applies when no policy is configured: public destinations over http and https
+var defaultEgressPolicy = &EgressPolicy{}
+
+// EgressConfig is a server-wide egress policy with per-module overrides
+type EgressConfig struct {
+	// Default applies to every module without an override
+	Default *EgressPolicy `json:"default,omitempty"`
+	// Modules maps module hashes to a policy replacing Default for that module
+	Modules map[string]*EgressPolicy `json:"modules,omitempty"`
+}
+
+// LoadEgressConfig reads an EgressConfig from a JSON file
+func LoadEgressConfig(path string) (*EgressConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read egress policy: %w", err)
+	}
+
+	config := &EgressConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse egress policy: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Validate checks every policy of the configuration and normalizes module hashes
+func (c *EgressConfig) Validate() error {
+	if c.Default != nil {
+		if err := c.Default.Validate(); err != nil {
+			return fmt.Errorf("default egress policy: %v", err)
+		}
+	}
+
+	modules := make(map[string]*EgressPolicy, len(c.Modules))
+	for hash, policy := range c.Modules {
+		normalized, err := normalizeModuleHash(hash)
+		if err != nil {
+			return fmt.Errorf("egress policy: %w", err)
+		}
+		if policy == nil {
+			policy = &EgressPolicy{}
+		}
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("egress policy of module %s: %v", normalized, err)
+		}
+		modules[normalized] = policy
+	}
+	c.Modules = modules
+
+	return nil
+}
+
+// PolicyFor returns the policy applying to the module with the given hash
+func (c *EgressConfig) PolicyFor(moduleHash string) *EgressPolicy {
+	if c == nil {
+		return defaultEgressPolicy
+	}
+	if policy, ok := c.Modules[strings.ToLower(moduleHash)]; ok {
+		return policy
+	}
+	if c.Default != nil {
+		return c.Default
+	}
+
+	return defaultEgressPolicy
+}
+
+// Validate checks that the CIDR lists of the policy parse
+func (p *EgressPolicy) Validate() error {
+	for _, cidr := range slices.Concat(p.AllowedCIDRs, p.DeniedCIDRs) {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %v", cidr, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckRequest validates the parts of a request known before the destination is resolved
+func (p *EgressPolicy) CheckRequest(method string, target *url.URL) error {
+	scheme := strings.ToLower(target.Scheme)
+	schemes := p.AllowedSchemes
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https"}
+	}
+	if !containsFold(schemes, scheme) {
+		return &EgressError{Rule: EgressRuleScheme, Value: scheme}
+	}
+
+	if len(p.AllowedMethods) > 0 && !containsFold(p.AllowedMethods, method) {
+		return &EgressError{Rule: EgressRuleMethod, Value: method}
+	}
+
+	port := target.Port()
+	if port == "" {
+		port = defaultPort(scheme)
+	}
+	if len(p.AllowedPorts) > 0 {
+		n, err := strconv.Atoi(port)
+		if err != nil || !slices.Contains(p.AllowedPorts, n) {
+			return &EgressError{Rule: EgressRulePort, Value: port}
+		}
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(target.Hostname()), ".")
+	if matchesHost(p.DeniedHosts, host) {
+		return &EgressError{Rule: EgressRuleHost, Value: host}
+	}
+	if len(p.AllowedHosts) > 0 && !matchesHost(p.AllowedHosts, host) {
+		return &EgressError{Rule: EgressRuleHost, Value: host}
+	}
+
+	return nil
+}
+
+// CheckAddress validates a resolved destination address
+func (p *EgressPolicy) CheckAddress(addr netip.Addr) error {
+	addr = addr.Unmap()
+
+	if containsAddress(p.DeniedCIDRs, addr) {
+		return &EgressError{Rule: EgressRuleAddress, Value: addr.String()}
+	}
+
+	if len(p.AllowedCIDRs) > 0 {
+		if containsAddress(p.AllowedCIDRs, addr) {
+			return nil
+		}
+		return &EgressError{Rule: EgressRuleAddress, Value: addr.String()}
+	}
+
+	if !isPublicAddress(addr) {
+		return &EgressError{Rule: EgressRuleAddress, Value: addr.String()}
+	}
+
+	return nil
+}
+
+// Client returns an HTTP client enforcing the policy
+// Addresses are checked right before connecting, after DNS resolution, so a host name
+// re-resolving to an internal address (DNS rebinding) is still rejected. Redirects are
+// checked like the original request and environment proxies are ignored. tlsConfig, when
+// not nil, returns the TLS configuration of each connection by host name.
+// A client is built for every host call, so its connections are closed after each response
+// instead of being kept idle by a transport nobody reuses.
+func (p *EgressPolicy) Client(timeout time.Duration, tlsConfig func(host string) *tls.Config) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return &EgressError{Rule: EgressRuleAddress, Value: address}
+			}
+			return p.CheckAddress(addrPort.Addr())
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy: nil,
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		},
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: 10 * time.Second,
+		DisableKeepAlives:   true,
+	}
+	if tlsConfig != nil {
+		transport.DialTLSContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return nil, err
+			}
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil {
+				return nil, err
+			}
+
+			handshakeCtx, cancel := context.WithTimeout(ctx, transport.TLSHandshakeTimeout)
+			defer cancel()
+			tlsConn := tls.Client(conn, tlsConfig(host))
+			if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return p.CheckRequest(req.Method, req.URL)
+		},
+	}
+}
+
+// egressViolation extracts the policy violation from an HTTP client error
+func egressViolation(err error) *EgressError {
+	var egressErr *EgressError
+	if errors.As(err, &egressErr) {
+		return egressErr
+	}
+	return nil
+}
+
+// isPublicAddress reports whether addr is globally routable
+// Loopback, private, link-local (including cloud metadata endpoints), CGNAT, multicast
+// and unspecified addresses are not
+func isPublicAddress(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nonPublicPrefixes lists special-purpose blocks not covered by the netip predicates
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, embeds any IPv4 address including private ones
+	netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4, embeds any IPv4 address including private ones
+	netip.MustParsePrefix("100::/64"),
+}
+
+// containsAddress reports whether addr lies in one of the CIDR blocks, invalid blocks are skipped
+func containsAddress(cidrs []string, addr netip.Addr) bool {
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Masked().Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesHost reports whether host matches one of the patterns
+// A pattern matches the host exactly, "*.example.com" matches any subdomain of example.com
+func matchesHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.ToLower(pattern), ".")
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+func defaultPort(scheme string) string {
+	switch scheme {
+	case "https":
+		return "443"
+	case "http":
+		return "80"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,241 @@
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestEgressCheckRequest - Verifies scheme, method, port and host rules
+func TestEgressCheckRequest(t *testing.T) {
+	policy := &EgressPolicy{
+		AllowedMethods: []string{"GET"},
+		AllowedPorts:   []int{443, 8443},
+		AllowedHosts:   []string{"api.example.com", "*.googleapis.com"},
+		DeniedHosts:    []string{"secret.googleapis.com"},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		rule   string
+	}{
+		{name: "allowed", method: "GET", url: "https://api.example.com/v1"},
+		{name: "wildcard", method: "get", url: "https://storage.googleapis.com:8443/b"},
+		{name: "scheme", method: "GET", url: "file:///etc/passwd", rule: EgressRuleScheme},
+		{name: "method", method: "POST", url: "https://api.example.com/v1", rule: EgressRuleMethod},
+		{name: "port", method: "GET", url: "http://api.example.com/v1", rule: EgressRulePort},
+		{name: "host", method: "GET", url: "https://example.com/", rule: EgressRuleHost},
+		{name: "wildcard_apex", method: "GET", url: "https://googleapis.com/", rule: EgressRuleHost},
+		{name: "denied_host", method: "GET", url: "https://SECRET.googleapis.com./", rule: EgressRuleHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+
+			err = policy.CheckRequest(tt.method, target)
+			if tt.rule == "" {
+				if err != nil {
+					t.Errorf("Expected request to be allowed, got %v", err)
+				}
+				return
+			}
+			if violation := egressViolation(err); violation == nil || violation.Rule != tt.rule {
+				t.Errorf("Expected %s violation, got %v", tt.rule, err)
+			}
+		})
+	}
+}
+
+// TestEgressCheckAddress - Verifies the public-only default and the CIDR lists
+func TestEgressCheckAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *EgressPolicy
+		addr    string
+		allowed bool
+	}{
+		{name: "public", policy: defaultEgressPolicy, addr: "8.8.8.8", allowed: true},
+		{name: "public_v6", policy: defaultEgressPolicy, addr: "2001:4860:4860::8888", allowed: true},
+		{name: "metadata", policy: defaultEgressPolicy, addr: "169.254.169.254"},
+		{name: "loopback", policy: defaultEgressPolicy, addr: "127.0.0.1"},
+		{name: "private", policy: defaultEgressPolicy, addr: "10.1.2.3"},
+		{name: "cgnat", policy: defaultEgressPolicy, addr: "100.64.0.1"},
+		{name: "unspecified", policy: defaultEgressPolicy, addr: "0.0.0.0"},
+		{name: "mapped_loopback", policy: defaultEgressPolicy, addr: "::ffff:127.0.0.1"},
+		{name: "unique_local", policy: defaultEgressPolicy, addr: "fd00::1"},
+		{name: "nat64_metadata", policy: defaultEgressPolicy, addr: "64:ff9b::a9fe:a9fe"},
+		{name: "6to4_loopback", policy: defaultEgressPolicy, addr: "2002:7f00:1::1"},
+		{name: "allowed_cidr", policy: &EgressPolicy{AllowedCIDRs: []string{"10.0.0.0/8"}}, addr: "10.1.2.3", allowed: true},
+		{name: "outside_allowed_cidr", policy: &EgressPolicy{AllowedCIDRs: []string{"10.0.0.0/8"}}, addr: "8.8.8.8"},
+		{
+			name:   "denied_wins",
+			policy: &EgressPolicy{AllowedCIDRs: []string{"10.0.0.0/8"}, DeniedCIDRs: []string{"10.1.0.0/16"}},
+			addr:   "10.1.2.3",
+		},
+		{name: "denied_public", policy: &EgressPolicy{DeniedCIDRs: []string{"8.8.8.0/24"}}, addr: "8.8.8.8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.CheckAddress(netip.MustParseAddr(tt.addr))
+			if tt.allowed && err != nil {
+				t.Errorf("Expected %s to be allowed, got %v", tt.addr, err)
+			}
+			if !tt.allowed && egressViolation(err) == nil {
+				t.Errorf("Expected %s to be denied, got %v", tt.addr, err)
+			}
+		})
+	}
+}
+
+// TestEgressResolvedAddress - Verifies that addresses are enforced when connecting, after DNS resolution
+func TestEgressResolvedAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "internal")
+	}))
+	defer server.Close()
+
+	// localhost passes every host rule and is only caught once it resolves to loopback
+	target := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	request, _ := json.Marshal(HttpRequest{Method: "GET", URL: target})
+
+	respJSON, exchange := performHttpRequest(context.Background(), httpOptions{egress: defaultEgressPolicy, limits: DefaultHttpLimits}, string(request))
+	if exchange != nil {
+		t.Errorf("Expected rejected request to stay out of the transcript, got %v", exchange)
+	}
+	var response HttpResponse
+	if err := json.Unmarshal(respJSON, &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Violation == nil || response.Violation.Rule != EgressRuleAddress {
+		t.Fatalf("Expected address violation, got %+v", response)
+	}
+	if response.Error == "" || response.StatusCode != 0 {
+		t.Errorf("Expected error response, got %+v", response)
+	}
+
+	if body, _ := fetch(context.Background(), httpOptions{egress: defaultEgressPolicy, limits: DefaultHttpLimits}, target); body != nil {
+		t.Errorf("Expected fetch to be rejected, got %q", body)
+	}
+
+	allowLoopback := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8", "::1/128"}}
+	respJSON, _ = performHttpRequest(context.Background(), httpOptions{egress: allowLoopback, limits: DefaultHttpLimits}, string(request))
+	response = HttpResponse{}
+	if err := json.Unmarshal(respJSON, &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || response.Body != "internal" || response.Violation != nil {
+		t.Errorf("Expected request to succeed, got %+v", response)
+	}
+}
+
+// TestEgressRedirect - Verifies that redirect targets are checked like the original request
+func TestEgressRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://blocked.example.com/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	policy := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}, DeniedHosts: []string{"blocked.example.com"}}
+	request, _ := json.Marshal(HttpRequest{URL: server.URL})
+
+	respJSON, _ := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits}, string(request))
+	var response HttpResponse
+	if err := json.Unmarshal(respJSON, &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Violation == nil || response.Violation.Rule != EgressRuleHost || response.Violation.Value != "blocked.example.com" {
+		t.Errorf("Expected host violation, got %+v", response)
+	}
+}
+
+// TestEgressConnectionsClosed - Verifies that host calls do not leave idle connections behind
+func TestEgressConnectionsClosed(t *testing.T) {
+	var opened, closed atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			opened.Add(1)
+		case http.StateClosed:
+			closed.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	opts := httpOptions{egress: &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}}, limits: DefaultHttpLimits}
+	request, _ := json.Marshal(HttpRequest{URL: server.URL})
+	for i := 0; i < 3; i++ {
+		performHttpRequest(context.Background(), opts, string(request))
+		if body, _ := fetch(context.Background(), opts, server.URL); string(body) != "ok" {
+			t.Fatalf("Expected fetch to succeed, got %q", body)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for closed.Load() != opened.Load() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected every connection to be closed, %d of %d are", closed.Load(), opened.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if opened.Load() != 6 {
+		t.Errorf("Expected a connection per call, got %d", opened.Load())
+	}
+}
+
+// TestLoadEgressConfig - Verifies module overrides and policy validation
+func TestLoadEgressConfig(t *testing.T) {
+	hash := ModuleHash([]byte("module"))
+	path := filepath.Join(t.TempDir(), "egress.json")
+	config := fmt.Sprintf(`{
+		"default": {"allowed_hosts": ["api.example.com"]},
+		"modules": {%q: {"allowed_cidrs": ["10.0.0.0/8"]}}
+	}`, strings.ToUpper(hash))
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+
+	egress, err := LoadEgressConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+	if policy := egress.PolicyFor(hash); len(policy.AllowedCIDRs) != 1 {
+		t.Errorf("Expected module override, got %+v", policy)
+	}
+	if policy := egress.PolicyFor(ModuleHash([]byte("other"))); len(policy.AllowedHosts) != 1 {
+		t.Errorf("Expected default policy, got %+v", policy)
+	}
+
+	var empty *EgressConfig
+	if policy := empty.PolicyFor(hash); policy != defaultEgressPolicy {
+		t.Errorf("Expected built-in default, got %+v", policy)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"default": {"denied_cidrs": ["10.0.0.0/33"]}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	if _, err := LoadEgressConfig(path); err == nil {
+		t.Errorf("Expected invalid CIDR to be rejected")
+	}
+}
@@ -0,0 +1,85 @@
+package wasm
+
+import (
+	"strings"
+	"time"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// gasCheckpointInterval is the minimum period of the gas checkpoints sent to ExecuteOptions.Events
+const gasCheckpointInterval = time.Second
+
+// maxLogLineBytes bounds a guest log line, longer lines are cut
+const maxLogLineBytes = 4096
+
+// emit sends an event to ExecuteOptions.Events, if set
+func (h *host) emit(event *types.ExecutionEvent) {
+	if h.events != nil {
+		h.events(event)
+	}
+}
+
+// checkpoint sends a gas checkpoint if the last one is at least gasCheckpointInterval old
+// WasmEdge's statistics are not synchronized, so checkpoints are only taken from host functions: the guest
+// is suspended in the call on the thread that updates them. A guest that never calls the host reports none.
+func (h *host) checkpoint() {
+	if h.events == nil || time.Since(h.lastCheckpoint) < gasCheckpointInterval {
+		return
+	}
+	h.lastCheckpoint = time.Now()
+
+	h.emit(&types.ExecutionEvent{Event: &types.ExecutionEvent_GasCheckpoint{GasCheckpoint: &types.GasCheckpoint{
+		GasUsed:   uint64(h.stat.GetTotalCost() - h.gasBefore),
+		GasLimit:  h.gasLimit,
+		ElapsedMs: uint64(time.Since(h.started).Milliseconds()),
+	}}})
+}
+
+// Host function writing a log line: log(ptr, len) -> 0 or resultErrOutOfBounds
+// Lines are only delivered to ExecuteOptions.Events, they are not part of the result and not attested
+func (h *host) log(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	if h.stopped.Load() {
+		return nil, wasmedge.Result_Terminate
+	}
+	if h.events == nil {
+		return []any{int32(0)}, wasmedge.Result_Success
+	}
+	h.checkpoint()
+
+	size := uint32(params[1].(int32))
+	truncated := size > maxLogLineBytes
+	if truncated {
+		size = maxLogLineBytes
+	}
+	line, err := readGuestMemory(callframe, uint32(params[0].(int32)), size)
+	if err != nil {
+		return []any{resultErrOutOfBounds}, wasmedge.Result_Success
+	}
+
+	h.emit(&types.ExecutionEvent{Event: &types.ExecutionEvent_Log{Log: &types.GuestLog{
+		// Protobuf strings must be valid UTF-8, and a cut line may end within a character
+		Message:   strings.ToValidUTF8(string(line), "�"),
+		Truncated: truncated,
+	}}})
+
+	return []any{int32(0)}, wasmedge.Result_Success
+}
+
+// httpCallStarted builds the event of a host HTTP call about to be sent
+// fetch requests are plain URLs, http requests are JSON unless they are legacy plain URLs as well
+func httpCallStarted(index uint32, call string, request string) *types.ExecutionEvent {
+	started := &types.HttpCallStarted{Index: index, Call: call, Method: "GET", Url: request}
+	if call == ReplayCallHttp {
+		if decoded, _, err := decodeHttpRequest([]byte(request)); err == nil {
+			started.Url = decoded.URL
+			if decoded.Method != "" {
+				started.Method = decoded.Method
+			}
+		}
+	}
+
+	return &types.ExecutionEvent{Event: &types.ExecutionEvent_HttpCallStarted{HttpCallStarted: started}}
+}
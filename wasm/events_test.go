@@ -0,0 +1,68 @@
+package wasm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestHttpCallStarted - Verifies the method and URL reported for each kind of host HTTP call
+func TestHttpCallStarted(t *testing.T) {
+	tests := []struct {
+		name           string
+		call           string
+		request        string
+		expectedMethod string
+		expectedURL    string
+	}{
+		{"fetch", ReplayCallFetch, "https://example.com/a", "GET", "https://example.com/a"},
+		{"http_v1", ReplayCallHttp, `{"method":"POST","url":"https://example.com/b"}`, "POST", "https://example.com/b"},
+		{"http_v2", ReplayCallHttp, `{"version":2,"url":"https://example.com/c"}`, "GET", "https://example.com/c"},
+		{"http_invalid", ReplayCallHttp, `{"version":9}`, "GET", `{"version":9}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := httpCallStarted(3, tt.call, tt.request)
+			started, ok := event.Event.(*types.ExecutionEvent_HttpCallStarted)
+			if !ok {
+				t.Fatalf("Unexpected event %v", event)
+			}
+			if started.HttpCallStarted.Index != 3 || started.HttpCallStarted.Call != tt.call ||
+				started.HttpCallStarted.Method != tt.expectedMethod || started.HttpCallStarted.Url != tt.expectedURL {
+				t.Errorf("Unexpected event %v", started.HttpCallStarted)
+			}
+		})
+	}
+}
+
+// TestGasCheckpoint - Verifies that host calls send gas checkpoints at most once per interval
+func TestGasCheckpoint(t *testing.T) {
+	var checkpoints []*types.GasCheckpoint
+	h := &host{
+		stat:     wasmedge.NewStatistics(),
+		gasLimit: 1000,
+		events: func(event *types.ExecutionEvent) {
+			checkpoints = append(checkpoints, event.GetGasCheckpoint())
+		},
+	}
+	h.started, h.lastCheckpoint = time.Now().Add(-2*gasCheckpointInterval), time.Now()
+
+	h.checkpoint()
+	if len(checkpoints) != 0 {
+		t.Fatalf("Expected no checkpoint within the interval, got %v", checkpoints)
+	}
+
+	h.lastCheckpoint = time.Now().Add(-gasCheckpointInterval)
+	h.checkpoint()
+	h.checkpoint()
+	if len(checkpoints) != 1 {
+		t.Fatalf("Expected one checkpoint, got %v", checkpoints)
+	}
+	if checkpoints[0].GasLimit != 1000 || checkpoints[0].ElapsedMs < uint64((2*gasCheckpointInterval).Milliseconds()) {
+		t.Errorf("Unexpected checkpoint %v", checkpoints[0])
+	}
+}
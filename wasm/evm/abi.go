@@ -0,0 +1,401 @@
+// Package evm encodes execution results for EVM contracts.
+//
+// Outputs are ABI encoded together with the request id, the module hash and the report data
+// digest, hashed with keccak256 following EIP-191 or EIP-712 and signed by the receipt key.
+// Like reportdata it does not depend on the WasmEdge runtime, see docs/evm.md.
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// ErrInvalidABI is returned for unsupported ABI types and values that do not fit their type
+var ErrInvalidABI = errors.New("invalid ABI encoding")
+
+// kind is the family of an ABI type
+type kind int
+
+const (
+	kindUint kind = iota
+	kindInt
+	kindBool
+	kindAddress
+	kindFixedBytes
+	kindBytes
+	kindString
+	kindArray
+)
+
+// abiType is a parsed ABI type, arrays are dynamic arrays of elem
+type abiType struct {
+	kind kind
+	size int // Bits of integers, length of fixed bytes
+	elem *abiType
+}
+
+// dynamic reports whether values of the type are encoded in the tail
+func (t abiType) dynamic() bool {
+	return t.kind == kindBytes || t.kind == kindString || t.kind == kindArray
+}
+
+// parseType parses the ABI types outputs can be encoded as
+// Integers (uintN, intN), bool, address, bytesN, bytes, string and dynamic arrays T[] of integers
+// and bool are supported.
+func parseType(s string) (abiType, error) {
+	if elem, ok := strings.CutSuffix(s, "[]"); ok {
+		t, err := parseType(elem)
+		if err != nil {
+			return abiType{}, err
+		}
+		if t.kind != kindUint && t.kind != kindInt && t.kind != kindBool {
+			return abiType{}, fmt.Errorf("%w: unsupported array type %q", ErrInvalidABI, s)
+		}
+		return abiType{kind: kindArray, elem: &t}, nil
+	}
+
+	switch s {
+	case "bool":
+		return abiType{kind: kindBool}, nil
+	case "address":
+		return abiType{kind: kindAddress}, nil
+	case "bytes":
+		return abiType{kind: kindBytes}, nil
+	case "string":
+		return abiType{kind: kindString}, nil
+	case "uint", "int":
+		return parseType(s + "256")
+	}
+
+	for _, prefix := range []struct {
+		name string
+		kind kind
+		max  int
+		step int
+	}{{"uint", kindUint, 256, 8}, {"int", kindInt, 256, 8}, {"bytes", kindFixedBytes, 32, 1}} {
+		digits, ok := strings.CutPrefix(s, prefix.name)
+		if !ok {
+			continue
+		}
+		size, err := strconv.Atoi(digits)
+		if err != nil || size <= 0 || size > prefix.max || size%prefix.step != 0 || digits[0] == '0' {
+			break
+		}
+		return abiType{kind: prefix.kind, size: size}, nil
+	}
+
+	return abiType{}, fmt.Errorf("%w: unsupported type %q", ErrInvalidABI, s)
+}
+
+// ValidateTypes checks that every type can be used with EncodeOutputs
+func ValidateTypes(abiTypes []string) error {
+	for _, s := range abiTypes {
+		if _, err := parseType(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OutputTypes returns the ABI types of the outputs
+// Declared types are returned as is, when none are declared each output gets the type matching its
+// WasmValue: intN and uintN at their width, bool, string, bytes and arrays of those integers.
+func OutputTypes(declared []string, outputs []*types.WasmValue) ([]string, error) {
+	if len(declared) > 0 {
+		if len(declared) != len(outputs) {
+			return nil, fmt.Errorf("%w: %d output types for %d outputs", ErrInvalidABI, len(declared), len(outputs))
+		}
+		return declared, nil
+	}
+
+	abiTypes := make([]string, len(outputs))
+	for i, output := range outputs {
+		switch output.GetValue().(type) {
+		case *types.WasmValue_BoolValue:
+			abiTypes[i] = "bool"
+		case *types.WasmValue_Int8Value:
+			abiTypes[i] = "int8"
+		case *types.WasmValue_Uint8Value:
+			abiTypes[i] = "uint8"
+		case *types.WasmValue_Int16Value:
+			abiTypes[i] = "int16"
+		case *types.WasmValue_Uint16Value:
+			abiTypes[i] = "uint16"
+		case *types.WasmValue_Int32Value:
+			abiTypes[i] = "int32"
+		case *types.WasmValue_Uint32Value:
+			abiTypes[i] = "uint32"
+		case *types.WasmValue_Int64Value:
+			abiTypes[i] = "int64"
+		case *types.WasmValue_Uint64Value:
+			abiTypes[i] = "uint64"
+		case *types.WasmValue_StringValue:
+			abiTypes[i] = "string"
+		case *types.WasmValue_BytesValue:
+			abiTypes[i] = "bytes"
+		case *types.WasmValue_Int8Array:
+			abiTypes[i] = "int8[]"
+		case *types.WasmValue_Uint16Array:
+			abiTypes[i] = "uint16[]"
+		case *types.WasmValue_Int16Array:
+			abiTypes[i] = "int16[]"
+		case *types.WasmValue_Uint32Array:
+			abiTypes[i] = "uint32[]"
+		case *types.WasmValue_Int32Array:
+			abiTypes[i] = "int32[]"
+		case *types.WasmValue_Uint64Array:
+			abiTypes[i] = "uint64[]"
+		case *types.WasmValue_Int64Array:
+			abiTypes[i] = "int64[]"
+		default:
+			return nil, fmt.Errorf("%w: output %d has no ABI type, declare one", ErrInvalidABI, i)
+		}
+	}
+
+	return abiTypes, nil
+}
+
+// EncodeOutputs returns abi.encode of the outputs as the given types
+//
+// Integers accept any integer WasmValue in range, and strings holding a decimal or 0x prefixed hex
+// number so guests can return values wider than 64 bits. address accepts a 0x prefixed hex string
+// or 20 bytes, bytesN exactly N bytes. Arrays of integers accept the integer array WasmValues.
+func EncodeOutputs(abiTypes []string, outputs []*types.WasmValue) ([]byte, error) {
+	if len(abiTypes) != len(outputs) {
+		return nil, fmt.Errorf("%w: %d output types for %d outputs", ErrInvalidABI, len(abiTypes), len(outputs))
+	}
+
+	parts := make([]part, len(outputs))
+	for i, output := range outputs {
+		t, err := parseType(abiTypes[i])
+		if err != nil {
+			return nil, err
+		}
+		data, err := encodeValue(t, output)
+		if err != nil {
+			return nil, fmt.Errorf("output %d as %s: %w", i, abiTypes[i], err)
+		}
+		parts[i] = part{dynamic: t.dynamic(), data: data}
+	}
+
+	return encodeTuple(parts), nil
+}
+
+// encodeValue encodes a single value, without the offset of dynamic types
+func encodeValue(t abiType, v *types.WasmValue) ([]byte, error) {
+	switch t.kind {
+	case kindUint, kindInt:
+		n, err := integer(v)
+		if err != nil {
+			return nil, err
+		}
+		return encodeInteger(t, n)
+	case kindBool:
+		b, ok := v.GetValue().(*types.WasmValue_BoolValue)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a bool", ErrInvalidABI)
+		}
+		return encodeBool(b.BoolValue), nil
+	case kindAddress:
+		address, err := addressValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return leftPad(address[:]), nil
+	case kindFixedBytes:
+		b, ok := v.GetValue().(*types.WasmValue_BytesValue)
+		if !ok || len(b.BytesValue) != t.size {
+			return nil, fmt.Errorf("%w: expected %d bytes", ErrInvalidABI, t.size)
+		}
+		return rightPad(b.BytesValue), nil
+	case kindBytes:
+		b, ok := v.GetValue().(*types.WasmValue_BytesValue)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected bytes", ErrInvalidABI)
+		}
+		return encodeBytes(b.BytesValue), nil
+	case kindString:
+		s, ok := v.GetValue().(*types.WasmValue_StringValue)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a string", ErrInvalidABI)
+		}
+		return encodeBytes([]byte(s.StringValue)), nil
+	case kindArray:
+		return encodeArray(*t.elem, v)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type", ErrInvalidABI)
+	}
+}
+
+// encodeArray encodes an integer array WasmValue as a dynamic array of elem
+func encodeArray(elem abiType, v *types.WasmValue) ([]byte, error) {
+	var values []*big.Int
+	switch array := v.GetValue().(type) {
+	case *types.WasmValue_Int8Array:
+		values = int64s(array.Int8Array.GetValues())
+	case *types.WasmValue_Int16Array:
+		values = int64s(array.Int16Array.GetValues())
+	case *types.WasmValue_Int32Array:
+		values = int64s(array.Int32Array.GetValues())
+	case *types.WasmValue_Int64Array:
+		values = int64s(array.Int64Array.GetValues())
+	case *types.WasmValue_Uint16Array:
+		values = uint64s(array.Uint16Array.GetValues())
+	case *types.WasmValue_Uint32Array:
+		values = uint64s(array.Uint32Array.GetValues())
+	case *types.WasmValue_Uint64Array:
+		values = uint64s(array.Uint64Array.GetValues())
+	default:
+		return nil, fmt.Errorf("%w: expected an integer array", ErrInvalidABI)
+	}
+
+	out := encodeUint(uint64(len(values)))
+	for i, n := range values {
+		var word []byte
+		var err error
+		if elem.kind == kindBool {
+			if n.Sign() != 0 && n.Cmp(big.NewInt(1)) != 0 {
+				return nil, fmt.Errorf("%w: element %d is not 0 or 1", ErrInvalidABI, i)
+			}
+			word = encodeBool(n.Sign() != 0)
+		} else if word, err = encodeInteger(elem, n); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out = append(out, word...)
+	}
+
+	return out, nil
+}
+
+// integer converts an integer WasmValue, or a string holding a number, to a big.Int
+func integer(v *types.WasmValue) (*big.Int, error) {
+	switch value := v.GetValue().(type) {
+	case *types.WasmValue_Int8Value:
+		return big.NewInt(int64(value.Int8Value)), nil
+	case *types.WasmValue_Int16Value:
+		return big.NewInt(int64(value.Int16Value)), nil
+	case *types.WasmValue_Int32Value:
+		return big.NewInt(int64(value.Int32Value)), nil
+	case *types.WasmValue_Int64Value:
+		return big.NewInt(value.Int64Value), nil
+	case *types.WasmValue_Uint8Value:
+		return new(big.Int).SetUint64(uint64(value.Uint8Value)), nil
+	case *types.WasmValue_Uint16Value:
+		return new(big.Int).SetUint64(uint64(value.Uint16Value)), nil
+	case *types.WasmValue_Uint32Value:
+		return new(big.Int).SetUint64(uint64(value.Uint32Value)), nil
+	case *types.WasmValue_Uint64Value:
+		return new(big.Int).SetUint64(value.Uint64Value), nil
+	case *types.WasmValue_StringValue:
+		// Base 0 accepts decimal and 0x prefixed hex, "_" separators are not valid input
+		n, ok := new(big.Int).SetString(value.StringValue, 0)
+		if !ok || strings.Contains(value.StringValue, "_") {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidABI, value.StringValue)
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: expected an integer", ErrInvalidABI)
+	}
+}
+
+// addressValue converts a 0x prefixed hex string or 20 bytes to an address
+func addressValue(v *types.WasmValue) ([20]byte, error) {
+	switch value := v.GetValue().(type) {
+	case *types.WasmValue_StringValue:
+		return ParseAddress(value.StringValue)
+	case *types.WasmValue_BytesValue:
+		if len(value.BytesValue) != 20 {
+			return [20]byte{}, fmt.Errorf("%w: address must be 20 bytes", ErrInvalidABI)
+		}
+		return [20]byte(value.BytesValue), nil
+	default:
+		return [20]byte{}, fmt.Errorf("%w: expected an address", ErrInvalidABI)
+	}
+}
+
+// part is a tuple element, dynamic elements are referenced by offset from the head
+type part struct {
+	dynamic bool
+	data    []byte
+}
+
+// encodeTuple lays out the heads of the parts followed by the tails of the dynamic ones
+func encodeTuple(parts []part) []byte {
+	var head, tail []byte
+	headSize := 32 * len(parts)
+	for _, p := range parts {
+		if p.dynamic {
+			head = append(head, encodeUint(uint64(headSize+len(tail)))...)
+			tail = append(tail, p.data...)
+		} else {
+			head = append(head, p.data...)
+		}
+	}
+	return append(head, tail...)
+}
+
+// encodeInteger encodes n as a two's complement word after checking it fits t
+func encodeInteger(t abiType, n *big.Int) ([]byte, error) {
+	if t.kind == kindUint {
+		if n.Sign() < 0 || n.BitLen() > t.size {
+			return nil, fmt.Errorf("%w: %s does not fit uint%d", ErrInvalidABI, n, t.size)
+		}
+		return n.FillBytes(make([]byte, 32)), nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.size-1))
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, fmt.Errorf("%w: %s does not fit int%d", ErrInvalidABI, n, t.size)
+	}
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+func encodeUint(n uint64) []byte {
+	return new(big.Int).SetUint64(n).FillBytes(make([]byte, 32))
+}
+
+func encodeBool(b bool) []byte {
+	if b {
+		return encodeUint(1)
+	}
+	return encodeUint(0)
+}
+
+// encodeBytes encodes the length of data followed by data padded to a word boundary
+func encodeBytes(data []byte) []byte {
+	return append(encodeUint(uint64(len(data))), rightPad(data)...)
+}
+
+func leftPad(data []byte) []byte {
+	out := make([]byte, 32)
+	copy(out[32-len(data):], data)
+	return out
+}
+
+func rightPad(data []byte) []byte {
+	return append(append([]byte{}, data...), make([]byte, (32-len(data)%32)%32)...)
+}
+
+func int64s[T int32 | int64](values []T) []*big.Int {
+	out := make([]*big.Int, len(values))
+	for i, v := range values {
+		out[i] = big.NewInt(int64(v))
+	}
+	return out
+}
+
+func uint64s[T uint32 | uint64](values []T) []*big.Int {
+	out := make([]*big.Int, len(values))
+	for i, v := range values {
+		out[i] = new(big.Int).SetUint64(uint64(v))
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+func stringValue(s string) *types.WasmValue {
+	return &types.WasmValue{Value: &types.WasmValue_StringValue{StringValue: s}}
+}
+
+func bytesValue(b []byte) *types.WasmValue {
+	return &types.WasmValue{Value: &types.WasmValue_BytesValue{BytesValue: b}}
+}
+
+// TestEncodeOutputs - Checks the encoding against the example of the Solidity ABI specification
+func TestEncodeOutputs(t *testing.T) {
+	// f(uint256,uint32[],bytes10,bytes) called with (0x123, [0x456, 0x789], "1234567890", "Hello, world!")
+	encoded, err := EncodeOutputs([]string{"uint256", "uint32[]", "bytes10", "bytes"}, []*types.WasmValue{
+		stringValue("0x123"),
+		{Value: &types.WasmValue_Uint32Array{Uint32Array: &types.Uint32Array{Values: []uint32{0x456, 0x789}}}},
+		bytesValue([]byte("1234567890")),
+		bytesValue([]byte("Hello, world!")),
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode outputs: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	}, "")
+	if hex.EncodeToString(encoded) != expected {
+		t.Errorf("Unexpected encoding\n got %x\nwant %s", encoded, expected)
+	}
+}
+
+// TestEncodeValues - Checks single values, default types and rejected conversions
+func TestEncodeValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		abiType  string
+		value    *types.WasmValue
+		expected string
+		error    bool
+	}{
+		{name: "int32", abiType: "int32", value: &types.WasmValue{Value: &types.WasmValue_Int32Value{Int32Value: -1}},
+			expected: strings.Repeat("ff", 32)},
+		{name: "uint256_decimal", abiType: "uint256", value: stringValue("1000000000000000000"),
+			expected: strings.Repeat("00", 24) + "0de0b6b3a7640000"},
+		{name: "bool", abiType: "bool", value: &types.WasmValue{Value: &types.WasmValue_BoolValue{BoolValue: true}},
+			expected: strings.Repeat("00", 31) + "01"},
+		{name: "address", abiType: "address", value: stringValue("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+			expected: strings.Repeat("00", 12) + "7e5f4552091a69125d5dfcb7b8c2659029395bdf"},
+		{name: "string", abiType: "string", value: stringValue("hi"),
+			expected: strings.Repeat("00", 31) + "20" + strings.Repeat("00", 31) + "02" + "6869" + strings.Repeat("00", 30)},
+		{name: "negative_uint", abiType: "uint64", value: &types.WasmValue{Value: &types.WasmValue_Int64Value{Int64Value: -1}}, error: true},
+		{name: "overflow", abiType: "int8", value: &types.WasmValue{Value: &types.WasmValue_Int32Value{Int32Value: 128}}, error: true},
+		{name: "not_a_number", abiType: "uint256", value: stringValue("1_000"), error: true},
+		{name: "short_bytes32", abiType: "bytes32", value: bytesValue([]byte{1}), error: true},
+		{name: "float", abiType: "uint256", value: &types.WasmValue{Value: &types.WasmValue_Float64Value{Float64Value: 1.5}}, error: true},
+		{name: "unsupported_type", abiType: "uint7", value: stringValue("1"), error: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := EncodeOutputs([]string{tt.abiType}, []*types.WasmValue{tt.value})
+			if tt.error {
+				if !errors.Is(err, ErrInvalidABI) {
+					t.Errorf("Expected %v, got %v", ErrInvalidABI, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to encode: %v", err)
+			}
+			if hex.EncodeToString(encoded) != tt.expected {
+				t.Errorf("Unexpected encoding\n got %x\nwant %s", encoded, tt.expected)
+			}
+		})
+	}
+
+	abiTypes, err := OutputTypes(nil, []*types.WasmValue{
+		stringValue("hi"),
+		{Value: &types.WasmValue_Uint64Value{Uint64Value: 1}},
+		{Value: &types.WasmValue_Int32Array{Int32Array: &types.Int32Array{}}},
+	})
+	if err != nil || strings.Join(abiTypes, ",") != "string,uint64,int32[]" {
+		t.Errorf("Unexpected default types %v, %v", abiTypes, err)
+	}
+	if _, err := OutputTypes(nil, []*types.WasmValue{{Value: &types.WasmValue_Float32Value{}}}); !errors.Is(err, ErrInvalidABI) {
+		t.Errorf("Expected floats to need a declared type, got %v", err)
+	}
+}
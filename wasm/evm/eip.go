@@ -0,0 +1,155 @@
+package evm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// EIP-712 domain of signed results
+const (
+	DomainName    = "wasmvm-tee"
+	DomainVersion = "1"
+)
+
+// ResultType is the EIP-712 type of a signed result, fields in the order of Result.Encode
+const ResultType = "ExecutionResult(string requestId,bytes32 moduleHash,bytes32 reportDigest,bytes outputs)"
+
+const domainType = "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
+
+// Result is the execution result signed for EVM contracts
+type Result struct {
+	RequestID    string   // Request id of the execution
+	ModuleHash   [32]byte // SHA-256 of the bytecode
+	ReportDigest [32]byte // First half of report_data, which commits to inputs, outputs and transcript
+	Outputs      []byte   // abi.encode of the outputs, see EncodeOutputs
+}
+
+// Encode returns abi.encode(string requestId, bytes32 moduleHash, bytes32 reportDigest, bytes outputs)
+func (r Result) Encode() []byte {
+	return encodeTuple([]part{
+		{dynamic: true, data: encodeBytes([]byte(r.RequestID))},
+		{data: r.ModuleHash[:]},
+		{data: r.ReportDigest[:]},
+		{dynamic: true, data: encodeBytes(r.Outputs)},
+	})
+}
+
+// EIP191Digest returns the digest signed with personal_sign semantics
+//
+//	keccak256("\x19Ethereum Signed Message:\n32" || keccak256(Encode()))
+func (r Result) EIP191Digest() [32]byte {
+	hash := Keccak256(r.Encode())
+	return Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash[:])
+}
+
+// Domain is the EIP-712 domain of a verifying contract
+type Domain struct {
+	ChainID           uint64
+	VerifyingContract [20]byte
+}
+
+// Separator returns the EIP-712 domain separator of name DomainName and version DomainVersion
+func (d Domain) Separator() [32]byte {
+	typeHash := Keccak256([]byte(domainType))
+	name := Keccak256([]byte(DomainName))
+	version := Keccak256([]byte(DomainVersion))
+	return Keccak256(typeHash[:], name[:], version[:], encodeUint(d.ChainID), leftPad(d.VerifyingContract[:]))
+}
+
+// EIP712Digest returns the typed data digest of the result as an ExecutionResult in domain d
+//
+//	keccak256("\x19\x01" || domainSeparator || hashStruct(result))
+func (r Result) EIP712Digest(d Domain) [32]byte {
+	typeHash := Keccak256([]byte(ResultType))
+	requestID := Keccak256([]byte(r.RequestID))
+	outputs := Keccak256(r.Outputs)
+	structHash := Keccak256(typeHash[:], requestID[:], r.ModuleHash[:], r.ReportDigest[:], outputs[:])
+
+	separator := d.Separator()
+	return Keccak256([]byte("\x19\x01"), separator[:], structHash[:])
+}
+
+// Keccak256 returns the Ethereum keccak256 hash of the concatenated data
+func Keccak256(data ...[]byte) [32]byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// Address returns the EIP-55 checksummed address of a secp256k1 public key
+func Address(publicKey []byte) (string, error) {
+	key, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %v", err)
+	}
+	return keyAddress(key), nil
+}
+
+// ParseAddress parses a 0x prefixed hex address, the checksum is not enforced
+func ParseAddress(s string) ([20]byte, error) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok {
+		digits, ok = strings.CutPrefix(s, "0X")
+	}
+	raw, err := hex.DecodeString(digits)
+	if !ok || err != nil || len(raw) != 20 {
+		return [20]byte{}, fmt.Errorf("%w: %q is not a 0x prefixed 20 byte address", ErrInvalidABI, s)
+	}
+	return [20]byte(raw), nil
+}
+
+// RecoverAddress returns the address that produced a 65 byte r || s || v signature of digest
+// v is 27 or 28 as returned by Sign and expected by ecrecover.
+func RecoverAddress(digest [32]byte, signature []byte) (string, error) {
+	if len(signature) != 65 || (signature[64] != 27 && signature[64] != 28) {
+		return "", fmt.Errorf("signature must be 65 bytes r || s || v with v 27 or 28")
+	}
+
+	// RecoverCompact expects the recovery code first, 27 + v for uncompressed keys
+	compact := append([]byte{signature[64]}, signature[:64]...)
+	key, _, err := ecdsa.RecoverCompact(compact, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to recover signer: %v", err)
+	}
+	return keyAddress(key), nil
+}
+
+// Sign signs digest with key, returning r || s || v with v 27 or 28
+func Sign(key *secp256k1.PrivateKey, digest [32]byte) []byte {
+	compact := ecdsa.SignCompact(key, digest[:], false)
+	return append(compact[1:], compact[0])
+}
+
+// keyAddress returns the checksummed address of key: the last 20 bytes of keccak256(X || Y)
+func keyAddress(key *secp256k1.PublicKey) string {
+	hash := Keccak256(key.SerializeUncompressed()[1:])
+	return checksum(hash[12:])
+}
+
+// checksum formats an address with the EIP-55 mixed case checksum
+func checksum(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := Keccak256([]byte(lower))
+
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
@@ -0,0 +1,95 @@
+package evm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// testResult is the result of the docs/evm.md test vectors
+func testResult(t *testing.T) Result {
+	t.Helper()
+
+	outputs, err := hex.DecodeString("" +
+		"0000000000000000000000000000000000000000000000000000000000000123" +
+		"0000000000000000000000000000000000000000000000000000000000000080" +
+		"3132333435363738393000000000000000000000000000000000000000000000" +
+		"00000000000000000000000000000000000000000000000000000000000000e0" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000456" +
+		"0000000000000000000000000000000000000000000000000000000000000789" +
+		"000000000000000000000000000000000000000000000000000000000000000d" +
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatalf("Invalid outputs: %v", err)
+	}
+	reportDigest, _ := hex.DecodeString("10e3cc0fa9c34530e922876314f7770f75063c612d0a5f59eafbf8169f621fff")
+
+	return Result{
+		RequestID:    "req-1",
+		ModuleHash:   sha256.Sum256([]byte("\x00asm\x01\x00\x00\x00")),
+		ReportDigest: [32]byte(reportDigest),
+		Outputs:      outputs,
+	}
+}
+
+// TestDigests - Checks keccak256, the EIP-191 and EIP-712 digests of a result against docs/evm.md
+func TestDigests(t *testing.T) {
+	empty := Keccak256()
+	contract, err := ParseAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	if err != nil {
+		t.Fatalf("Failed to parse address: %v", err)
+	}
+	result := testResult(t)
+	encoded := Keccak256(result.Encode())
+	domain := Domain{ChainID: 1, VerifyingContract: contract}
+	separator := domain.Separator()
+	eip191 := result.EIP191Digest()
+	eip712 := result.EIP712Digest(domain)
+
+	tests := []struct {
+		name     string
+		actual   []byte
+		expected string
+	}{
+		{"keccak256_empty", empty[:], "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"encoded", encoded[:], "56179687d29409185266511f8bcf74e24c767e133a449b2375e3941f3d409c5c"},
+		{"eip191", eip191[:], "640c0b68573ecae7dcbb400810711f83c68c073961ea7ceb59302fc0a1fa9e83"},
+		{"domain_separator", separator[:], "617b2d639ba0252c27dded35a7e8d2effe85b86d18f4a0e5d660fd10aa9550a7"},
+		{"eip712", eip712[:], "1d7fd9ab60cdb55f4977d71cf798833ee22f8b34b012da185d87bcdcb0d2cc7c"},
+	}
+	for _, tt := range tests {
+		if hex.EncodeToString(tt.actual) != tt.expected {
+			t.Errorf("Unexpected %s. Expected %s, got %x", tt.name, tt.expected, tt.actual)
+		}
+	}
+}
+
+// TestSignatures - Checks addresses and that signatures recover to the signing key like ecrecover
+func TestSignatures(t *testing.T) {
+	var one secp256k1.ModNScalar
+	one.SetInt(1)
+	key := secp256k1.NewPrivateKey(&one)
+
+	address, err := Address(key.PubKey().SerializeCompressed())
+	if err != nil || address != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Fatalf("Unexpected address %s, %v", address, err)
+	}
+
+	digest := testResult(t).EIP191Digest()
+	signature := Sign(key, digest)
+	if len(signature) != 65 || (signature[64] != 27 && signature[64] != 28) {
+		t.Fatalf("Unexpected signature %x", signature)
+	}
+	if recovered, err := RecoverAddress(digest, signature); err != nil || recovered != address {
+		t.Errorf("Expected %s to be recovered, got %s, %v", address, recovered, err)
+	}
+
+	// A signature of another digest recovers to another address
+	other := Keccak256([]byte("other"))
+	if recovered, _ := RecoverAddress(other, signature); recovered == address {
+		t.Errorf("Expected a signature of another digest not to recover the key")
+	}
+}
@@ -0,0 +1,223 @@
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// contractPath is the reference verifier contract, whose constants are checked against this package
+// The contract itself is never compiled or executed here, see verifierModel
+const contractPath = "../../contracts/WasmvmResultVerifier.sol"
+
+// verifierModel is a Go model of WasmvmResultVerifier written by hand from its source: the digests are built
+// with the contract's abi.encode and abi.encodePacked layouts, not with this package. It checks that this package
+// agrees with the model, a change to the contract logic is not caught unless the model is changed with it.
+type verifierModel struct {
+	domainTypeHash [32]byte
+	resultTypeHash [32]byte
+	nameHash       [32]byte
+	versionHash    [32]byte
+	halfOrder      *big.Int
+
+	chainID          uint64
+	address          [20]byte
+	trustedSigners   map[string]bool
+	allowedModules   map[[32]byte]bool
+	consumedRequests map[[32]byte]bool
+}
+
+// newVerifierModel reads the constants of the contract source
+func newVerifierModel(t *testing.T, chainID uint64, address [20]byte) *verifierModel {
+	t.Helper()
+
+	source, err := os.ReadFile(contractPath)
+	if err != nil {
+		t.Fatalf("Failed to read contract: %v", err)
+	}
+	constant := func(pattern string) string {
+		match := regexp.MustCompile(pattern).FindSubmatch(source)
+		if match == nil {
+			t.Fatalf("Contract does not match %s", pattern)
+		}
+		return string(match[1])
+	}
+
+	halfOrder, ok := new(big.Int).SetString(constant(`HALF_ORDER = 0x([0-9a-f]+);`), 16)
+	if !ok {
+		t.Fatalf("Invalid HALF_ORDER")
+	}
+	domainSeparator := regexp.MustCompile(`abi\.encode\(DOMAIN_TYPEHASH, keccak256\("([^"]*)"\), keccak256\("([^"]*)"\)`).FindSubmatch(source)
+	if domainSeparator == nil {
+		t.Fatalf("Contract domain separator not found")
+	}
+
+	return &verifierModel{
+		domainTypeHash:   Keccak256([]byte(constant(`DOMAIN_TYPEHASH =\s+keccak256\("([^"]*)"\)`))),
+		resultTypeHash:   Keccak256([]byte(constant(`RESULT_TYPEHASH =\s+keccak256\("([^"]*)"\)`))),
+		nameHash:         Keccak256(domainSeparator[1]),
+		versionHash:      Keccak256(domainSeparator[2]),
+		halfOrder:        halfOrder,
+		chainID:          chainID,
+		address:          address,
+		trustedSigners:   make(map[string]bool),
+		allowedModules:   make(map[[32]byte]bool),
+		consumedRequests: make(map[[32]byte]bool),
+	}
+}
+
+// word left-pads b to a 32 byte ABI word
+func word(b []byte) []byte {
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+// domainSeparator is keccak256(abi.encode(DOMAIN_TYPEHASH, keccak256(name), keccak256(version), chainid, this))
+func (c *verifierModel) domainSeparator() [32]byte {
+	chainID := new(big.Int).SetUint64(c.chainID).Bytes()
+	return Keccak256(c.domainTypeHash[:], c.nameHash[:], c.versionHash[:], word(chainID), word(c.address[:]))
+}
+
+// verifyEIP712 models the contract function of the same name, state changes included
+func (c *verifierModel) verifyEIP712(requestID string, moduleHash, reportDigest [32]byte, outputs, signature []byte) error {
+	requestIDHash := Keccak256([]byte(requestID))
+	outputsHash := Keccak256(outputs)
+	structHash := Keccak256(c.resultTypeHash[:], requestIDHash[:], moduleHash[:], reportDigest[:], outputsHash[:])
+	separator := c.domainSeparator()
+	digest := Keccak256([]byte("\x19\x01"), separator[:], structHash[:])
+
+	if err := c.check(digest, moduleHash, signature); err != nil {
+		return err
+	}
+	return c.consume(requestID)
+}
+
+// verifyEIP191 models the contract function of the same name on the fields of encoded
+func (c *verifierModel) verifyEIP191(result Result, signature []byte) error {
+	encodedHash := Keccak256(result.Encode())
+	digest := Keccak256([]byte("\x19Ethereum Signed Message:\n32"), encodedHash[:])
+
+	if err := c.check(digest, result.ModuleHash, signature); err != nil {
+		return err
+	}
+	return c.consume(result.RequestID)
+}
+
+func (c *verifierModel) check(digest, moduleHash [32]byte, signature []byte) error {
+	if !c.allowedModules[moduleHash] {
+		return errors.New("module not allowed")
+	}
+	if len(signature) != 65 {
+		return errors.New("invalid signature length")
+	}
+	if new(big.Int).SetBytes(signature[32:64]).Cmp(c.halfOrder) > 0 {
+		return errors.New("invalid signature s")
+	}
+	if v := signature[64]; v != 27 && v != 28 {
+		return errors.New("invalid signature v")
+	}
+	signer, err := RecoverAddress(digest, signature)
+	if err != nil {
+		return errors.New("invalid signature")
+	}
+	if !c.trustedSigners[signer] {
+		return errors.New("untrusted signer")
+	}
+	return nil
+}
+
+func (c *verifierModel) consume(requestID string) error {
+	if requestID == "" {
+		return errors.New("missing request id")
+	}
+	requestIDHash := Keccak256([]byte(requestID))
+	if c.consumedRequests[requestIDHash] {
+		return errors.New("request already consumed")
+	}
+	c.consumedRequests[requestIDHash] = true
+	return nil
+}
+
+// TestVerifierModel - Checks the contract constants and that signed results verify exactly once under the Go model of the contract
+func TestVerifierModel(t *testing.T) {
+	contractAddress, err := ParseAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	if err != nil {
+		t.Fatalf("Failed to parse address: %v", err)
+	}
+	contract := newVerifierModel(t, 1, contractAddress)
+
+	// The contract constants are those of this package
+	if expected := Keccak256([]byte(ResultType)); contract.resultTypeHash != expected {
+		t.Errorf("Contract RESULT_TYPEHASH does not hash %q", ResultType)
+	}
+	if expected := Keccak256([]byte(domainType)); contract.domainTypeHash != expected {
+		t.Errorf("Contract DOMAIN_TYPEHASH does not hash %q", domainType)
+	}
+	if contract.nameHash != Keccak256([]byte(DomainName)) || contract.versionHash != Keccak256([]byte(DomainVersion)) {
+		t.Errorf("Contract domain name or version differ from %s %s", DomainName, DomainVersion)
+	}
+	if halfOrder := new(big.Int).Rsh(secp256k1.S256().N, 1); contract.halfOrder.Cmp(halfOrder) != 0 {
+		t.Errorf("Contract HALF_ORDER %x, expected %x", contract.halfOrder, halfOrder)
+	}
+	domain := Domain{ChainID: 1, VerifyingContract: contractAddress}
+	if separator := contract.domainSeparator(); hex.EncodeToString(separator[:]) != "617b2d639ba0252c27dded35a7e8d2effe85b86d18f4a0e5d660fd10aa9550a7" {
+		t.Errorf("Unexpected contract domain separator %x", separator)
+	}
+
+	var one secp256k1.ModNScalar
+	one.SetInt(1)
+	key := secp256k1.NewPrivateKey(&one)
+	signer := keyAddress(key.PubKey())
+	result := testResult(t)
+	contract.trustedSigners[signer] = true
+
+	eip712Signature := Sign(key, result.EIP712Digest(domain))
+	verify712 := func(r Result, signature []byte) error {
+		return contract.verifyEIP712(r.RequestID, r.ModuleHash, r.ReportDigest, r.Outputs, signature)
+	}
+
+	// Results of modules that are not allowed are rejected
+	if err := verify712(result, eip712Signature); err == nil || err.Error() != "module not allowed" {
+		t.Fatalf("Expected the module to be rejected, got %v", err)
+	}
+	contract.allowedModules[result.ModuleHash] = true
+
+	// A tampered field changes the digest and recovers another signer
+	tampered := result
+	tampered.Outputs = append([]byte{1}, result.Outputs[1:]...)
+	if err := verify712(tampered, eip712Signature); err == nil || err.Error() != "untrusted signer" {
+		t.Errorf("Expected tampered outputs to be rejected, got %v", err)
+	}
+
+	// The malleable twin (r, n - s, v ^ 1) of a valid signature is rejected
+	s := new(big.Int).SetBytes(eip712Signature[32:64])
+	twin := append([]byte{}, eip712Signature[:32]...)
+	twin = append(twin, word(new(big.Int).Sub(secp256k1.S256().N, s).Bytes())...)
+	twin = append(twin, eip712Signature[64]^1)
+	if err := verify712(result, twin); err == nil || err.Error() != "invalid signature s" {
+		t.Errorf("Expected the malleable signature to be rejected, got %v", err)
+	}
+
+	// The result is accepted once, a replay is rejected
+	if err := verify712(result, eip712Signature); err != nil {
+		t.Fatalf("Expected the EIP-712 result to verify, got %v", err)
+	}
+	if err := verify712(result, eip712Signature); err == nil || err.Error() != "request already consumed" {
+		t.Errorf("Expected the replayed result to be rejected, got %v", err)
+	}
+
+	// EIP-191 results consume their request id as well
+	eip191 := result
+	eip191.RequestID = "req-2"
+	eip191Signature := Sign(key, eip191.EIP191Digest())
+	if err := contract.verifyEIP191(eip191, eip191Signature); err != nil {
+		t.Fatalf("Expected the EIP-191 result to verify, got %v", err)
+	}
+	if err := contract.verifyEIP191(eip191, eip191Signature); err == nil || err.Error() != "request already consumed" {
+		t.Errorf("Expected the replayed result to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package wasm
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/evm"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// checkEvmOutput validates the EVM output options of a request before the module is executed
+func checkEvmOutput(opts *types.EvmOutputOptions) error {
+	if opts == nil {
+		return nil
+	}
+	if err := evm.ValidateTypes(opts.OutputTypes); err != nil {
+		return err
+	}
+	if opts.Scheme == types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP712 {
+		if _, err := evm.ParseAddress(opts.VerifyingContract); err != nil {
+			return fmt.Errorf("verifying_contract: %w", err)
+		}
+	}
+	return nil
+}
+
+// evmResult ABI encodes the outputs of an execution and signs them with the receipt key
+// The signed result carries the first half of report_data, so a contract trusting the signer
+// also learns which inputs, transcript and secrets the outputs were computed from.
+func (s *Server) evmResult(execution *types.WASMVMExecution, outputValues []*types.WasmValue, components reportdata.Components) (*types.EvmResult, error) {
+	opts := execution.EvmOutput
+	outputTypes, err := evm.OutputTypes(opts.OutputTypes, outputValues)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := evm.EncodeOutputs(outputTypes, outputValues)
+	if err != nil {
+		return nil, err
+	}
+
+	reportData := components.ReportData()
+	result := evm.Result{
+		RequestID:    execution.RequestId,
+		ModuleHash:   components.ModuleHash,
+		ReportDigest: [32]byte(reportData[:32]),
+		Outputs:      outputs,
+	}
+
+	evmResult := &types.EvmResult{
+		RequestId:   execution.RequestId,
+		OutputTypes: outputTypes,
+		Outputs:     "0x" + hex.EncodeToString(outputs),
+		Encoded:     "0x" + hex.EncodeToString(result.Encode()),
+		Scheme:      types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP191,
+		Signer:      s.receipts.Address(),
+	}
+
+	digest := result.EIP191Digest()
+	if opts.Scheme == types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP712 {
+		contract, err := evm.ParseAddress(opts.VerifyingContract)
+		if err != nil {
+			return nil, err
+		}
+		digest = result.EIP712Digest(evm.Domain{ChainID: opts.ChainId, VerifyingContract: contract})
+		evmResult.Scheme = opts.Scheme
+		evmResult.ChainId = opts.ChainId
+		evmResult.VerifyingContract = opts.VerifyingContract
+	}
+
+	evmResult.Digest = "0x" + hex.EncodeToString(digest[:])
+	evmResult.Signature = "0x" + hex.EncodeToString(s.receipts.SignEVM(digest))
+
+	return evmResult, nil
+}
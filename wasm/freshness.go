@@ -0,0 +1,38 @@
+package wasm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+var (
+	// ErrStaleRequest is returned when an execution timestamp is missing or outside the allowed clock skew
+	ErrStaleRequest = errors.New("stale request")
+	// ErrNonceRequired is returned when the server requires a nonce and the execution carries none
+	ErrNonceRequired = errors.New("nonce required")
+)
+
+// checkFreshness enforces Config.MaxClockSkew and Config.RequireNonce on an execution
+// The nonce is what binds a result to the client's challenge, the timestamp only keeps stale requests from running.
+func (s *Server) checkFreshness(execution *types.WASMVMExecution, now time.Time) error {
+	if s.config.RequireNonce && len(execution.Nonce) == 0 {
+		return ErrNonceRequired
+	}
+	if s.config.MaxClockSkew <= 0 {
+		return nil
+	}
+
+	if execution.Timestamp == 0 {
+		return fmt.Errorf("%w: timestamp is required", ErrStaleRequest)
+	}
+	skew := now.Sub(time.Unix(execution.Timestamp, 0))
+	if skew > s.config.MaxClockSkew || skew < -s.config.MaxClockSkew {
+		return fmt.Errorf("%w: timestamp %d is %v away from the server clock, at most %v is allowed (timestamps are Unix seconds)",
+			ErrStaleRequest, execution.Timestamp, skew.Truncate(time.Second), s.config.MaxClockSkew)
+	}
+
+	return nil
+}
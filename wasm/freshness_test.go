@@ -0,0 +1,57 @@
+package wasm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestCheckFreshness - Verifies timestamp skew and nonce requirements and their status codes
+func TestCheckFreshness(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	strict := &Server{config: Config{MaxClockSkew: 30 * time.Second, RequireNonce: true}}
+	lenient := &Server{}
+
+	tests := []struct {
+		name      string
+		server    *Server
+		execution *types.WASMVMExecution
+		expected  error
+		code      codes.Code
+	}{
+		{name: "unchecked", server: lenient, execution: &types.WASMVMExecution{}},
+		{name: "fresh", server: strict, execution: &types.WASMVMExecution{Timestamp: now.Unix() - 10, Nonce: []byte{1}}},
+		{name: "ahead_within_skew", server: strict, execution: &types.WASMVMExecution{Timestamp: now.Unix() + 30, Nonce: []byte{1}}},
+		{name: "missing_nonce", server: strict, execution: &types.WASMVMExecution{Timestamp: now.Unix()},
+			expected: ErrNonceRequired, code: codes.InvalidArgument},
+		{name: "missing_timestamp", server: strict, execution: &types.WASMVMExecution{Nonce: []byte{1}},
+			expected: ErrStaleRequest, code: codes.FailedPrecondition},
+		{name: "stale", server: strict, execution: &types.WASMVMExecution{Timestamp: now.Unix() - 31, Nonce: []byte{1}},
+			expected: ErrStaleRequest, code: codes.FailedPrecondition},
+		{name: "milliseconds", server: strict, execution: &types.WASMVMExecution{Timestamp: now.UnixMilli(), Nonce: []byte{1}},
+			expected: ErrStaleRequest, code: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.server.checkFreshness(tt.execution, now)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected the execution to be fresh, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if code := status.Code(executionStatusError(err)); code != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package wasm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+	bindgen "github.com/second-state/wasmedge-bindgen/host/go"
+)
+
+// guest calls the functions of an instantiated module with the wasmedge-bindgen calling convention
+// Every call into the guest, the allocations included, runs through WasmEdge's asynchronous API so that
+// cancelling ctx stops the interpreter through its own stop token instead of touching its state from outside
+type guest struct {
+	ctx context.Context
+	vm  *wasmedge.VM
+}
+
+// Execute calls fnName with inputs and returns its results, or the error message the guest returned
+func (g *guest) Execute(fnName string, inputs ...any) ([]any, any, error) {
+	// Frame of (pointer, length) pairs passed to the function
+	frame, err := g.allocate(int32(len(inputs) * 4 * 2))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	memory, err := g.memory()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, input := range inputs {
+		data, length, err := encodeInput(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		pointer, err := g.allocate(int32(len(data)))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := memory.SetData(data, uint(pointer), uint(len(data))); err != nil {
+			return nil, nil, err
+		}
+
+		entry := make([]byte, 8)
+		binary.LittleEndian.PutUint32(entry[0:4], uint32(pointer))
+		binary.LittleEndian.PutUint32(entry[4:8], uint32(length))
+		if err := memory.SetData(entry, uint(frame)+uint(i*8), 8); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rets, err := g.call(fnName, frame, int32(len(inputs)))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rets) != 1 {
+		return nil, nil, errors.New("invalid return value")
+	}
+	ret, ok := rets[0].(int32)
+	if !ok {
+		return nil, nil, errors.New("invalid return value")
+	}
+
+	header, err := memory.GetData(uint(ret), 9)
+	if err != nil {
+		return nil, nil, err
+	}
+	pointer := int32(binary.LittleEndian.Uint32(header[1:5]))
+	size := int32(binary.LittleEndian.Uint32(header[5:9]))
+
+	if header[0] != 0 {
+		message, err := memory.GetData(uint(pointer), uint(size))
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, string(message), nil
+	}
+
+	results, err := decodeResults(memory, pointer, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, nil, nil
+}
+
+// allocate reserves size bytes of guest memory and returns their address
+func (g *guest) allocate(size int32) (int32, error) {
+	rets, err := g.call("allocate", size)
+	if err != nil {
+		return 0, err
+	}
+	if len(rets) != 1 {
+		return 0, errors.New("invalid allocation")
+	}
+	pointer, ok := rets[0].(int32)
+	if !ok {
+		return 0, errors.New("invalid allocation")
+	}
+	return pointer, nil
+}
+
+// memory returns the linear memory of the active module
+func (g *guest) memory() (*wasmedge.Memory, error) {
+	module := g.vm.GetActiveModule()
+	if module == nil {
+		return nil, errors.New("module not instantiated")
+	}
+	memory := module.FindMemory("memory")
+	if memory == nil {
+		return nil, errors.New("memory not found")
+	}
+	return memory, nil
+}
+
+// call runs fnName asynchronously and cancels it when ctx ends
+// The statistics of the VM may only be read once call has returned
+func (g *guest) call(fnName string, params ...any) ([]any, error) {
+	async := g.vm.AsyncExecute(fnName, params...)
+	if async == nil {
+		return nil, fmt.Errorf("failed to start %s", fnName)
+	}
+	defer async.Release()
+
+	// The watcher must be gone before the Async is released
+	finished := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-g.ctx.Done():
+			async.Cancel()
+		case <-finished:
+		}
+	}()
+
+	results, err := async.GetResult()
+	close(finished)
+	wg.Wait()
+
+	return results, err
+}
+
+// encodeInput returns the little-endian encoding of a bindgen input and the length passed along with it
+// Scalars pass a length of one, slices and strings their number of elements
+func encodeInput(input any) ([]byte, int32, error) {
+	switch v := input.(type) {
+	case string:
+		return []byte(v), int32(len(v)), nil
+	case bool:
+		if v {
+			return []byte{1}, 1, nil
+		}
+		return []byte{0}, 1, nil
+	case int8, uint8, int16, uint16, int32, uint32, int64, uint64, float32, float64:
+		data, err := binary.Append(nil, binary.LittleEndian, v)
+		return data, 1, err
+	case []byte, []int8, []uint16, []int16, []uint32, []int32, []uint64, []int64:
+		data, err := binary.Append(nil, binary.LittleEndian, v)
+		return data, int32(reflect.ValueOf(v).Len()), err
+	default:
+		return nil, 0, fmt.Errorf("unsupported arg type %T", input)
+	}
+}
+
+// decodeResults reads the size results the guest described at pointer
+// Each result is described by its address, its bindgen type and its length in bytes
+func decodeResults(memory *wasmedge.Memory, pointer, size int32) ([]any, error) {
+	descriptors, err := memory.GetData(uint(pointer), uint(size)*3*4)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]any, size)
+	for i := range results {
+		descriptor := descriptors[i*12 : (i+1)*12]
+		address := binary.LittleEndian.Uint32(descriptor[0:4])
+		kind := int32(binary.LittleEndian.Uint32(descriptor[4:8]))
+		length := binary.LittleEndian.Uint32(descriptor[8:12])
+
+		data, err := memory.GetData(uint(address), uint(length))
+		if err != nil {
+			return nil, err
+		}
+		if results[i], err = decodeResult(kind, data); err != nil {
+			return nil, fmt.Errorf("result %d: %w", i, err)
+		}
+	}
+
+	return results, nil
+}
+
+// decodeResult decodes a result of the given bindgen type
+func decodeResult(kind int32, data []byte) (any, error) {
+	var value any
+	switch kind {
+	case bindgen.String:
+		return string(data), nil
+	case bindgen.ByteArray:
+		return append([]byte{}, data...), nil
+	case bindgen.Bool:
+		if len(data) < 1 {
+			return nil, errors.New("short bool")
+		}
+		return data[0] == 1, nil
+	case bindgen.U8:
+		value = new(uint8)
+	case bindgen.I8:
+		value = new(int8)
+	case bindgen.U16:
+		value = new(uint16)
+	case bindgen.I16:
+		value = new(int16)
+	case bindgen.U32:
+		value = new(uint32)
+	case bindgen.I32, bindgen.Rune:
+		value = new(int32)
+	case bindgen.U64:
+		value = new(uint64)
+	case bindgen.I64:
+		value = new(int64)
+	case bindgen.F32:
+		value = new(float32)
+	case bindgen.F64:
+		value = new(float64)
+	case bindgen.I8Array:
+		value = make([]int8, len(data))
+	case bindgen.U16Array:
+		value = make([]uint16, len(data)/2)
+	case bindgen.I16Array:
+		value = make([]int16, len(data)/2)
+	case bindgen.U32Array:
+		value = make([]uint32, len(data)/4)
+	case bindgen.I32Array:
+		value = make([]int32, len(data)/4)
+	case bindgen.U64Array:
+		value = make([]uint64, len(data)/8)
+	case bindgen.I64Array:
+		value = make([]int64, len(data)/8)
+	default:
+		return nil, fmt.Errorf("unsupported result type %d", kind)
+	}
+
+	if _, err := binary.Decode(data, binary.LittleEndian, value); err != nil {
+		return nil, err
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		return v.Elem().Interface(), nil
+	}
+	return value, nil
+}
@@ -0,0 +1,56 @@
+package wasm
+
+import (
+	"reflect"
+	"testing"
+
+	bindgen "github.com/second-state/wasmedge-bindgen/host/go"
+)
+
+// TestGuestEncoding - Verifies the bindgen encoding of inputs and decoding of results
+func TestGuestEncoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  any
+		kind   int32
+		length int32
+	}{
+		{"string", "héllo", bindgen.String, 6},
+		{"bytes", []byte{1, 2, 3}, bindgen.ByteArray, 3},
+		{"bool", true, bindgen.Bool, 1},
+		{"u8", uint8(200), bindgen.U8, 1},
+		{"i16", int16(-2), bindgen.I16, 1},
+		{"u32", uint32(1 << 31), bindgen.U32, 1},
+		{"i64", int64(-1 << 40), bindgen.I64, 1},
+		{"f64", 3.25, bindgen.F64, 1},
+		{"i32 array", []int32{1, -2, 3}, bindgen.I32Array, 3},
+		{"u64 array", []uint64{1 << 50}, bindgen.U64Array, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, length, err := encodeInput(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to encode: %v", err)
+			}
+			if length != tt.length {
+				t.Errorf("Expected length %d, got %d", tt.length, length)
+			}
+
+			decoded, err := decodeResult(tt.kind, data)
+			if err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.input) {
+				t.Errorf("Expected %#v, got %#v", tt.input, decoded)
+			}
+		})
+	}
+
+	if _, _, err := encodeInput(struct{}{}); err == nil {
+		t.Errorf("Expected unsupported input to fail")
+	}
+	if _, err := decodeResult(bindgen.U64, []byte{1, 2}); err == nil {
+		t.Errorf("Expected short result to fail")
+	}
+}
@@ -0,0 +1,189 @@
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+)
+
+// Status codes returned to the guest by the result_* host functions
+const (
+	resultErrInvalidHandle int32 = -1 // The handle is unknown or was already freed
+	resultErrOutOfBounds   int32 = -2 // The destination range lies outside the guest memory
+	resultErrInvalidOffset int32 = -3 // The offset is negative or past the end of the result
+)
+
+// maxOpenResults bounds the results a guest may hold at once so it cannot pin host memory
+const maxOpenResults = 64
+
+// wasmPageSize is the size of a linear memory page in bytes
+const wasmPageSize = 65536
+
+// storeResult keeps data until the guest frees it and returns its handle
+// Handles are positive and never reused within an execution
+func (h *host) storeResult(data []byte) (int32, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.results) >= maxOpenResults {
+		return 0, fmt.Errorf("too many open results, free some with free_result")
+	}
+	if h.results == nil {
+		h.results = make(map[int32][]byte)
+	}
+
+	h.lastHandle++
+	h.results[h.lastHandle] = data
+
+	return h.lastHandle, nil
+}
+
+// result returns the data stored under handle
+func (h *host) result(handle int32) ([]byte, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data, ok := h.results[handle]
+	return data, ok
+}
+
+// freeStoredResult releases the data stored under handle
+func (h *host) freeStoredResult(handle int32) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.results[handle]; !ok {
+		return false
+	}
+	delete(h.results, handle)
+
+	return true
+}
+
+// Host function returning the length of a result: result_len(handle) -> len
+func (h *host) resultLen(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	data, ok := h.result(params[0].(int32))
+	if !ok {
+		return []any{resultErrInvalidHandle}, wasmedge.Result_Success
+	}
+
+	return []any{int32(len(data))}, wasmedge.Result_Success
+}
+
+// Host function copying a result into guest memory: read_result(handle, ptr, len) -> written
+// At most len bytes are written, the destination range is validated before anything is copied
+func (h *host) readResult(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	data, ok := h.result(params[0].(int32))
+	if !ok {
+		return []any{resultErrInvalidHandle}, wasmedge.Result_Success
+	}
+
+	pointer := uint32(params[1].(int32))
+	size := uint32(params[2].(int32))
+	if uint64(size) > uint64(len(data)) {
+		size = uint32(len(data))
+	}
+
+	if err := writeGuestMemory(callframe, pointer, data[:size]); err != nil {
+		return []any{resultErrOutOfBounds}, wasmedge.Result_Success
+	}
+
+	return []any{int32(size)}, wasmedge.Result_Success
+}
+
+// Host function copying part of a result into guest memory: read_result_at(handle, offset, ptr, len) -> written
+// Lets guests consume large results in chunks, 0 is returned once offset reaches the end
+func (h *host) readResultAt(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	data, ok := h.result(params[0].(int32))
+	if !ok {
+		return []any{resultErrInvalidHandle}, wasmedge.Result_Success
+	}
+
+	chunk, ok := resultChunk(data, params[1].(int32), params[3].(int32))
+	if !ok {
+		return []any{resultErrInvalidOffset}, wasmedge.Result_Success
+	}
+
+	if err := writeGuestMemory(callframe, uint32(params[2].(int32)), chunk); err != nil {
+		return []any{resultErrOutOfBounds}, wasmedge.Result_Success
+	}
+
+	return []any{int32(len(chunk))}, wasmedge.Result_Success
+}
+
+// resultChunk returns at most size bytes of data starting at offset
+func resultChunk(data []byte, offset, size int32) ([]byte, bool) {
+	if offset < 0 || int(offset) > len(data) {
+		return nil, false
+	}
+
+	data = data[offset:]
+	if size >= 0 && int(size) < len(data) {
+		data = data[:size]
+	}
+
+	return data, true
+}
+
+// Host function releasing a result: free_result(handle) -> 0 or resultErrInvalidHandle
+func (h *host) freeResult(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	if !h.freeStoredResult(params[0].(int32)) {
+		return []any{resultErrInvalidHandle}, wasmedge.Result_Success
+	}
+
+	return []any{int32(0)}, wasmedge.Result_Success
+}
+
+// readGuestMemory copies size bytes at pointer out of the guest's linear memory
+func readGuestMemory(callframe *wasmedge.CallingFrame, pointer, size uint32) ([]byte, error) {
+	mem, err := guestMemoryRange(callframe, pointer, size)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return []byte{}, nil
+	}
+
+	data, err := mem.GetData(uint(pointer), uint(size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read guest memory: %v", err)
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+// writeGuestMemory copies data into the guest's linear memory at pointer
+func writeGuestMemory(callframe *wasmedge.CallingFrame, pointer uint32, data []byte) error {
+	mem, err := guestMemoryRange(callframe, pointer, uint32(len(data)))
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := mem.SetData(data, uint(pointer), uint(len(data))); err != nil {
+		return fmt.Errorf("failed to write guest memory: %v", err)
+	}
+
+	return nil
+}
+
+// guestMemoryRange returns the guest memory after checking that [pointer, pointer+size) lies within it
+func guestMemoryRange(callframe *wasmedge.CallingFrame, pointer, size uint32) (*wasmedge.Memory, error) {
+	mem := callframe.GetMemoryByIndex(0)
+	if mem == nil {
+		return nil, fmt.Errorf("guest has no linear memory")
+	}
+
+	if !inBounds(pointer, size, uint64(mem.GetPageSize())*wasmPageSize) {
+		return nil, fmt.Errorf("memory range [%d, %d+%d) is out of bounds", pointer, pointer, size)
+	}
+
+	return mem, nil
+}
+
+// inBounds reports whether [pointer, pointer+size) lies within a memory of memSize bytes
+func inBounds(pointer, size uint32, memSize uint64) bool {
+	return uint64(pointer)+uint64(size) <= memSize
+}
@@ -0,0 +1,103 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// TestHostResults - Verifies that results are kept per handle until freed
+func TestHostResults(t *testing.T) {
+	h := &host{ctx: context.Background()}
+
+	first, err := h.storeResult([]byte("first"))
+	if err != nil {
+		t.Fatalf("Failed to store result: %v", err)
+	}
+	second, err := h.storeResult([]byte("second"))
+	if err != nil {
+		t.Fatalf("Failed to store result: %v", err)
+	}
+	if first <= 0 || second <= 0 || first == second {
+		t.Fatalf("Expected distinct positive handles, got %d and %d", first, second)
+	}
+
+	// A second call must not overwrite the first result
+	if data, ok := h.result(first); !ok || !bytes.Equal(data, []byte("first")) {
+		t.Errorf("Unexpected first result %q", data)
+	}
+
+	if !h.freeStoredResult(first) {
+		t.Errorf("Expected first result to be freed")
+	}
+	if h.freeStoredResult(first) {
+		t.Errorf("Expected double free to be rejected")
+	}
+	if _, ok := h.result(first); ok {
+		t.Errorf("Expected freed handle to be invalid")
+	}
+	if data, ok := h.result(second); !ok || !bytes.Equal(data, []byte("second")) {
+		t.Errorf("Unexpected second result %q", data)
+	}
+
+	for i := 1; i < maxOpenResults; i++ {
+		if _, err := h.storeResult(nil); err != nil {
+			t.Fatalf("Failed to store result %d: %v", i, err)
+		}
+	}
+	if _, err := h.storeResult(nil); err == nil {
+		t.Errorf("Expected an error once %d results are open", maxOpenResults)
+	}
+}
+
+// TestInBounds - Checks guest memory range validation, including 32 bit overflow
+func TestInBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		pointer  uint32
+		size     uint32
+		expected bool
+	}{
+		{name: "inside", pointer: 0, size: wasmPageSize, expected: true},
+		{name: "empty_at_end", pointer: wasmPageSize, size: 0, expected: true},
+		{name: "past_end", pointer: wasmPageSize - 1, size: 2, expected: false},
+		{name: "overflow", pointer: 0xffffffff, size: 2, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := inBounds(tt.pointer, tt.size, wasmPageSize); actual != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// TestResultChunk - Checks the ranges served to read_result_at
+func TestResultChunk(t *testing.T) {
+	data := []byte("0123456789")
+
+	tests := []struct {
+		name     string
+		offset   int32
+		size     int32
+		expected string
+		ok       bool
+	}{
+		{name: "first_chunk", offset: 0, size: 4, expected: "0123", ok: true},
+		{name: "middle_chunk", offset: 4, size: 4, expected: "4567", ok: true},
+		{name: "last_chunk", offset: 8, size: 4, expected: "89", ok: true},
+		{name: "end", offset: 10, size: 4, expected: "", ok: true},
+		{name: "past_end", offset: 11, size: 4},
+		{name: "negative_offset", offset: -1, size: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk, ok := resultChunk(data, tt.offset, tt.size)
+			if ok != tt.ok || string(chunk) != tt.expected {
+				t.Errorf("Expected %q (%v), got %q (%v)", tt.expected, tt.ok, chunk, ok)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package wasm
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +12,8 @@ import (
 	"time"
 
 	"github.com/second-state/WasmEdge-go/wasmedge"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
 )
 
 // HttpRequest represents a complete HTTP request structure
@@ -18,67 +23,214 @@ type HttpRequest struct {
 	Headers map[string]string `json:"headers,omitempty"`
 	Body    string            `json:"body,omitempty"`
 	Timeout int               `json:"timeout,omitempty"` // timeout in seconds
+	TLS     *HttpTLSOptions   `json:"tls,omitempty"`     // Pins and host-held TLS material to use
 }
 
 // HttpResponse represents the HTTP response
 type HttpResponse struct {
-	StatusCode int               `json:"status_code"`
-	Headers    map[string]string `json:"headers"`
-	Body       string            `json:"body"`
-	Error      string            `json:"error,omitempty"`
+	StatusCode    int               `json:"status_code"`
+	Headers       map[string]string `json:"headers"`
+	Body          string            `json:"body"`
+	Error         string            `json:"error,omitempty"`
+	Violation     *EgressError      `json:"violation,omitempty"`      // Set when the egress policy rejected the request
+	Truncated     bool              `json:"truncated,omitempty"`      // The body was cut at Limits.MaxResponseBodyBytes
+	LimitExceeded string            `json:"limit_exceeded,omitempty"` // The HttpLimit that rejected the request or response
+	Limits        *HttpLimits       `json:"limits,omitempty"`         // The limits applied, set when one was reached
+	TLS           *HttpTLSInfo      `json:"tls,omitempty"`            // The verified connection, set for https responses
 }
 
-// performHttpRequest performs a complete HTTP request with full control
-func performHttpRequest(requestJSON string) []byte {
-	var httpReq HttpRequest
-	if err := json.Unmarshal([]byte(requestJSON), &httpReq); err != nil {
-		response := HttpResponse{
-			StatusCode: 0,
-			Error:      fmt.Sprintf("Failed to parse request JSON: %v", err),
+// Limits reported in HttpResponse.LimitExceeded, named after the HttpLimits fields
+const (
+	HttpLimitRequestBody  = "max_request_body_bytes"
+	HttpLimitResponseBody = "max_response_body_bytes"
+	HttpLimitHeaders      = "max_headers"
+)
+
+// HttpLimits bounds the traffic of a single fetch or http call
+type HttpLimits struct {
+	MaxRequestBodyBytes  int64 `json:"max_request_body_bytes"`  // Largest request body a guest may send
+	MaxResponseBodyBytes int64 `json:"max_response_body_bytes"` // Response bodies are truncated beyond this size
+	MaxHeaders           int   `json:"max_headers"`             // Most request or response header values
+}
+
+// DefaultHttpLimits applies to every limit left at zero
+var DefaultHttpLimits = HttpLimits{
+	MaxRequestBodyBytes:  1 << 20,
+	MaxResponseBodyBytes: 4 << 20,
+	MaxHeaders:           64,
+}
+
+// withDefaults returns the limits with zero values replaced by DefaultHttpLimits
+func (l *HttpLimits) withDefaults() HttpLimits {
+	limits := DefaultHttpLimits
+	if l == nil {
+		return limits
+	}
+	if l.MaxRequestBodyBytes > 0 {
+		limits.MaxRequestBodyBytes = l.MaxRequestBodyBytes
+	}
+	if l.MaxResponseBodyBytes > 0 {
+		limits.MaxResponseBodyBytes = l.MaxResponseBodyBytes
+	}
+	if l.MaxHeaders > 0 {
+		limits.MaxHeaders = l.MaxHeaders
+	}
+
+	return limits
+}
+
+// readLimitedBody reads at most limit bytes and reports whether the body was longer
+// Only limit+1 bytes are ever buffered, whatever the upstream sends
+func readLimitedBody(body io.Reader, limit int64) ([]byte, bool, error) {
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) > limit {
+		return data[:limit], true, nil
+	}
+
+	return data, false, nil
+}
+
+// headerCount counts the values of a header, repeated headers count once per value
+func headerCount(header http.Header) int {
+	count := 0
+	for _, values := range header {
+		count += len(values)
+	}
+	return count
+}
+
+// httpOptions configures the outbound requests of the fetch and http host functions
+type httpOptions struct {
+	egress  *EgressPolicy                     // Destinations requests may reach
+	limits  HttpLimits                        // Bounds on bodies and headers
+	tls     *TLSConfig                        // CA bundles, client certificates and pins, may be nil
+	secrets func(name string) ([]byte, error) // Resolves {{secret:name}} header placeholders, nil when none are available
+}
+
+// transcriptHeaders lists the response headers recorded in the HTTP transcript
+var transcriptHeaders = []string{"Content-Type", "Date", "ETag", "Last-Modified"}
+
+// newHttpExchange records a received response for the HTTP transcript
+// The URL is the one the response was served from, after redirects
+func newHttpExchange(resp *http.Response, body []byte) *types.HttpExchange {
+	bodyHash := sha256.Sum256(body)
+	exchange := &types.HttpExchange{
+		Method:     resp.Request.Method,
+		Url:        resp.Request.URL.String(),
+		StatusCode: int32(resp.StatusCode),
+		Headers:    make(map[string]string),
+		BodyHash:   hex.EncodeToString(bodyHash[:]),
+	}
+
+	for _, name := range transcriptHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			exchange.Headers[strings.ToLower(name)] = value
 		}
-		respJSON, _ := json.Marshal(response)
-		return respJSON
 	}
 
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		fingerprint := sha256.Sum256(resp.TLS.PeerCertificates[0].Raw)
+		exchange.TlsCertFingerprint = hex.EncodeToString(fingerprint[:])
+	}
+
+	return exchange
+}
+
+// failedHttpExchange records a request that was sent but produced no usable response
+func failedHttpExchange(req *http.Request, err error) *types.HttpExchange {
+	bodyHash := sha256.Sum256(nil)
+	return &types.HttpExchange{
+		Method:   req.Method,
+		Url:      req.URL.String(),
+		BodyHash: hex.EncodeToString(bodyHash[:]),
+		Error:    err.Error(),
+	}
+}
+
+// performHttpRequest performs a complete HTTP request with full control
+// The request is subject to the egress policy, violations are reported in HttpResponse.Violation
+// Bodies and headers are bounded by the limits, truncated bodies are flagged in HttpResponse.Truncated
+// TLS material named by the request is looked up in the TLS configuration
+// The response is encoded in the schema version of the request, see decodeHttpRequest
+// The returned exchange is nil when no request was sent
+func performHttpRequest(ctx context.Context, opts httpOptions, requestJSON string) ([]byte, *types.HttpExchange) {
+	call, encode, err := decodeHttpRequest([]byte(requestJSON))
+	if err != nil {
+		return encode(&httpResult{Error: fmt.Sprintf("Failed to parse request JSON: %v", err)}), nil
+	}
+
+	result, exchange := doHttpRequest(ctx, opts, call)
+	return encode(result), exchange
+}
+
+// doHttpRequest performs a decoded guest request
+func doHttpRequest(ctx context.Context, opts httpOptions, call *httpCall) (*httpResult, *types.HttpExchange) {
+	policy, limits := opts.egress, opts.limits
+
 	// Set default values
-	if httpReq.Method == "" {
-		httpReq.Method = "GET"
+	if call.Method == "" {
+		call.Method = "GET"
+	}
+	call.Method = strings.ToUpper(call.Method)
+	if call.Timeout == 0 {
+		call.Timeout = 30 // default 30 seconds
 	}
-	if httpReq.Timeout == 0 {
-		httpReq.Timeout = 30 // default 30 seconds
+
+	if int64(len(call.Body)) > limits.MaxRequestBodyBytes {
+		return httpLimitExceeded(0, HttpLimitRequestBody, limits, fmt.Sprintf("request body of %d bytes exceeds %d", len(call.Body), limits.MaxRequestBodyBytes)), nil
+	}
+	if count := headerCount(call.Headers); count > limits.MaxHeaders {
+		return httpLimitExceeded(0, HttpLimitHeaders, limits, fmt.Sprintf("%d request headers exceed %d", count, limits.MaxHeaders)), nil
 	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: time.Duration(httpReq.Timeout) * time.Second,
+	// Create HTTP client with timeout, enforcing the egress and TLS policies on every connection
+	tlsClientConfig, err := opts.tls.clientConfig(call.TLS)
+	if err != nil {
+		return &httpResult{Error: err.Error()}, nil
 	}
+	client := opts.tls.restrictRedirects(policy.Client(time.Duration(call.Timeout)*time.Second, tlsClientConfig), call.TLS)
 
 	// Create request body
 	var reqBody io.Reader
-	if httpReq.Body != "" {
-		reqBody = strings.NewReader(httpReq.Body)
+	if len(call.Body) > 0 {
+		reqBody = bytes.NewReader(call.Body)
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest(strings.ToUpper(httpReq.Method), httpReq.URL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, call.Method, call.URL, reqBody)
 	if err != nil {
-		response := HttpResponse{
-			StatusCode: 0,
-			Error:      fmt.Sprintf("Failed to create request: %v", err),
-		}
+		return &httpResult{Error: fmt.Sprintf("Failed to create request: %v", err)}, nil
+	}
 
-		respJSON, _ := json.Marshal(response)
-		return respJSON
+	if err := policy.CheckRequest(req.Method, req.URL); err != nil {
+		return &httpResult{Error: err.Error(), Violation: egressViolation(err)}, nil
+	}
+	if err := opts.tls.requireHTTPS(call.TLS, req.URL); err != nil {
+		return &httpResult{Error: err.Error()}, nil
 	}
 
-	// Set headers
-	for key, value := range httpReq.Headers {
-		req.Header.Set(key, value)
+	// Insert secrets into {{secret:name}} placeholders, the guest only ever sees the placeholders
+	headers, err := resolveSecretPlaceholders(call.Headers, opts.secrets)
+	if err != nil {
+		return &httpResult{Error: fmt.Sprintf("Failed to resolve secrets: %v", err)}, nil
+	}
+	if hasSecretPlaceholders(call.Headers) {
+		client = keepSecretsOnHost(client)
+	}
+
+	// Set headers, repeated values are sent as repeated headers
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
 	}
 
 	// Set default Content-Type for POST/PUT/PATCH requests with body
-	if httpReq.Body != "" && req.Header.Get("Content-Type") == "" {
-		if strings.ToUpper(httpReq.Method) == "POST" || strings.ToUpper(httpReq.Method) == "PUT" || strings.ToUpper(httpReq.Method) == "PATCH" {
+	if len(call.Body) > 0 && req.Header.Get("Content-Type") == "" {
+		if call.Method == "POST" || call.Method == "PUT" || call.Method == "PATCH" {
 			req.Header.Set("Content-Type", "application/json")
 		}
 	}
@@ -86,78 +238,95 @@ func performHttpRequest(requestJSON string) []byte {
 	// Perform the request
 	resp, err := client.Do(req)
 	if err != nil {
-		response := HttpResponse{
-			StatusCode: 0,
-			Error:      fmt.Sprintf("Request failed: %v", err),
+		if violation := egressViolation(err); violation != nil {
+			return &httpResult{Error: violation.Error(), Violation: violation}, nil
 		}
-		respJSON, _ := json.Marshal(response)
-		return respJSON
+		return &httpResult{Error: fmt.Sprintf("Request failed: %v", err)}, failedHttpExchange(req, err)
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	if count := headerCount(resp.Header); count > limits.MaxHeaders {
+		err := fmt.Errorf("%d response headers exceed %d", count, limits.MaxHeaders)
+		return httpLimitExceeded(resp.StatusCode, HttpLimitHeaders, limits, err.Error()), failedHttpExchange(resp.Request, err)
+	}
+
+	// Read response body, never buffering more than the limit
+	body, truncated, err := readLimitedBody(resp.Body, limits.MaxResponseBodyBytes)
 	if err != nil {
-		response := HttpResponse{
+		return &httpResult{
 			StatusCode: resp.StatusCode,
 			Error:      fmt.Sprintf("Failed to read response body: %v", err),
-		}
-		respJSON, _ := json.Marshal(response)
-		return respJSON
+		}, failedHttpExchange(resp.Request, err)
 	}
 
-	// Extract response headers
-	headers := make(map[string]string)
-	for key, values := range resp.Header {
-		if len(values) > 0 {
-			headers[key] = values[0] // Take the first value if multiple
-		}
-	}
-
-	// Create response
-	response := HttpResponse{
+	result := &httpResult{
 		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Body:       string(body),
+		Headers:    resp.Header,
+		Body:       body,
+		FinalURL:   resp.Request.URL.String(),
+		TLS:        newHttpTLSInfo(resp.TLS),
 	}
+	if truncated {
+		result.Truncated = true
+		result.LimitExceeded = HttpLimitResponseBody
+		result.Limits = &limits
+	}
+
+	return result, newHttpExchange(resp, body)
+}
 
-	respJSON, _ := json.Marshal(response)
-	return respJSON
+// httpLimitExceeded builds the result returned when a request or response exceeds a limit
+func httpLimitExceeded(statusCode int, limit string, limits HttpLimits, message string) *httpResult {
+	return &httpResult{
+		StatusCode:    statusCode,
+		Error:         message,
+		LimitExceeded: limit,
+		Limits:        &limits,
+	}
 }
 
-// Host function for fetching - now supports complete HTTP requests
+// Host function for fetching - now supports complete HTTP requests: http(request_ptr, request_len) -> handle
 func (h *host) http(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	if h.stopped.Load() {
+		return nil, wasmedge.Result_Terminate
+	}
+
 	// get request JSON from memory
-	pointer := params[0].(int32)
-	size := params[1].(int32)
-	mem := callframe.GetMemoryByIndex(0)
-	data, _ := mem.GetData(uint(pointer), uint(size))
-	requestData := make([]byte, size)
-	copy(requestData, data)
+	requestData, err := readGuestMemory(callframe, uint32(params[0].(int32)), uint32(params[1].(int32)))
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
 
 	requestStr := string(requestData)
 
-	// Try to parse as JSON first (new format), fallback to simple URL (legacy)
+	// JSON requests are performed in their schema version, anything else is a legacy URL
 	var respBody []byte
-	var httpReq HttpRequest
-	if err := json.Unmarshal([]byte(requestStr), &httpReq); err == nil {
-		// New format: complete HTTP request JSON
-		respBody = performHttpRequest(requestStr)
-	} else {
-		// Legacy format: simple URL string
-		legacyResp := fetch(requestStr)
-		if legacyResp == nil {
-			return nil, wasmedge.Result_Fail
+	var exchange *types.HttpExchange
+	call := ReplayCallHttp
+	perform := func() ([]byte, *types.HttpExchange) {
+		return performHttpRequest(h.ctx, h.httpOptions, requestStr)
+	}
+	if !isHttpRequestJSON(requestData) {
+		call = ReplayCallFetch
+		perform = func() ([]byte, *types.HttpExchange) {
+			return fetch(h.ctx, h.httpOptions, requestStr)
 		}
-		respBody = legacyResp
 	}
+	respBody, exchange, err = h.roundTrip(call, requestStr, perform)
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
+	h.record(exchange)
 
 	if respBody == nil {
 		return nil, wasmedge.Result_Fail
 	}
 
 	// store the response
-	h.fetchResult = respBody
+	handle, err := h.storeResult(respBody)
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
 
-	return []any{any(len(respBody))}, wasmedge.Result_Success
+	return []any{handle}, wasmedge.Result_Success
 }
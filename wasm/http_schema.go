@@ -0,0 +1,216 @@
+package wasm
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Schema versions of the http host function, selected by the version field of the request
+const (
+	HttpSchemaV1 = 1 // HttpRequest and HttpResponse, also used when the version field is absent
+	HttpSchemaV2 = 2 // HttpRequestV2 and HttpResponseV2
+)
+
+// Response body encodings of HttpRequestV2.BodyEncoding
+const (
+	HttpBodyBase64 = "base64" // The body is carried in HttpResponseV2.BodyBase64
+	HttpBodyRaw    = "raw"    // The body follows the response JSON as raw bytes
+)
+
+// HttpRequestV2 is the binary-safe request schema of the http host function
+type HttpRequestV2 struct {
+	Version      int                 `json:"version"`                 // Must be HttpSchemaV2
+	Method       string              `json:"method"`                  // Defaults to GET
+	URL          string              `json:"url"`                     // Request URL
+	Headers      map[string][]string `json:"headers,omitempty"`       // Repeated values are sent as repeated headers
+	Body         string              `json:"body,omitempty"`          // Text body
+	BodyBase64   string              `json:"body_base64,omitempty"`   // Binary body, exclusive with Body
+	Timeout      int                 `json:"timeout,omitempty"`       // timeout in seconds
+	BodyEncoding string              `json:"body_encoding,omitempty"` // Encoding of the response body, HttpBodyBase64 by default
+	TLS          *HttpTLSOptions     `json:"tls,omitempty"`           // Pins and host-held TLS material to use
+}
+
+// HttpResponseV2 is the binary-safe response schema of the http host function
+// With HttpBodyRaw the result is uint32(len(json)) || json || body, the length big-endian
+type HttpResponseV2 struct {
+	Version       int                 `json:"version"`
+	StatusCode    int                 `json:"status_code"`
+	Headers       map[string][]string `json:"headers"`                  // Every value of every header
+	BodyBase64    string              `json:"body_base64,omitempty"`    // Set with HttpBodyBase64
+	BodySize      int                 `json:"body_size"`                // Size of the decoded body in bytes
+	FinalURL      string              `json:"final_url,omitempty"`      // URL the response was served from, after redirects
+	Error         string              `json:"error,omitempty"`          // Set when the request failed
+	Violation     *EgressError        `json:"violation,omitempty"`      // Set when the egress policy rejected the request
+	Truncated     bool                `json:"truncated,omitempty"`      // The body was cut at Limits.MaxResponseBodyBytes
+	LimitExceeded string              `json:"limit_exceeded,omitempty"` // The HttpLimit that rejected the request or response
+	Limits        *HttpLimits         `json:"limits,omitempty"`         // The limits applied, set when one was reached
+	TLS           *HttpTLSInfo        `json:"tls,omitempty"`            // The verified connection, set for https responses
+}
+
+// httpCall is a guest request independent of the schema version it was sent in
+type httpCall struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+	Timeout int
+	TLS     *HttpTLSOptions
+}
+
+// httpResult is the outcome of an httpCall independent of the schema version it is returned in
+type httpResult struct {
+	StatusCode    int
+	Headers       http.Header
+	Body          []byte
+	FinalURL      string
+	Error         string
+	Violation     *EgressError
+	Truncated     bool
+	LimitExceeded string
+	Limits        *HttpLimits
+	TLS           *HttpTLSInfo
+}
+
+// isHttpRequestJSON reports whether a guest request is a JSON object rather than a legacy plain URL
+func isHttpRequestJSON(data []byte) bool {
+	var object map[string]json.RawMessage
+	return json.Unmarshal(data, &object) == nil
+}
+
+// decodeHttpRequest parses a request in any schema version
+// It returns the encoder producing the response in the same version, which is valid even on error
+func decodeHttpRequest(data []byte) (*httpCall, func(*httpResult) []byte, error) {
+	var probe struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, encodeHttpResponseV1, err
+	}
+
+	switch probe.Version {
+	case 0, HttpSchemaV1:
+		var request HttpRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, encodeHttpResponseV1, err
+		}
+
+		headers := make(http.Header, len(request.Headers))
+		for key, value := range request.Headers {
+			headers.Set(key, value)
+		}
+		return &httpCall{
+			Method:  request.Method,
+			URL:     request.URL,
+			Headers: headers,
+			Body:    []byte(request.Body),
+			Timeout: request.Timeout,
+			TLS:     request.TLS,
+		}, encodeHttpResponseV1, nil
+	case HttpSchemaV2:
+		encode := httpResponseV2Encoder(HttpBodyBase64)
+
+		var request HttpRequestV2
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, encode, err
+		}
+		switch request.BodyEncoding {
+		case "", HttpBodyBase64, HttpBodyRaw:
+		default:
+			return nil, encode, fmt.Errorf("unsupported body_encoding %q", request.BodyEncoding)
+		}
+		if request.BodyEncoding != "" {
+			encode = httpResponseV2Encoder(request.BodyEncoding)
+		}
+
+		body := []byte(request.Body)
+		if request.BodyBase64 != "" {
+			if request.Body != "" {
+				return nil, encode, fmt.Errorf("body and body_base64 are mutually exclusive")
+			}
+			decoded, err := base64.StdEncoding.DecodeString(request.BodyBase64)
+			if err != nil {
+				return nil, encode, fmt.Errorf("invalid body_base64: %v", err)
+			}
+			body = decoded
+		}
+
+		headers := make(http.Header, len(request.Headers))
+		for key, values := range request.Headers {
+			for _, value := range values {
+				headers.Add(key, value)
+			}
+		}
+		return &httpCall{
+			Method:  request.Method,
+			URL:     request.URL,
+			Headers: headers,
+			Body:    body,
+			Timeout: request.Timeout,
+			TLS:     request.TLS,
+		}, encode, nil
+	default:
+		// Answer in the newest schema so the guest learns which version the host speaks
+		return nil, httpResponseV2Encoder(HttpBodyBase64), fmt.Errorf("unsupported schema version %d", probe.Version)
+	}
+}
+
+// encodeHttpResponseV1 encodes a result in the original schema, keeping the first value of each header
+func encodeHttpResponseV1(result *httpResult) []byte {
+	var headers map[string]string
+	if result.Headers != nil {
+		headers = make(map[string]string, len(result.Headers))
+		for key, values := range result.Headers {
+			if len(values) > 0 {
+				headers[key] = values[0] // Take the first value if multiple
+			}
+		}
+	}
+
+	respJSON, _ := json.Marshal(HttpResponse{
+		StatusCode:    result.StatusCode,
+		Headers:       headers,
+		Body:          string(result.Body),
+		Error:         result.Error,
+		Violation:     result.Violation,
+		Truncated:     result.Truncated,
+		LimitExceeded: result.LimitExceeded,
+		Limits:        result.Limits,
+		TLS:           result.TLS,
+	})
+	return respJSON
+}
+
+// httpResponseV2Encoder returns an encoder of results in schema v2 with the given body encoding
+func httpResponseV2Encoder(bodyEncoding string) func(*httpResult) []byte {
+	return func(result *httpResult) []byte {
+		response := HttpResponseV2{
+			Version:       HttpSchemaV2,
+			StatusCode:    result.StatusCode,
+			Headers:       result.Headers,
+			BodySize:      len(result.Body),
+			FinalURL:      result.FinalURL,
+			Error:         result.Error,
+			Violation:     result.Violation,
+			Truncated:     result.Truncated,
+			LimitExceeded: result.LimitExceeded,
+			Limits:        result.Limits,
+			TLS:           result.TLS,
+		}
+		if bodyEncoding != HttpBodyRaw {
+			response.BodyBase64 = base64.StdEncoding.EncodeToString(result.Body)
+		}
+
+		respJSON, _ := json.Marshal(response)
+		if bodyEncoding != HttpBodyRaw {
+			return respJSON
+		}
+
+		out := make([]byte, 0, 4+len(respJSON)+len(result.Body))
+		out = binary.BigEndian.AppendUint32(out, uint32(len(respJSON)))
+		out = append(out, respJSON...)
+		return append(out, result.Body...)
+	}
+}
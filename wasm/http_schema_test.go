@@ -0,0 +1,153 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// binaryBody is not valid UTF-8, so it cannot survive the v1 string body
+var binaryBody = []byte{0x00, 0xff, 0xfe, 0x80, 'o', 'k'}
+
+// newSchemaTestServer serves a binary body with repeated headers behind a redirect and echoes request bodies
+func newSchemaTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/binary", http.StatusFound)
+	})
+	mux.HandleFunc("/binary", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.Write(binaryBody)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["X-Values"] = r.Header.Values("X-Values")
+		io.Copy(w, r.Body)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestHttpSchemaV2 - Verifies binary bodies, repeated headers and the final URL in schema v2
+func TestHttpSchemaV2(t *testing.T) {
+	server := newSchemaTestServer(t)
+	policy := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}}
+
+	request, _ := json.Marshal(HttpRequestV2{Version: HttpSchemaV2, URL: server.URL + "/old"})
+	respJSON, _ := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits}, string(request))
+
+	var response HttpResponseV2
+	if err := json.Unmarshal(respJSON, &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	body, _ := base64.StdEncoding.DecodeString(response.BodyBase64)
+	if response.Version != HttpSchemaV2 || !bytes.Equal(body, binaryBody) || response.BodySize != len(binaryBody) {
+		t.Errorf("Expected the binary body to round-trip, got %+v", response)
+	}
+	if cookies := response.Headers["Set-Cookie"]; len(cookies) != 2 || cookies[0] != "a=1" || cookies[1] != "b=2" {
+		t.Errorf("Expected every Set-Cookie value, got %v", cookies)
+	}
+	if response.FinalURL != server.URL+"/binary" {
+		t.Errorf("Expected final URL after redirect, got %q", response.FinalURL)
+	}
+
+	// Binary request bodies and repeated request headers reach the upstream intact
+	request, _ = json.Marshal(HttpRequestV2{
+		Version:      HttpSchemaV2,
+		Method:       "POST",
+		URL:          server.URL + "/echo",
+		Headers:      map[string][]string{"X-Values": {"1", "2"}},
+		BodyBase64:   base64.StdEncoding.EncodeToString(binaryBody),
+		BodyEncoding: HttpBodyRaw,
+	})
+	result, _ := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits}, string(request))
+
+	// Raw responses are uint32(len(json)) || json || body
+	if len(result) < 4 {
+		t.Fatalf("Raw response too short: %x", result)
+	}
+	size := binary.BigEndian.Uint32(result)
+	response = HttpResponseV2{}
+	if err := json.Unmarshal(result[4:4+size], &response); err != nil {
+		t.Fatalf("Failed to parse raw response header: %v", err)
+	}
+	if !bytes.Equal(result[4+size:], binaryBody) || response.BodyBase64 != "" || response.BodySize != len(binaryBody) {
+		t.Errorf("Expected raw body after the JSON, got %x", result[4+size:])
+	}
+	if values := response.Headers["X-Values"]; len(values) != 2 {
+		t.Errorf("Expected repeated request headers to be forwarded, got %v", values)
+	}
+}
+
+// TestHttpSchemaV1 - Verifies that requests without a version keep the original response shape
+func TestHttpSchemaV1(t *testing.T) {
+	server := newSchemaTestServer(t)
+	policy := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.1/32"}}
+
+	request, _ := json.Marshal(HttpRequest{URL: server.URL + "/binary"})
+	respJSON, _ := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits}, string(request))
+
+	var response map[string]any
+	if err := json.Unmarshal(respJSON, &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if _, ok := response["version"]; ok {
+		t.Errorf("Expected no version field in a v1 response")
+	}
+	if headers, ok := response["headers"].(map[string]any); !ok || headers["Set-Cookie"] != "a=1" {
+		t.Errorf("Expected single valued headers, got %v", response["headers"])
+	}
+	if _, ok := response["body"].(string); !ok {
+		t.Errorf("Expected a string body, got %v", response["body"])
+	}
+}
+
+// TestDecodeHttpRequest - Checks schema detection and request validation
+func TestDecodeHttpRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+		version int // Schema version of the response
+		valid   bool
+	}{
+		{name: "v1_implicit", request: `{"url":"https://example.com"}`, version: HttpSchemaV1, valid: true},
+		{name: "v1_explicit", request: `{"version":1,"url":"https://example.com"}`, version: HttpSchemaV1, valid: true},
+		{name: "v2", request: `{"version":2,"url":"https://example.com","headers":{"A":["1","2"]}}`, version: HttpSchemaV2, valid: true},
+		{name: "v2_both_bodies", request: `{"version":2,"url":"https://example.com","body":"a","body_base64":"YQ=="}`, version: HttpSchemaV2},
+		{name: "v2_bad_base64", request: `{"version":2,"url":"https://example.com","body_base64":"!"}`, version: HttpSchemaV2},
+		{name: "v2_bad_encoding", request: `{"version":2,"url":"https://example.com","body_encoding":"hex"}`, version: HttpSchemaV2},
+		{name: "unknown_version", request: `{"version":9,"url":"https://example.com"}`, version: HttpSchemaV2},
+		{name: "v1_with_v2_headers", request: `{"url":"https://example.com","headers":{"A":["1"]}}`, version: HttpSchemaV1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call, encode, err := decodeHttpRequest([]byte(tt.request))
+			if tt.valid != (err == nil) || tt.valid != (call != nil) {
+				t.Fatalf("Expected valid %v, got %v", tt.valid, err)
+			}
+
+			var response struct {
+				Version int `json:"version"`
+			}
+			json.Unmarshal(encode(&httpResult{Error: "x"}), &response)
+			if version := max(response.Version, HttpSchemaV1); version != tt.version {
+				t.Errorf("Expected a v%d response, got v%d", tt.version, version)
+			}
+		})
+	}
+
+	if isHttpRequestJSON([]byte("https://example.com")) || !isHttpRequestJSON([]byte(`{"url":"https://example.com"}`)) {
+		t.Errorf("Expected plain URLs to be told apart from JSON requests")
+	}
+}
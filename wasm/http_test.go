@@ -1,8 +1,16 @@
 package wasm
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -96,3 +104,151 @@ func TestHttpFunctions(t *testing.T) {
 		}
 	})
 }
+
+// TestHttpTranscript - Verifies that performed requests are recorded for the transcript
+func TestHttpTranscript(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprint(w, `{"price":"42"}`)
+	}))
+	defer server.Close()
+
+	policy := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}}
+	request, _ := json.Marshal(HttpRequest{Method: "post", URL: server.URL + "/price", Body: "{}"})
+	_, exchange := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits}, string(request))
+	if exchange == nil {
+		t.Fatalf("Expected the request to be recorded")
+	}
+
+	bodyHash := sha256.Sum256([]byte(`{"price":"42"}`))
+	if exchange.Method != "POST" || exchange.Url != server.URL+"/price" || exchange.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected exchange %v", exchange)
+	}
+	if exchange.BodyHash != hex.EncodeToString(bodyHash[:]) {
+		t.Errorf("Unexpected body hash %s", exchange.BodyHash)
+	}
+	if exchange.Headers["content-type"] != "application/json" || exchange.Headers["etag"] != `"v1"` || len(exchange.Headers) != 3 {
+		t.Errorf("Unexpected recorded headers %v", exchange.Headers)
+	}
+	if exchange.TlsCertFingerprint != "" {
+		t.Errorf("Expected no certificate fingerprint for plain HTTP")
+	}
+
+	// A transport failure is recorded with its error
+	server.Close()
+	_, exchange = fetch(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits}, server.URL)
+	if exchange == nil || exchange.Error == "" || exchange.StatusCode != 0 {
+		t.Errorf("Expected failed request to be recorded, got %v", exchange)
+	}
+}
+
+// TestHttpTranscriptTLS - Verifies that the server certificate fingerprint is recorded
+func TestHttpTranscriptTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	exchange := newHttpExchange(resp, []byte("ok"))
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	if exchange.TlsCertFingerprint != hex.EncodeToString(fingerprint[:]) {
+		t.Errorf("Unexpected certificate fingerprint %s", exchange.TlsCertFingerprint)
+	}
+}
+
+// TestHttpReplay - Replays recorded HTTP calls so the guest runs without network access
+func TestHttpReplay(t *testing.T) {
+	wasmBytes, err := os.ReadFile("rust_host_func/target/wasm32-wasip1/release/rust_host_func.wasm")
+	if err != nil {
+		t.Fatalf("Failed to read WASM file: %v", err)
+	}
+
+	recorded, _ := json.Marshal(HttpResponse{StatusCode: http.StatusOK, Body: `{"url":"https://httpbin.org/get"}`})
+	// The request exactly as test_http_get sends it
+	request := `{
+        "method": "GET",
+        "url": "https://httpbin.org/get",
+        "headers": {
+            "User-Agent": "WasmVM-TEE/1.0"
+        },
+        "timeout": 30
+    }`
+	bundle := &ReplayBundle{Entries: []ReplayEntry{{Call: ReplayCallHttp, Request: request, Response: recorded}}}
+
+	result, err := ExecuteWasmWithOptions(context.Background(), wasmBytes, "test_http_get", []any{}, ExecuteOptions{Replay: bundle, Record: true})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if len(result.Values) != 1 || result.Values[0] != string(recorded) {
+		t.Errorf("Expected the recorded response, got %v", result.Values)
+	}
+	if len(result.Replay.Entries) != 1 {
+		t.Errorf("Expected the replayed call to be captured, got %d entries", len(result.Replay.Entries))
+	}
+
+	// A request missing from the bundle fails the execution
+	_, err = ExecuteWasmWithOptions(context.Background(), wasmBytes, "test_http_post", []any{}, ExecuteOptions{Replay: bundle})
+	if !errors.Is(err, ErrReplayMismatch) {
+		t.Errorf("Expected %v, got %v", ErrReplayMismatch, err)
+	}
+}
+
+// TestHttpLimits - Verifies that oversized requests are rejected and oversized responses truncated
+func TestHttpLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 8; i++ {
+			w.Header().Add("X-Filler", fmt.Sprint(i))
+		}
+		fmt.Fprint(w, strings.Repeat("a", 1024))
+	}))
+	defer server.Close()
+
+	policy := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}}
+	perform := func(limits HttpLimits, request HttpRequest) HttpResponse {
+		t.Helper()
+		requestJSON, _ := json.Marshal(request)
+		respJSON, _ := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: limits.withDefaults()}, string(requestJSON))
+		var response HttpResponse
+		if err := json.Unmarshal(respJSON, &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	response := perform(HttpLimits{MaxResponseBodyBytes: 100}, HttpRequest{URL: server.URL})
+	if !response.Truncated || len(response.Body) != 100 || response.LimitExceeded != HttpLimitResponseBody {
+		t.Errorf("Expected truncated body, got %d bytes, truncated %v", len(response.Body), response.Truncated)
+	}
+	if response.Limits == nil || response.Limits.MaxResponseBodyBytes != 100 || response.StatusCode != http.StatusOK {
+		t.Errorf("Expected the applied limits to be reported, got %+v", response.Limits)
+	}
+
+	response = perform(HttpLimits{}, HttpRequest{URL: server.URL})
+	if response.Truncated || len(response.Body) != 1024 || response.Limits != nil {
+		t.Errorf("Expected the complete body, got %d bytes, truncated %v", len(response.Body), response.Truncated)
+	}
+
+	response = perform(HttpLimits{MaxRequestBodyBytes: 10}, HttpRequest{Method: "POST", URL: server.URL, Body: strings.Repeat("b", 11)})
+	if response.LimitExceeded != HttpLimitRequestBody || response.Error == "" || response.StatusCode != 0 {
+		t.Errorf("Expected request body limit, got %+v", response)
+	}
+
+	response = perform(HttpLimits{MaxHeaders: 4}, HttpRequest{URL: server.URL})
+	if response.LimitExceeded != HttpLimitHeaders || response.StatusCode != http.StatusOK || response.Body != "" {
+		t.Errorf("Expected response header limit, got %+v", response)
+	}
+
+	// fetch has no truncation flag, oversized bodies fail and are recorded as such
+	body, exchange := fetch(context.Background(), httpOptions{egress: policy, limits: (&HttpLimits{MaxResponseBodyBytes: 100}).withDefaults()}, server.URL)
+	if body != nil || exchange == nil || exchange.Error == "" {
+		t.Errorf("Expected oversized fetch to fail, got %d bytes, exchange %v", len(body), exchange)
+	}
+}
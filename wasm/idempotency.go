@@ -0,0 +1,136 @@
+package wasm
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// ErrRequestIDConflict is returned when a request_id is reused for a different execution
+var ErrRequestIDConflict = errors.New("request_id already used by another execution")
+
+// DefaultIdempotencyMaxEntries is the number of cached results kept when Config.IdempotencyMaxEntries is zero
+const DefaultIdempotencyMaxEntries = 65536
+
+// idempotencyStore caches execution results by request_id so retried requests are not executed twice
+// A retry must be the identical execution: the same request_id with other parameters is rejected.
+// Failed executions are not cached, so they can be retried. Entries live in memory for ttl, and beyond
+// maxEntries cached results the oldest are evicted early. Executions in flight are bounded by the pool.
+type idempotencyStore struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+	// expiry lists completed entries in completion order, which is also their expiry order
+	expiry []*idempotencyEntry
+}
+
+// idempotencyEntry is an execution in flight or its cached result
+type idempotencyEntry struct {
+	requestID   string
+	fingerprint [32]byte
+	done        chan struct{}
+
+	// Set before done is closed, result is nil when the execution failed
+	result  *types.WASMVMExecutionResult
+	expires time.Time
+}
+
+// newIdempotencyStore creates a store keeping at most maxEntries results for ttl
+// A zero maxEntries uses DefaultIdempotencyMaxEntries.
+func newIdempotencyStore(ttl time.Duration, maxEntries int) *idempotencyStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultIdempotencyMaxEntries
+	}
+	return &idempotencyStore{ttl: ttl, maxEntries: maxEntries, now: time.Now, entries: make(map[string]*idempotencyEntry)}
+}
+
+// Do returns the cached result of the execution's request_id, or runs execute and caches its result
+// Concurrent calls for the same request_id wait for the first one instead of executing again.
+func (s *idempotencyStore) Do(ctx context.Context, execution *types.WASMVMExecution, execute func() (*types.WASMVMExecutionResult, error)) (*types.WASMVMExecutionResult, error) {
+	if execution.RequestId == "" {
+		return execute()
+	}
+
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(execution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode execution: %v", err)
+	}
+	fingerprint := sha256.Sum256(encoded)
+
+	for {
+		s.mu.Lock()
+		s.expire()
+		entry, ok := s.entries[execution.RequestId]
+		if !ok {
+			entry = &idempotencyEntry{requestID: execution.RequestId, fingerprint: fingerprint, done: make(chan struct{})}
+			s.entries[execution.RequestId] = entry
+			s.mu.Unlock()
+			return s.run(entry, execute)
+		}
+		s.mu.Unlock()
+
+		if entry.fingerprint != fingerprint {
+			return nil, fmt.Errorf("%w: %q", ErrRequestIDConflict, execution.RequestId)
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.result != nil {
+			return entry.result, nil
+		}
+		// The execution we waited for failed and was dropped, run it again
+	}
+}
+
+// run executes entry and publishes its outcome to the callers waiting on it
+func (s *idempotencyStore) run(entry *idempotencyEntry, execute func() (*types.WASMVMExecutionResult, error)) (*types.WASMVMExecutionResult, error) {
+	result, err := execute()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		delete(s.entries, entry.requestID)
+	} else {
+		entry.result = result
+		entry.expires = s.now().Add(s.ttl)
+		s.expiry = append(s.expiry, entry)
+		if len(s.expiry) > s.maxEntries {
+			s.evict(len(s.expiry) - s.maxEntries)
+		}
+	}
+	close(entry.done)
+
+	return result, err
+}
+
+// expire drops the cached results whose ttl has elapsed, s.mu must be held
+func (s *idempotencyStore) expire() {
+	now := s.now()
+	n := 0
+	for n < len(s.expiry) && !now.Before(s.expiry[n].expires) {
+		n++
+	}
+	s.evict(n)
+}
+
+// evict drops the n oldest cached results, s.mu must be held
+func (s *idempotencyStore) evict(n int) {
+	for _, entry := range s.expiry[:n] {
+		delete(s.entries, entry.requestID)
+	}
+	clear(s.expiry[:n])
+	s.expiry = s.expiry[n:]
+}
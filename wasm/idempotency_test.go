@@ -0,0 +1,108 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestIdempotencyStore - Verifies that retries are served from the cache, conflicts rejected and failures retried
+func TestIdempotencyStore(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	store := newIdempotencyStore(time.Minute, 0)
+	store.now = func() time.Time { return now }
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	execute := func() (*types.WASMVMExecutionResult, error) {
+		runs.Add(1)
+		<-release
+		return &types.WASMVMExecutionResult{GasUsed: uint64(runs.Load())}, nil
+	}
+	execution := &types.WASMVMExecution{RequestId: "req-1", FnName: "price", Nonce: []byte{1}}
+
+	// Concurrent retries wait for the first execution
+	results := make([]*types.WASMVMExecutionResult, 4)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = store.Do(context.Background(), execution, execute)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if runs.Load() != 1 {
+		t.Fatalf("Expected a single execution, got %d", runs.Load())
+	}
+	for i, result := range results {
+		if result != results[0] {
+			t.Errorf("Retry %d got another result %v", i, result)
+		}
+	}
+
+	// The same request_id with other parameters is rejected
+	replayed := &types.WASMVMExecution{RequestId: "req-1", FnName: "price", Nonce: []byte{2}}
+	_, err := store.Do(context.Background(), replayed, execute)
+	if !errors.Is(err, ErrRequestIDConflict) || status.Code(executionStatusError(err)) != codes.AlreadyExists {
+		t.Errorf("Expected %v, got %v", ErrRequestIDConflict, err)
+	}
+
+	// Results expire after the ttl
+	now = now.Add(time.Minute)
+	if result, _ := store.Do(context.Background(), execution, execute); result == results[0] || runs.Load() != 2 {
+		t.Errorf("Expected the result to expire and the execution to run again")
+	}
+
+	// Failures are not cached, requests without a request_id never are
+	failing := &types.WASMVMExecution{RequestId: "req-2"}
+	for range 2 {
+		store.Do(context.Background(), failing, func() (*types.WASMVMExecutionResult, error) {
+			runs.Add(1)
+			return nil, errors.New("guest trapped")
+		})
+	}
+	for range 2 {
+		store.Do(context.Background(), &types.WASMVMExecution{}, execute)
+	}
+	if runs.Load() != 6 {
+		t.Errorf("Expected failed and anonymous executions to run every time, got %d runs", runs.Load())
+	}
+}
+
+// TestIdempotencyStoreEviction - Verifies that beyond maxEntries the oldest results are evicted first
+func TestIdempotencyStoreEviction(t *testing.T) {
+	store := newIdempotencyStore(time.Hour, 2)
+
+	var runs atomic.Int32
+	execute := func() (*types.WASMVMExecutionResult, error) {
+		return &types.WASMVMExecutionResult{GasUsed: uint64(runs.Add(1))}, nil
+	}
+	for _, id := range []string{"req-1", "req-2", "req-3"} {
+		store.Do(context.Background(), &types.WASMVMExecution{RequestId: id}, execute)
+	}
+	if len(store.entries) != 2 || len(store.expiry) != 2 {
+		t.Fatalf("Expected 2 cached results, got %d entries and %d in expiry", len(store.entries), len(store.expiry))
+	}
+
+	// The newest results are still served from the cache, the oldest runs again
+	for _, id := range []string{"req-2", "req-3", "req-1"} {
+		store.Do(context.Background(), &types.WASMVMExecution{RequestId: id}, execute)
+	}
+	if runs.Load() != 4 {
+		t.Errorf("Expected only the evicted request to run again, got %d runs", runs.Load())
+	}
+	if _, ok := store.entries["req-2"]; ok {
+		t.Errorf("Expected req-2 to be evicted by req-1")
+	}
+}
@@ -0,0 +1,304 @@
+package wasm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// Defaults of the job settings left at zero in Config
+const (
+	DefaultJobQueueSize   = 1024
+	DefaultJobRetention   = time.Hour
+	DefaultJobMaxRetained = 10000
+)
+
+var (
+	// ErrJobNotFound is returned when no job of the owner token is known under the requested id, or it has expired
+	ErrJobNotFound = errors.New("execution job not found")
+	// ErrJobQueueFull is returned when SubmitExecution would exceed the job queue size
+	ErrJobQueueFull = errors.New("execution job queue is full")
+)
+
+// jobManager runs submitted executions in the background on a bounded number of workers
+// Jobs wait in a FIFO queue, and finished jobs are kept for the retention period so their result can be retrieved.
+// Beyond maxRetained finished jobs the oldest are dropped early.
+type jobManager struct {
+	execute     func(ctx context.Context, execution *types.WASMVMExecution, client string) (*types.WASMVMExecutionResult, error)
+	workers     int
+	queueSize   int
+	retention   time.Duration
+	maxRetained int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	jobs     map[string]*job
+	order    []*job // Jobs in submission order
+	queue    []*job // Queued jobs in submission order
+	finished []*job // Finished jobs in completion order, which is also their expiry order
+	running  int    // Active worker goroutines
+}
+
+// job is a submitted execution, its state is guarded by jobManager.mu
+type job struct {
+	state     *types.ExecutionJob
+	execution *types.WASMVMExecution
+	client    string   // Fair queuing key, not an owner
+	owner     [32]byte // SHA-256 of the owner token
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// newJobManager creates a job manager running jobs with execute
+// Zero settings use runtime.NumCPU workers, DefaultJobQueueSize, DefaultJobRetention and DefaultJobMaxRetained.
+func newJobManager(execute func(ctx context.Context, execution *types.WASMVMExecution, client string) (*types.WASMVMExecutionResult, error), workers, queueSize int, retention time.Duration, maxRetained int) *jobManager {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultJobQueueSize
+	}
+	if retention <= 0 {
+		retention = DefaultJobRetention
+	}
+	if maxRetained <= 0 {
+		maxRetained = DefaultJobMaxRetained
+	}
+
+	return &jobManager{
+		execute:     execute,
+		workers:     workers,
+		queueSize:   queueSize,
+		retention:   retention,
+		maxRetained: maxRetained,
+		now:         time.Now,
+		jobs:        make(map[string]*job),
+	}
+}
+
+// Submit queues an execution of client and returns its job
+// The job is bound to ownerToken, which is required to get, cancel or list it.
+func (m *jobManager) Submit(execution *types.WASMVMExecution, client string, ownerToken []byte) (*types.ExecutionJob, error) {
+	owner, err := ownerHash(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expire()
+
+	if len(m.queue) >= m.queueSize {
+		return nil, fmt.Errorf("%w: %d jobs queued", ErrJobQueueFull, len(m.queue))
+	}
+
+	j := &job{
+		execution: execution,
+		client:    client,
+		owner:     owner,
+		state: &types.ExecutionJob{
+			Id:          id,
+			RequestId:   execution.RequestId,
+			State:       types.ExecutionState_EXECUTION_STATE_QUEUED,
+			SubmittedAt: m.now().Unix(),
+		},
+	}
+	m.jobs[id] = j
+	m.order = append(m.order, j)
+	m.queue = append(m.queue, j)
+
+	// Workers exit once the queue is drained, start one if there is room
+	if m.running < m.workers {
+		m.running++
+		go m.work()
+	}
+
+	return proto.Clone(j.state).(*types.ExecutionJob), nil
+}
+
+// Get returns the job with the given id owned by ownerToken, including its result
+func (m *jobManager) Get(id string, ownerToken []byte) (*types.ExecutionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expire()
+
+	j, err := m.lookup(id, ownerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(j.state).(*types.ExecutionJob), nil
+}
+
+// Cancel stops a queued or running job owned by ownerToken, finished jobs are returned unchanged
+func (m *jobManager) Cancel(id string, ownerToken []byte) (*types.ExecutionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expire()
+
+	j, err := m.lookup(id, ownerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	switch j.state.State {
+	case types.ExecutionState_EXECUTION_STATE_QUEUED:
+		for i, queued := range m.queue {
+			if queued == j {
+				m.queue = append(m.queue[:i], m.queue[i+1:]...)
+				break
+			}
+		}
+		j.cancelled = true
+		m.finish(j, nil, context.Canceled)
+	case types.ExecutionState_EXECUTION_STATE_RUNNING:
+		// The worker records the cancellation once the execution returns
+		j.cancelled = true
+		j.cancel()
+	}
+
+	return proto.Clone(j.state).(*types.ExecutionJob), nil
+}
+
+// List returns the jobs owned by ownerToken in submission order without their results, optionally only those in state
+func (m *jobManager) List(ownerToken []byte, state types.ExecutionState) ([]*types.ExecutionJob, error) {
+	owner, err := ownerHash(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expire()
+
+	jobs := make([]*types.ExecutionJob, 0)
+	for _, j := range m.order {
+		if j.owner != owner {
+			continue
+		}
+		if state != types.ExecutionState_EXECUTION_STATE_UNSPECIFIED && j.state.State != state {
+			continue
+		}
+		summary := proto.Clone(j.state).(*types.ExecutionJob)
+		summary.Result = nil
+		jobs = append(jobs, summary)
+	}
+
+	return jobs, nil
+}
+
+// lookup returns the job with the given id if ownerToken owns it, m.mu must be held
+// Jobs of other owners are reported as not found so their ids cannot be probed.
+func (m *jobManager) lookup(id string, ownerToken []byte) (*job, error) {
+	j, ok := m.jobs[id]
+	if !ok || !ownedBy(j.owner[:], ownerToken) {
+		return nil, fmt.Errorf("%w: %q", ErrJobNotFound, id)
+	}
+	return j, nil
+}
+
+// work runs queued jobs until the queue is empty
+func (m *jobManager) work() {
+	for {
+		m.mu.Lock()
+		if len(m.queue) == 0 {
+			m.running--
+			m.mu.Unlock()
+			return
+		}
+		j := m.queue[0]
+		m.queue = m.queue[1:]
+
+		ctx, cancel := context.WithCancel(context.Background())
+		j.cancel = cancel
+		j.state.State = types.ExecutionState_EXECUTION_STATE_RUNNING
+		j.state.StartedAt = m.now().Unix()
+		m.mu.Unlock()
+
+		result, err := m.execute(ctx, j.execution, j.client)
+		cancel()
+
+		m.mu.Lock()
+		m.finish(j, result, err)
+		m.mu.Unlock()
+	}
+}
+
+// finish records the outcome of a job and schedules its expiry, m.mu must be held
+func (m *jobManager) finish(j *job, result *types.WASMVMExecutionResult, err error) {
+	now := m.now()
+	j.state.FinishedAt = now.Unix()
+	j.state.ExpiresAt = now.Add(m.retention).Unix()
+	m.finished = append(m.finished, j)
+	if len(m.finished) > m.maxRetained {
+		m.drop(len(m.finished) - m.maxRetained)
+	}
+
+	switch {
+	case err == nil:
+		j.state.State = types.ExecutionState_EXECUTION_STATE_SUCCEEDED
+		j.state.Result = result
+	case j.cancelled && errors.Is(err, context.Canceled):
+		j.state.State = types.ExecutionState_EXECUTION_STATE_CANCELLED
+		j.state.Error = "execution cancelled"
+		j.state.ErrorCode = int32(status.Code(executionStatusError(err)))
+	default:
+		statusErr := status.Convert(executionStatusError(err))
+		j.state.State = types.ExecutionState_EXECUTION_STATE_FAILED
+		j.state.Error = statusErr.Message()
+		j.state.ErrorCode = int32(statusErr.Code())
+	}
+}
+
+// expire drops finished jobs past their retention, m.mu must be held
+func (m *jobManager) expire() {
+	now := m.now().Unix()
+	n := 0
+	for n < len(m.finished) && m.finished[n].state.ExpiresAt <= now {
+		n++
+	}
+	if n > 0 {
+		m.drop(n)
+	}
+}
+
+// drop forgets the n oldest finished jobs, m.mu must be held
+func (m *jobManager) drop(n int) {
+	for _, j := range m.finished[:n] {
+		delete(m.jobs, j.state.Id)
+	}
+	clear(m.finished[:n])
+	m.finished = m.finished[n:]
+
+	order := m.order[:0]
+	for _, j := range m.order {
+		if _, ok := m.jobs[j.state.Id]; ok {
+			order = append(order, j)
+		}
+	}
+	clear(m.order[len(order):])
+	m.order = order
+}
+
+// newJobID returns a random job identifier
+func newJobID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %v", err)
+	}
+	return hex.EncodeToString(id), nil
+}
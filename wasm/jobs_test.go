@@ -0,0 +1,225 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// Owner tokens of the jobs submitted in tests
+var (
+	testOwnerToken  = []byte("test-owner-token-0001")
+	otherOwnerToken = []byte("test-owner-token-0002")
+)
+
+// listJobs lists the jobs of ownerToken, failing the test on error
+func listJobs(t *testing.T, m *jobManager, ownerToken []byte, state types.ExecutionState) []*types.ExecutionJob {
+	t.Helper()
+
+	jobs, err := m.List(ownerToken, state)
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+	return jobs
+}
+
+// waitForJob polls a job until it reaches state
+func waitForJob(t *testing.T, m *jobManager, id string, state types.ExecutionState) *types.ExecutionJob {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := m.Get(id, testOwnerToken)
+		if err != nil {
+			t.Fatalf("Failed to get job: %v", err)
+		}
+		if job.State == state {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job %s is %v, expected %v", id, job.State, state)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestJobManager - Verifies job states, cancellation, queue bounds and retention
+func TestJobManager(t *testing.T) {
+	release := make(chan struct{})
+	execute := func(ctx context.Context, execution *types.WASMVMExecution, client string) (*types.WASMVMExecutionResult, error) {
+		switch execution.FnName {
+		case "fail":
+			return nil, ErrGasLimitExceeded
+		case "block":
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return &types.WASMVMExecutionResult{GasUsed: 42}, nil
+	}
+	m := newJobManager(execute, 1, 2, time.Minute, 0)
+	now := time.Unix(1_700_000_000, 0)
+	m.now = func() time.Time { return now }
+
+	// A single worker runs the first job, the next ones queue behind it
+	running, err := m.Submit(&types.WASMVMExecution{RequestId: "req-1", FnName: "block"}, "client", testOwnerToken)
+	if err != nil || running.State != types.ExecutionState_EXECUTION_STATE_QUEUED || running.RequestId != "req-1" {
+		t.Fatalf("Unexpected job %v, %v", running, err)
+	}
+	waitForJob(t, m, running.Id, types.ExecutionState_EXECUTION_STATE_RUNNING)
+	queued, _ := m.Submit(&types.WASMVMExecution{FnName: "ok"}, "client", testOwnerToken)
+	failing, _ := m.Submit(&types.WASMVMExecution{FnName: "fail"}, "client", testOwnerToken)
+	if _, err := m.Submit(&types.WASMVMExecution{}, "client", testOwnerToken); !errors.Is(err, ErrJobQueueFull) || status.Code(jobStatusError(err)) != codes.ResourceExhausted {
+		t.Errorf("Expected %v, got %v", ErrJobQueueFull, err)
+	}
+
+	// Cancelling a queued job removes it from the queue
+	cancelled, err := m.Cancel(queued.Id, testOwnerToken)
+	if err != nil || cancelled.State != types.ExecutionState_EXECUTION_STATE_CANCELLED || cancelled.ErrorCode != int32(codes.Canceled) {
+		t.Errorf("Unexpected cancelled job %v, %v", cancelled, err)
+	}
+
+	close(release)
+	done := waitForJob(t, m, running.Id, types.ExecutionState_EXECUTION_STATE_SUCCEEDED)
+	if done.Result.GetGasUsed() != 42 || done.ExpiresAt != now.Add(time.Minute).Unix() {
+		t.Errorf("Unexpected finished job %v", done)
+	}
+	failed := waitForJob(t, m, failing.Id, types.ExecutionState_EXECUTION_STATE_FAILED)
+	if failed.ErrorCode != int32(codes.ResourceExhausted) || failed.Error == "" {
+		t.Errorf("Unexpected failed job %v", failed)
+	}
+
+	// Cancelling a running job stops its execution
+	release = make(chan struct{})
+	blocked, _ := m.Submit(&types.WASMVMExecution{FnName: "block"}, "client", testOwnerToken)
+	waitForJob(t, m, blocked.Id, types.ExecutionState_EXECUTION_STATE_RUNNING)
+	if _, err := m.Cancel(blocked.Id, testOwnerToken); err != nil {
+		t.Fatalf("Failed to cancel job: %v", err)
+	}
+	waitForJob(t, m, blocked.Id, types.ExecutionState_EXECUTION_STATE_CANCELLED)
+	if again, _ := m.Cancel(running.Id, testOwnerToken); again.State != types.ExecutionState_EXECUTION_STATE_SUCCEEDED {
+		t.Errorf("Expected a finished job to be returned unchanged, got %v", again.State)
+	}
+
+	// Listing keeps submission order and leaves results out
+	jobs := listJobs(t, m, testOwnerToken, types.ExecutionState_EXECUTION_STATE_UNSPECIFIED)
+	if len(jobs) != 4 || jobs[0].Id != running.Id || jobs[0].Result != nil {
+		t.Errorf("Unexpected jobs %v", jobs)
+	}
+	if succeeded := listJobs(t, m, testOwnerToken, types.ExecutionState_EXECUTION_STATE_SUCCEEDED); len(succeeded) != 1 {
+		t.Errorf("Expected one succeeded job, got %d", len(succeeded))
+	}
+
+	// Callers without the owner token can neither see nor cancel the jobs, whatever their client id
+	if jobs := listJobs(t, m, otherOwnerToken, types.ExecutionState_EXECUTION_STATE_UNSPECIFIED); len(jobs) != 0 {
+		t.Errorf("Expected no jobs for another owner, got %v", jobs)
+	}
+	if _, err := m.Get(running.Id, otherOwnerToken); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected %v for another owner, got %v", ErrJobNotFound, err)
+	}
+	if _, err := m.Cancel(running.Id, otherOwnerToken); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected %v for another owner, got %v", ErrJobNotFound, err)
+	}
+	if _, err := m.List(nil, types.ExecutionState_EXECUTION_STATE_UNSPECIFIED); !errors.Is(err, ErrInvalidOwnerToken) || status.Code(jobStatusError(err)) != codes.InvalidArgument {
+		t.Errorf("Expected %v without an owner token, got %v", ErrInvalidOwnerToken, err)
+	}
+	if _, err := m.Submit(&types.WASMVMExecution{}, "client", []byte("short")); !errors.Is(err, ErrInvalidOwnerToken) {
+		t.Errorf("Expected %v for a short owner token, got %v", ErrInvalidOwnerToken, err)
+	}
+
+	// Finished jobs are dropped after the retention period
+	now = now.Add(time.Minute)
+	if _, err := m.Get(running.Id, testOwnerToken); !errors.Is(err, ErrJobNotFound) || status.Code(jobStatusError(err)) != codes.NotFound {
+		t.Errorf("Expected %v, got %v", ErrJobNotFound, err)
+	}
+	if jobs := listJobs(t, m, testOwnerToken, types.ExecutionState_EXECUTION_STATE_UNSPECIFIED); len(jobs) != 0 {
+		t.Errorf("Expected every job to expire, got %v", jobs)
+	}
+}
+
+// TestJobManagerExpiry - Verifies that finished jobs expire regardless of older running ones, and that retention is bounded
+func TestJobManagerExpiry(t *testing.T) {
+	release := make(chan struct{})
+	execute := func(ctx context.Context, execution *types.WASMVMExecution, client string) (*types.WASMVMExecutionResult, error) {
+		if execution.FnName == "block" {
+			<-release
+		}
+		return &types.WASMVMExecutionResult{}, nil
+	}
+	m := newJobManager(execute, 2, 8, time.Minute, 2)
+	var mu sync.Mutex
+	now := time.Unix(1_700_000_000, 0)
+	m.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+
+	// A job finishing after an older, still running one expires on its own schedule
+	long, _ := m.Submit(&types.WASMVMExecution{FnName: "block"}, "client", testOwnerToken)
+	waitForJob(t, m, long.Id, types.ExecutionState_EXECUTION_STATE_RUNNING)
+	short, _ := m.Submit(&types.WASMVMExecution{}, "client", testOwnerToken)
+	waitForJob(t, m, short.Id, types.ExecutionState_EXECUTION_STATE_SUCCEEDED)
+	advance(time.Minute)
+	if _, err := m.Get(short.Id, testOwnerToken); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected the finished job to expire behind the running one, got %v", err)
+	}
+	if jobs := listJobs(t, m, testOwnerToken, types.ExecutionState_EXECUTION_STATE_UNSPECIFIED); len(jobs) != 1 || jobs[0].Id != long.Id {
+		t.Errorf("Expected only the running job to be listed, got %v", jobs)
+	}
+
+	// Beyond maxRetained finished jobs the oldest are dropped first
+	ids := make([]string, 3)
+	for i := range ids {
+		job, _ := m.Submit(&types.WASMVMExecution{}, "client", testOwnerToken)
+		waitForJob(t, m, job.Id, types.ExecutionState_EXECUTION_STATE_SUCCEEDED)
+		ids[i] = job.Id
+	}
+	if _, err := m.Get(ids[0], testOwnerToken); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected the oldest finished job to be dropped, got %v", err)
+	}
+	if jobs := listJobs(t, m, testOwnerToken, types.ExecutionState_EXECUTION_STATE_UNSPECIFIED); len(jobs) != 3 || jobs[0].Id != long.Id || jobs[1].Id != ids[1] {
+		t.Errorf("Expected the running job and the 2 newest finished ones, got %v", jobs)
+	}
+	close(release)
+	waitForJob(t, m, long.Id, types.ExecutionState_EXECUTION_STATE_SUCCEEDED)
+	if _, err := m.Get(ids[1], testOwnerToken); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected the oldest finished job to be dropped when the running one finished, got %v", err)
+	}
+}
+
+// TestSubmitExecution - Verifies that submissions are validated before they are queued
+func TestSubmitExecution(t *testing.T) {
+	s, err := NewServer(Config{MaxClockSkew: time.Minute, Attester: newTestAttester(t)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	if _, err := s.SubmitExecution(context.Background(), &types.SubmitExecutionRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a missing execution, got %v", err)
+	}
+	stale := &types.SubmitExecutionRequest{Execution: &types.WASMVMExecution{Timestamp: 1}}
+	if _, err := s.SubmitExecution(context.Background(), stale); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a stale execution, got %v", err)
+	}
+	if _, err := s.GetExecution(context.Background(), &types.GetExecutionRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown job, got %v", err)
+	}
+	if _, err := s.ListExecutions(context.Background(), &types.ListExecutionsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a listing without owner token, got %v", err)
+	}
+}
@@ -0,0 +1,383 @@
+package wasm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+var (
+	// ErrModuleNotFound is returned when no module is stored under the requested hash
+	ErrModuleNotFound = errors.New("module not found")
+	// ErrInvalidModuleHash is returned when a module hash is not a hex encoded SHA-256 digest
+	ErrInvalidModuleHash = errors.New("invalid module hash")
+	// ErrModuleOwner is returned when a module is deleted without an owner token it was uploaded with
+	ErrModuleOwner = errors.New("owner token does not match the module")
+)
+
+// ModuleStore persists WASM modules addressed by the SHA-256 of their bytecode
+// Identical bytecode uploaded by several owners is stored once and kept until every owner has deleted it,
+// so no client can remove a module another one relies on.
+type ModuleStore interface {
+	// Put stores the bytecode for the owner of ownerToken and returns its description
+	Put(bytecode []byte, ownerToken []byte) (*types.WasmModule, error)
+	// Get returns the bytecode and description of the module with the given hash
+	Get(hash string) ([]byte, *types.WasmModule, error)
+	// List returns the descriptions of all stored modules ordered by hash
+	List() ([]*types.WasmModule, error)
+	// Delete drops the owner of ownerToken from the module, and reports whether it was the last one and the module removed
+	Delete(hash string, ownerToken []byte) (bool, error)
+}
+
+// ModuleHash returns the hex encoded SHA-256 digest identifying the bytecode
+func ModuleHash(bytecode []byte) string {
+	sum := sha256.Sum256(bytecode)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeModuleHash validates a module hash and returns it in lower case
+func normalizeModuleHash(hash string) (string, error) {
+	hash = strings.ToLower(hash)
+	if len(hash) != sha256.Size*2 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidModuleHash, hash)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidModuleHash, hash)
+	}
+
+	return hash, nil
+}
+
+// MemoryModuleStore keeps modules in process memory
+type MemoryModuleStore struct {
+	mu      sync.RWMutex
+	modules map[string]*memoryModule
+}
+
+type memoryModule struct {
+	bytecode []byte
+	info     *types.WasmModule
+	owners   map[[32]byte]bool // SHA-256 of the owner tokens
+}
+
+var _ ModuleStore = (*MemoryModuleStore)(nil)
+
+// NewMemoryModuleStore creates an empty in-memory module store
+func NewMemoryModuleStore() *MemoryModuleStore {
+	return &MemoryModuleStore{modules: make(map[string]*memoryModule)}
+}
+
+func (m *MemoryModuleStore) Put(bytecode []byte, ownerToken []byte) (*types.WasmModule, error) {
+	owner, err := ownerHash(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+	hash := ModuleHash(bytecode)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.modules[hash]; ok {
+		existing.owners[owner] = true
+		return existing.info, nil
+	}
+
+	info := &types.WasmModule{
+		Hash:      hash,
+		Size:      uint64(len(bytecode)),
+		CreatedAt: time.Now().Unix(),
+	}
+	m.modules[hash] = &memoryModule{
+		bytecode: append([]byte(nil), bytecode...),
+		info:     info,
+		owners:   map[[32]byte]bool{owner: true},
+	}
+
+	return info, nil
+}
+
+func (m *MemoryModuleStore) Get(hash string) ([]byte, *types.WasmModule, error) {
+	hash, err := normalizeModuleHash(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	module, ok := m.modules[hash]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrModuleNotFound, hash)
+	}
+
+	return module.bytecode, module.info, nil
+}
+
+func (m *MemoryModuleStore) List() ([]*types.WasmModule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	modules := make([]*types.WasmModule, 0, len(m.modules))
+	for _, module := range m.modules {
+		modules = append(modules, module.info)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Hash < modules[j].Hash })
+
+	return modules, nil
+}
+
+func (m *MemoryModuleStore) Delete(hash string, ownerToken []byte) (bool, error) {
+	hash, err := normalizeModuleHash(hash)
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	module, ok := m.modules[hash]
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrModuleNotFound, hash)
+	}
+	owner := sha256.Sum256(ownerToken)
+	if !module.owners[owner] {
+		return false, fmt.Errorf("%w: %s", ErrModuleOwner, hash)
+	}
+	delete(module.owners, owner)
+	if len(module.owners) > 0 {
+		return false, nil
+	}
+	delete(m.modules, hash)
+
+	return true, nil
+}
+
+// FileModuleStore keeps modules as <hash>.wasm files in a local directory, next to <hash>.owners files listing
+// the SHA-256 of their owner tokens in hex, one per line
+// The directory lives outside the TEE's protected memory, so bytecode is re-hashed on every read
+type FileModuleStore struct {
+	dir string
+
+	// mu serializes the updates of module and owner files
+	mu sync.Mutex
+}
+
+var _ ModuleStore = (*FileModuleStore)(nil)
+
+// NewFileModuleStore creates a module store backed by dir
+func NewFileModuleStore(dir string) (*FileModuleStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create module store directory: %w", err)
+	}
+
+	return &FileModuleStore{dir: dir}, nil
+}
+
+func (f *FileModuleStore) path(hash string) string {
+	return filepath.Join(f.dir, hash+".wasm")
+}
+
+func (f *FileModuleStore) ownersPath(hash string) string {
+	return filepath.Join(f.dir, hash+".owners")
+}
+
+func (f *FileModuleStore) Put(bytecode []byte, ownerToken []byte) (*types.WasmModule, error) {
+	owner, err := ownerHash(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+	hash := ModuleHash(bytecode)
+	path := f.path(hash)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	owners, err := f.readOwners(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !owners[owner] {
+		owners[owner] = true
+		if err := f.writeOwners(hash, owners); err != nil {
+			return nil, err
+		}
+	}
+
+	if stat, err := os.Stat(path); err == nil {
+		return fileModuleInfo(hash, stat), nil
+	}
+
+	// Write into a temporary file and rename so readers never observe a partial module
+	if err := writeFileAtomic(f.dir, path, bytecode); err != nil {
+		return nil, fmt.Errorf("failed to store module file: %w", err)
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat module file: %w", err)
+	}
+
+	return fileModuleInfo(hash, stat), nil
+}
+
+func (f *FileModuleStore) Get(hash string) ([]byte, *types.WasmModule, error) {
+	hash, err := normalizeModuleHash(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := f.path(hash)
+	bytecode, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrModuleNotFound, hash)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read module file: %w", err)
+	}
+
+	if actual := ModuleHash(bytecode); actual != hash {
+		return nil, nil, fmt.Errorf("module file %s is corrupted: hash %s", path, actual)
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stat module file: %w", err)
+	}
+
+	return bytecode, fileModuleInfo(hash, stat), nil
+}
+
+func (f *FileModuleStore) List() ([]*types.WasmModule, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module store directory: %w", err)
+	}
+
+	modules := make([]*types.WasmModule, 0, len(entries))
+	for _, entry := range entries {
+		hash, ok := strings.CutSuffix(entry.Name(), ".wasm")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if _, err := normalizeModuleHash(hash); err != nil {
+			continue
+		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		modules = append(modules, fileModuleInfo(hash, stat))
+	}
+
+	return modules, nil
+}
+
+// Delete drops an owner of the module, modules stored without an owners file can only be removed by the operator
+func (f *FileModuleStore) Delete(hash string, ownerToken []byte) (bool, error) {
+	hash, err := normalizeModuleHash(hash)
+	if err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := os.Stat(f.path(hash)); errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("%w: %s", ErrModuleNotFound, hash)
+	}
+	owners, err := f.readOwners(hash)
+	if err != nil {
+		return false, err
+	}
+	owner := sha256.Sum256(ownerToken)
+	if !owners[owner] {
+		return false, fmt.Errorf("%w: %s", ErrModuleOwner, hash)
+	}
+	delete(owners, owner)
+	if len(owners) > 0 {
+		return false, f.writeOwners(hash, owners)
+	}
+
+	if err := os.Remove(f.path(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("failed to delete module file: %w", err)
+	}
+	if err := os.Remove(f.ownersPath(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("failed to delete module owners: %w", err)
+	}
+
+	return true, nil
+}
+
+// readOwners returns the owner hashes of a module, none when it has no owners file
+func (f *FileModuleStore) readOwners(hash string) (map[[32]byte]bool, error) {
+	owners := make(map[[32]byte]bool)
+
+	data, err := os.ReadFile(f.ownersPath(hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return owners, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module owners: %w", err)
+	}
+
+	for _, line := range strings.Fields(string(data)) {
+		owner, err := hex.DecodeString(line)
+		if err != nil || len(owner) != sha256.Size {
+			return nil, fmt.Errorf("module owners of %s are corrupted", hash)
+		}
+		owners[[32]byte(owner)] = true
+	}
+
+	return owners, nil
+}
+
+// writeOwners replaces the owners file of a module
+func (f *FileModuleStore) writeOwners(hash string, owners map[[32]byte]bool) error {
+	lines := make([]string, 0, len(owners))
+	for owner := range owners {
+		lines = append(lines, hex.EncodeToString(owner[:])+"\n")
+	}
+	sort.Strings(lines)
+
+	if err := writeFileAtomic(f.dir, f.ownersPath(hash), []byte(strings.Join(lines, ""))); err != nil {
+		return fmt.Errorf("failed to store module owners: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data into a temporary file of dir and renames it to path
+func writeFileAtomic(dir, path string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, "upload-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// fileModuleInfo describes a stored module file
+func fileModuleInfo(hash string, stat os.FileInfo) *types.WasmModule {
+	return &types.WasmModule{
+		Hash:      hash,
+		Size:      uint64(stat.Size()),
+		CreatedAt: stat.ModTime().Unix(),
+	}
+}
@@ -0,0 +1,147 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestModuleStores - Exercises the in-memory and filesystem module stores through the same scenario
+func TestModuleStores(t *testing.T) {
+	fileStore, err := NewFileModuleStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file module store: %v", err)
+	}
+
+	stores := map[string]ModuleStore{
+		"memory": NewMemoryModuleStore(),
+		"file":   fileStore,
+	}
+
+	bytecode := []byte("\x00asm\x01\x00\x00\x00")
+	expectedHash := ModuleHash(bytecode)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			module, err := store.Put(bytecode, testOwnerToken)
+			if err != nil {
+				t.Fatalf("Failed to put module: %v", err)
+			}
+			if _, err := store.Put(bytecode, testOwnerToken[:8]); !errors.Is(err, ErrInvalidOwnerToken) {
+				t.Errorf("Expected ErrInvalidOwnerToken for a short token, got %v", err)
+			}
+			if module.Hash != expectedHash || module.Size != uint64(len(bytecode)) {
+				t.Fatalf("Unexpected module description: %v", module)
+			}
+
+			stored, _, err := store.Get(expectedHash)
+			if err != nil {
+				t.Fatalf("Failed to get module: %v", err)
+			}
+			if !bytes.Equal(stored, bytecode) {
+				t.Errorf("Stored bytecode does not match the upload")
+			}
+
+			modules, err := store.List()
+			if err != nil {
+				t.Fatalf("Failed to list modules: %v", err)
+			}
+			if len(modules) != 1 || modules[0].Hash != expectedHash {
+				t.Errorf("Unexpected module list: %v", modules)
+			}
+
+			if _, _, err := store.Get("../../etc/passwd"); !errors.Is(err, ErrInvalidModuleHash) {
+				t.Errorf("Expected ErrInvalidModuleHash, got %v", err)
+			}
+
+			// The same bytecode uploaded by another owner is kept until both have deleted it
+			if _, err := store.Put(bytecode, otherOwnerToken); err != nil {
+				t.Fatalf("Failed to put module again: %v", err)
+			}
+			if _, err := store.Delete(expectedHash, []byte("not-an-owner-token")); !errors.Is(err, ErrModuleOwner) {
+				t.Errorf("Expected ErrModuleOwner, got %v", err)
+			}
+			if removed, err := store.Delete(expectedHash, testOwnerToken); err != nil || removed {
+				t.Fatalf("Expected the module to be kept for its other owner, got %v %v", removed, err)
+			}
+			if _, err := store.Delete(expectedHash, testOwnerToken); !errors.Is(err, ErrModuleOwner) {
+				t.Errorf("Expected ErrModuleOwner for a withdrawn owner, got %v", err)
+			}
+			if _, _, err := store.Get(expectedHash); err != nil {
+				t.Errorf("Expected the module to be kept, got %v", err)
+			}
+
+			if removed, err := store.Delete(expectedHash, otherOwnerToken); err != nil || !removed {
+				t.Fatalf("Failed to delete module: %v %v", removed, err)
+			}
+			if _, _, err := store.Get(expectedHash); !errors.Is(err, ErrModuleNotFound) {
+				t.Errorf("Expected ErrModuleNotFound after delete, got %v", err)
+			}
+		})
+	}
+}
+
+// TestFileModuleStoreDetectsTampering - Verifies that modified module files are rejected
+func TestFileModuleStoreDetectsTampering(t *testing.T) {
+	store, err := NewFileModuleStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file module store: %v", err)
+	}
+
+	module, err := store.Put([]byte("\x00asm\x01\x00\x00\x00"), testOwnerToken)
+	if err != nil {
+		t.Fatalf("Failed to put module: %v", err)
+	}
+
+	if err := os.WriteFile(store.path(module.Hash), []byte("tampered"), 0o600); err != nil {
+		t.Fatalf("Failed to tamper with module file: %v", err)
+	}
+
+	if _, _, err := store.Get(module.Hash); err == nil {
+		t.Fatalf("Expected tampered module to be rejected")
+	}
+}
+
+// TestModuleService - Verifies that uploads return an owner token and only their owner can delete them
+func TestModuleService(t *testing.T) {
+	s, err := NewServer(Config{Attester: newTestAttester(t)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	bytecode := base64.StdEncoding.EncodeToString([]byte("\x00asm\x01\x00\x00\x00"))
+
+	uploaded, err := s.UploadModule(ctx, &types.UploadModuleRequest{Bytecode: bytecode})
+	if err != nil {
+		t.Fatalf("UploadModule failed: %v", err)
+	}
+	if len(uploaded.OwnerToken) < minOwnerTokenBytes {
+		t.Fatalf("Expected a generated owner token, got %x", uploaded.OwnerToken)
+	}
+	if _, err := s.UploadModule(ctx, &types.UploadModuleRequest{Bytecode: bytecode, OwnerToken: []byte("short")}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %v, got %v", codes.InvalidArgument, err)
+	}
+
+	hash := uploaded.Module.Hash
+	if _, err := s.DeleteModule(ctx, &types.DeleteModuleRequest{Hash: hash}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected %v without owner token, got %v", codes.PermissionDenied, err)
+	}
+	if _, err := s.DeleteModule(ctx, &types.DeleteModuleRequest{Hash: hash, OwnerToken: testOwnerToken}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected %v, got %v", codes.PermissionDenied, err)
+	}
+	deleted, err := s.DeleteModule(ctx, &types.DeleteModuleRequest{Hash: hash, OwnerToken: uploaded.OwnerToken})
+	if err != nil || !deleted.Removed {
+		t.Fatalf("DeleteModule failed: %v %v", deleted, err)
+	}
+	if _, err := s.GetModule(ctx, &types.GetModuleRequest{Hash: hash}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected %v, got %v", codes.NotFound, err)
+	}
+}
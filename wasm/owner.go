@@ -0,0 +1,38 @@
+package wasm
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidOwnerToken is returned when a secret, module or job is claimed without an owner token of minOwnerTokenBytes
+var ErrInvalidOwnerToken = errors.New("invalid owner token")
+
+// minOwnerTokenBytes is the minimum length of the owner token binding a resource to its creator
+const minOwnerTokenBytes = 16
+
+// ownerHash returns the SHA-256 stored in place of an owner token, which must be at least minOwnerTokenBytes long
+func ownerHash(ownerToken []byte) ([32]byte, error) {
+	if len(ownerToken) < minOwnerTokenBytes {
+		return [32]byte{}, fmt.Errorf("%w: at least %d bytes are required", ErrInvalidOwnerToken, minOwnerTokenBytes)
+	}
+	return sha256.Sum256(ownerToken), nil
+}
+
+// ownedBy reports whether ownerToken hashes to hash, in constant time
+func ownedBy(hash []byte, ownerToken []byte) bool {
+	tokenHash := sha256.Sum256(ownerToken)
+	return len(hash) == len(tokenHash) && subtle.ConstantTimeCompare(hash, tokenHash[:]) == 1
+}
+
+// newOwnerToken returns a random owner token for clients that did not choose one
+func newOwnerToken() ([]byte, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate owner token: %v", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,226 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// DefaultExecutionQueueSize is the number of executions waiting for a worker when Config.ExecutionQueueSize is zero
+const DefaultExecutionQueueSize = 256
+
+// ErrQueueFull is returned when an execution arrives while every worker is busy and the queue is full
+var ErrQueueFull = errors.New("execution queue is full")
+
+// PoolStats describes the load of the execution worker pool, as reported by the health endpoint
+type PoolStats struct {
+	Workers       int     `json:"workers"`         // Executions running at once at most
+	Running       int     `json:"running"`         // Executions running
+	QueueSize     int     `json:"queue_size"`      // Executions waiting at most
+	Queued        int     `json:"queued"`          // Executions waiting for a worker
+	QueuedClients int     `json:"queued_clients"`  // Clients with waiting executions
+	Admitted      uint64  `json:"admitted"`        // Executions given a worker since startup
+	Rejected      uint64  `json:"rejected"`        // Executions rejected with a full queue since startup
+	AverageWaitMs float64 `json:"average_wait_ms"` // Mean queue wait of admitted executions
+	OldestWaitMs  int64   `json:"oldest_wait_ms"`  // Wait of the longest waiting execution
+}
+
+// executionPool bounds the number of WasmEdge VMs running at once
+// Executions beyond the worker count wait in a bounded queue. Waiting executions are grouped by client
+// and a freed worker goes to the next client in round-robin order, so one client's burst cannot starve
+// the others.
+type executionPool struct {
+	workers   int
+	queueSize int
+
+	mu      sync.Mutex
+	running int
+	queued  int
+	clients []string                 // Clients with waiting executions, in round-robin order
+	next    int                      // Index into clients of the next client to serve
+	waiting map[string][]*poolWaiter // Waiting executions of each client, oldest first
+
+	admitted  uint64
+	rejected  uint64
+	totalWait time.Duration
+}
+
+// poolWaiter is an execution waiting for a worker
+type poolWaiter struct {
+	client   string
+	enqueued time.Time
+	ready    chan struct{}
+	granted  bool
+}
+
+// newExecutionPool creates a pool of workers with a queue of queueSize
+// Zero settings use runtime.NumCPU workers and DefaultExecutionQueueSize.
+func newExecutionPool(workers, queueSize int) *executionPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultExecutionQueueSize
+	}
+
+	return &executionPool{workers: workers, queueSize: queueSize, waiting: make(map[string][]*poolWaiter)}
+}
+
+// Acquire waits for a worker and returns the function releasing it
+// With a full queue the execution is rejected with ErrQueueFull, unless wait is set: executions
+// already admitted by the job queue wait for a worker regardless.
+func (p *executionPool) Acquire(ctx context.Context, client string, wait bool) (func(), error) {
+	p.mu.Lock()
+	if p.running < p.workers && p.queued == 0 {
+		p.running++
+		p.admitted++
+		p.mu.Unlock()
+		return p.release, nil
+	}
+	if !wait && p.queued >= p.queueSize {
+		p.rejected++
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w: %d executions waiting for %d workers", ErrQueueFull, p.queueSize, p.workers)
+	}
+
+	w := &poolWaiter{client: client, enqueued: time.Now(), ready: make(chan struct{})}
+	if len(p.waiting[client]) == 0 {
+		p.clients = append(p.clients, client)
+	}
+	p.waiting[client] = append(p.waiting[client], w)
+	p.queued++
+	p.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return p.release, nil
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if w.granted {
+		// The worker was handed over as the context ended, pass it on
+		p.releaseLocked()
+	} else {
+		p.remove(w)
+	}
+
+	return nil, ctx.Err()
+}
+
+// release frees a worker, handing it to the next waiting client
+func (p *executionPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.releaseLocked()
+}
+
+// releaseLocked frees a worker, p.mu must be held
+func (p *executionPool) releaseLocked() {
+	if p.queued == 0 {
+		p.running--
+		return
+	}
+
+	if p.next >= len(p.clients) {
+		p.next = 0
+	}
+	w := p.waiting[p.clients[p.next]][0]
+	p.remove(w)
+	// remove moved the following client to index next unless w's client still waits
+	if len(p.waiting[w.client]) > 0 {
+		p.next++
+	}
+
+	w.granted = true
+	p.admitted++
+	p.totalWait += time.Since(w.enqueued)
+	close(w.ready)
+}
+
+// remove takes a waiter out of the queue, p.mu must be held
+func (p *executionPool) remove(w *poolWaiter) {
+	queue := p.waiting[w.client]
+	for i, queued := range queue {
+		if queued == w {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	p.queued--
+
+	if len(queue) > 0 {
+		p.waiting[w.client] = queue
+		return
+	}
+	delete(p.waiting, w.client)
+	for i, client := range p.clients {
+		if client == w.client {
+			p.clients = append(p.clients[:i], p.clients[i+1:]...)
+			if i < p.next {
+				p.next--
+			}
+			break
+		}
+	}
+}
+
+// Stats returns the current load of the pool
+func (p *executionPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := PoolStats{
+		Workers:       p.workers,
+		Running:       p.running,
+		QueueSize:     p.queueSize,
+		Queued:        p.queued,
+		QueuedClients: len(p.clients),
+		Admitted:      p.admitted,
+		Rejected:      p.rejected,
+	}
+	if p.admitted > 0 {
+		stats.AverageWaitMs = float64(p.totalWait.Microseconds()) / 1000 / float64(p.admitted)
+	}
+	for _, queue := range p.waiting {
+		if wait := time.Since(queue[0].enqueued).Milliseconds(); wait > stats.OldestWaitMs {
+			stats.OldestWaitMs = wait
+		}
+	}
+
+	return stats
+}
+
+// clientID identifies the client of a request for fair queuing
+// The x-client-id header is taken as is: fair queuing balances cooperating clients and is not an access control.
+// Without it requests are grouped by address, through the gateway the address it saw, which it appends last
+// to x-forwarded-for.
+func clientID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get("x-client-id"); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+			return strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+
+	return ""
+}
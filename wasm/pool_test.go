@@ -0,0 +1,120 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// poolGrant is a worker handed to a queued execution
+type poolGrant struct {
+	name    string
+	release func()
+}
+
+// enqueue starts an execution waiting on the pool and waits until it is queued
+func enqueue(t *testing.T, p *executionPool, ctx context.Context, name, client string, wait bool, grants chan<- poolGrant, errs chan<- error) {
+	t.Helper()
+
+	queued := p.Stats().Queued
+	go func() {
+		release, err := p.Acquire(ctx, client, wait)
+		if err != nil {
+			errs <- err
+			return
+		}
+		grants <- poolGrant{name: name, release: release}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for p.Stats().Queued == queued {
+		if time.Now().After(deadline) {
+			t.Fatalf("Execution %s was not queued", name)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestExecutionPool - Verifies the worker bound, queue rejection, round-robin fairness and cancellation
+func TestExecutionPool(t *testing.T) {
+	p := newExecutionPool(1, 3)
+	grants := make(chan poolGrant, 8)
+	errs := make(chan error, 8)
+
+	release, err := p.Acquire(context.Background(), "a", false)
+	if err != nil {
+		t.Fatalf("Failed to acquire a free worker: %v", err)
+	}
+
+	// Client a queues a burst before client b
+	enqueue(t, p, context.Background(), "a1", "a", false, grants, errs)
+	enqueue(t, p, context.Background(), "a2", "a", false, grants, errs)
+	enqueue(t, p, context.Background(), "b1", "b", false, grants, errs)
+
+	// The queue is full
+	if _, err := p.Acquire(context.Background(), "c", false); !errors.Is(err, ErrQueueFull) || status.Code(executionStatusError(err)) != codes.ResourceExhausted {
+		t.Errorf("Expected %v, got %v", ErrQueueFull, err)
+	}
+	// Executions admitted by the job queue wait regardless
+	enqueue(t, p, context.Background(), "c1", "c", true, grants, errs)
+
+	// A waiter whose context ends leaves the queue
+	ctx, cancel := context.WithCancel(context.Background())
+	enqueue(t, p, ctx, "d1", "d", true, grants, errs)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+
+	stats := p.Stats()
+	if stats.Workers != 1 || stats.Running != 1 || stats.Queued != 4 || stats.QueuedClients != 3 || stats.Admitted != 1 || stats.Rejected != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	// Freed workers go to the clients in turn
+	for _, expected := range []string{"a1", "b1", "c1", "a2"} {
+		release()
+		grant := <-grants
+		if grant.name != expected {
+			t.Fatalf("Expected %s to get the worker, got %s", expected, grant.name)
+		}
+		release = grant.release
+	}
+	release()
+
+	stats = p.Stats()
+	if stats.Running != 0 || stats.Queued != 0 || stats.QueuedClients != 0 || stats.Admitted != 5 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestClientID - Verifies how requests are attributed to clients
+func TestClientID(t *testing.T) {
+	remote := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{"none", context.Background(), ""},
+		{"peer", remote, "10.0.0.1"},
+		{"forwarded", metadata.NewIncomingContext(remote, metadata.Pairs("x-forwarded-for", "1.2.3.4, 192.168.1.7")), "192.168.1.7"},
+		{"header", metadata.NewIncomingContext(remote, metadata.Pairs("x-client-id", "tenant", "x-forwarded-for", "192.168.1.7")), "tenant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if id := clientID(tt.ctx); id != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, id)
+			}
+		})
+	}
+}
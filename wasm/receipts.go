@@ -0,0 +1,82 @@
+package wasm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/evm"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// ReceiptSigningAlgorithm names the signatures of execution receipts, see docs/canonical-encoding.md
+const ReceiptSigningAlgorithm = "ECDSA-secp256k1-SHA256"
+
+// ReceiptSigner holds the ephemeral TEE key execution receipts are signed with
+// The key never leaves the TEE and is lost on restart, its public key is attested once at creation
+// so clients can verify a single attestation and then check every receipt by its signature.
+type ReceiptSigner struct {
+	key      *secp256k1.PrivateKey
+	attested *types.GetAttestedKeyResponse
+}
+
+// NewReceiptSigner generates a fresh receipt key and has attester attest it
+// A key that cannot be attested is useless to clients, so attestation failures fail the creation.
+func NewReceiptSigner(attester Attester) (*ReceiptSigner, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate receipt key: %v", err)
+	}
+	r := &ReceiptSigner{key: key}
+
+	publicKey := r.PublicKey()
+	reportData := reportdata.KeyReportData(reportdata.KeyPurposeReceipts, publicKey, nil)
+	attestation, err := attester.Attest(reportData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attest receipt key: %v", err)
+	}
+
+	r.attested = &types.GetAttestedKeyResponse{
+		PublicKey:           publicKey,
+		Algorithm:           ReceiptSigningAlgorithm,
+		Attestation:         string(attestation),
+		AttestationProvider: attester.Provider(),
+		ReportData:          hex.EncodeToString(reportData[:]),
+		CreatedAt:           time.Now().Unix(),
+		Address:             r.Address(),
+	}
+	return r, nil
+}
+
+// PublicKey returns the compressed secp256k1 public key of the receipt key
+func (r *ReceiptSigner) PublicKey() []byte {
+	return r.key.PubKey().SerializeCompressed()
+}
+
+// Sign signs the receipt digest of reportData, returning the 64 byte r || s signature
+func (r *ReceiptSigner) Sign(reportData [64]byte) []byte {
+	digest := reportdata.ReceiptDigest(reportData)
+
+	// SignCompact prefixes r || s with the public key recovery code
+	return ecdsa.SignCompact(r.key, digest[:], true)[1:]
+}
+
+// SignEVM signs a keccak256 digest for ecrecover, returning r || s || v with v 27 or 28
+func (r *ReceiptSigner) SignEVM(digest [32]byte) []byte {
+	return evm.Sign(r.key, digest)
+}
+
+// Address returns the Ethereum address of the receipt key
+func (r *ReceiptSigner) Address() string {
+	address, _ := evm.Address(r.PublicKey())
+	return address
+}
+
+// Attested returns the public key with the attestation obtained at creation
+func (r *ReceiptSigner) Attested() *types.GetAttestedKeyResponse {
+	return r.attested
+}
@@ -0,0 +1,135 @@
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// ErrReplayMismatch is returned when a replayed guest makes a request the bundle does not hold next
+var ErrReplayMismatch = errors.New("unexpected request during replay")
+
+// Host functions whose calls are captured in a replay bundle
+const (
+	ReplayCallFetch = "fetch" // fetch, and http called with a plain URL
+	ReplayCallHttp  = "http"  // http called with a JSON request
+)
+
+// ReplayEntry is a single host call captured by the recorder
+type ReplayEntry struct {
+	Call     string              `json:"call"`               // Host function, one of the ReplayCall constants
+	Request  string              `json:"request"`            // Request exactly as passed by the guest, a URL or JSON
+	Response []byte              `json:"response,omitempty"` // Data handed to the guest, nil when the call failed
+	Exchange *types.HttpExchange `json:"exchange,omitempty"` // Transcript entry, nil when no request was sent
+}
+
+// ReplayBundle holds the fetch and http calls of an execution in the order they were made
+// Replaying a bundle re-executes a module deterministically and without network access.
+// Replayed responses come from the bundle rather than the network, so replayed executions
+// must never be attested. The server API neither records nor replays, bundles are only used through this package.
+type ReplayBundle struct {
+	Entries []ReplayEntry `json:"entries"`
+}
+
+// LoadReplayBundle reads a replay bundle from a JSON file
+func LoadReplayBundle(path string) (*ReplayBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay bundle: %w", err)
+	}
+
+	bundle := &ReplayBundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse replay bundle: %v", err)
+	}
+
+	return bundle, nil
+}
+
+// Save writes the bundle to a JSON file
+func (b *ReplayBundle) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode replay bundle: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write replay bundle: %v", err)
+	}
+
+	return nil
+}
+
+// roundTrip performs a host call through live, or serves it from the replay bundle in replay mode
+// The call is captured when recording and reported to ExecuteOptions.Events. In replay mode a request that differs from the next
+// entry, byte for byte, fails the call and is reported by ExecuteWasmWithOptions as ErrReplayMismatch.
+func (h *host) roundTrip(call string, request string, live func() ([]byte, *types.HttpExchange)) ([]byte, *types.HttpExchange, error) {
+	h.mu.Lock()
+	index := h.calls
+	h.calls++
+	h.mu.Unlock()
+	if h.events != nil {
+		h.checkpoint()
+		h.emit(httpCallStarted(index, call, request))
+	}
+	started := time.Now()
+
+	var response []byte
+	var exchange *types.HttpExchange
+	if h.replay != nil {
+		entry, err := h.nextReplayEntry(call, request)
+		if err != nil {
+			return nil, nil, err
+		}
+		response, exchange = entry.Response, entry.Exchange
+	} else {
+		response, exchange = live()
+	}
+
+	h.emit(&types.ExecutionEvent{Event: &types.ExecutionEvent_HttpCallFinished{HttpCallFinished: &types.HttpCallFinished{
+		Index:      index,
+		Call:       call,
+		Exchange:   exchange,
+		DurationMs: uint64(time.Since(started).Milliseconds()),
+	}}})
+
+	if h.recording != nil {
+		h.mu.Lock()
+		h.recording.Entries = append(h.recording.Entries, ReplayEntry{
+			Call:     call,
+			Request:  request,
+			Response: response,
+			Exchange: exchange,
+		})
+		h.mu.Unlock()
+	}
+
+	return response, exchange, nil
+}
+
+// nextReplayEntry returns the next bundle entry if it holds the given request
+func (h *host) nextReplayEntry(call string, request string) (ReplayEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.replayErr != nil {
+		return ReplayEntry{}, h.replayErr
+	}
+
+	if h.replayPos >= len(h.replay.Entries) {
+		h.replayErr = fmt.Errorf("%w: %s %q after the %d recorded calls", ErrReplayMismatch, call, request, len(h.replay.Entries))
+		return ReplayEntry{}, h.replayErr
+	}
+
+	entry := h.replay.Entries[h.replayPos]
+	if entry.Call != call || entry.Request != request {
+		h.replayErr = fmt.Errorf("%w: call %d is %s %q, recorded %s %q", ErrReplayMismatch, h.replayPos, call, request, entry.Call, entry.Request)
+		return ReplayEntry{}, h.replayErr
+	}
+	h.replayPos++
+
+	return entry, nil
+}
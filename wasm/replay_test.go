@@ -0,0 +1,94 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestReplayRoundTrip - Verifies that recorded calls are served back in order without the network
+func TestReplayRoundTrip(t *testing.T) {
+	calls := 0
+	live := func() ([]byte, *types.HttpExchange) {
+		calls++
+		return []byte("live"), &types.HttpExchange{Method: "GET", Url: "https://api.example.com/price"}
+	}
+	request := `{"url":"https://api.example.com/price","headers":{"Accept":"application/json"}}`
+
+	recorder := &host{ctx: context.Background(), recording: &ReplayBundle{}}
+	if _, _, err := recorder.roundTrip(ReplayCallHttp, request, live); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+	if _, _, err := recorder.roundTrip(ReplayCallFetch, "https://example.com", live); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "replay.json")
+	if err := recorder.recording.Save(path); err != nil {
+		t.Fatalf("Failed to save bundle: %v", err)
+	}
+	bundle, err := LoadReplayBundle(path)
+	if err != nil {
+		t.Fatalf("Failed to load bundle: %v", err)
+	}
+	if len(bundle.Entries) != 2 || bundle.Entries[0].Request != request || bundle.Entries[1].Call != ReplayCallFetch {
+		t.Fatalf("Unexpected bundle %+v", bundle)
+	}
+
+	replayer := &host{ctx: context.Background(), replay: bundle}
+	response, exchange, err := replayer.roundTrip(ReplayCallHttp, request, live)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if string(response) != "live" || exchange.GetUrl() != "https://api.example.com/price" {
+		t.Errorf("Unexpected replayed response %q, exchange %v", response, exchange)
+	}
+	if _, _, err := replayer.roundTrip(ReplayCallFetch, "https://example.com", live); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected replay to stay off the network, live was called %d times", calls)
+	}
+
+	// Calls past the end of the bundle are unexpected
+	if _, _, err := replayer.roundTrip(ReplayCallFetch, "https://example.com", live); !errors.Is(err, ErrReplayMismatch) {
+		t.Errorf("Expected %v, got %v", ErrReplayMismatch, err)
+	}
+}
+
+// TestReplayMismatch - Verifies that a request differing from the next recorded one fails the replay
+func TestReplayMismatch(t *testing.T) {
+	recorded := ReplayEntry{
+		Call:     ReplayCallHttp,
+		Request:  `{"method":"POST","url":"https://api.example.com/price","body":"{\"pair\":\"ETH/USD\"}"}`,
+		Response: []byte("recorded"),
+	}
+
+	tests := []struct {
+		name    string
+		call    string
+		request string
+	}{
+		{"call", ReplayCallFetch, recorded.Request},
+		{"url", ReplayCallHttp, `{"method":"POST","url":"https://attacker.example.com/price","body":"{\"pair\":\"ETH/USD\"}"}`},
+		{"body", ReplayCallHttp, `{"method":"POST","url":"https://api.example.com/price","body":"{\"pair\":\"BTC/USD\"}"}`},
+		{"timeout", ReplayCallHttp, `{"method":"POST","url":"https://api.example.com/price","body":"{\"pair\":\"ETH/USD\"}","timeout":5}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &host{ctx: context.Background(), replay: &ReplayBundle{Entries: []ReplayEntry{recorded}}}
+			if _, _, err := h.roundTrip(tt.call, tt.request, nil); !errors.Is(err, ErrReplayMismatch) {
+				t.Fatalf("Expected %v, got %v", ErrReplayMismatch, err)
+			}
+
+			// The first mismatch is sticky so it can be reported once the guest traps
+			if _, _, err := h.roundTrip(ReplayCallHttp, recorded.Request, nil); !errors.Is(err, ErrReplayMismatch) {
+				t.Errorf("Expected replay to stay failed, got %v", err)
+			}
+		})
+	}
+}
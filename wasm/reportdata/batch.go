@@ -0,0 +1,87 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// BatchDomain separates batch report data from execution and key report data
+const BatchDomain = "wasmvm-tee/batch/v1"
+
+// BatchLeaf hashes the report data of an execution into a leaf of its batch: MerkleLeaf(report_data)
+func BatchLeaf(reportData [64]byte) [32]byte {
+	return MerkleLeaf(reportData[:])
+}
+
+// BatchReportData computes the report data attesting a batch of executions
+//
+//	report_data[0:32]  = SHA-256("wasmvm-tee/batch/v1" || uint64(size) || root)
+//	report_data[32:64] = root
+//
+// where root is the MerkleRoot of the BatchLeaf of every execution in the batch.
+func BatchReportData(root [32]byte, size uint64) [64]byte {
+	h := sha256.New()
+	h.Write([]byte(BatchDomain))
+	h.Write(binary.BigEndian.AppendUint64(nil, size))
+	h.Write(root[:])
+
+	var reportData [64]byte
+	copy(reportData[:32], h.Sum(nil))
+	copy(reportData[32:], root[:])
+	return reportData
+}
+
+// InclusionProof returns the RFC 6962 audit path of leaves[index], sibling hashes from the leaf up
+//
+//	PATH(m, D[n]) = PATH(m, D[0:k]) : MTH(D[k:n])     for m < k
+//	PATH(m, D[n]) = PATH(m - k, D[k:n]) : MTH(D[0:k]) for m >= k
+func InclusionProof(leaves [][32]byte, index int) ([][32]byte, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, fmt.Errorf("leaf index %d out of range for %d leaves", index, len(leaves))
+	}
+	if len(leaves) == 1 {
+		return nil, nil
+	}
+
+	k := merkleSplit(len(leaves))
+	if index < k {
+		proof, err := InclusionProof(leaves[:k], index)
+		return append(proof, MerkleRoot(leaves[k:])), err
+	}
+	proof, err := InclusionProof(leaves[k:], index-k)
+	return append(proof, MerkleRoot(leaves[:k])), err
+}
+
+// InclusionRoot computes the root a leaf at index of a tree of size leaves hashes to with proof
+// The proof is checked as in RFC 9162 section 2.1.3.2, it must have exactly the length the tree
+// shape requires. Compare the result to the attested root.
+func InclusionRoot(leaf [32]byte, index, size uint64, proof [][32]byte) ([32]byte, error) {
+	if index >= size {
+		return [32]byte{}, fmt.Errorf("leaf index %d out of range for %d leaves", index, size)
+	}
+
+	fn, sn := index, size-1
+	root := leaf
+	for _, sibling := range proof {
+		if sn == 0 {
+			return [32]byte{}, fmt.Errorf("inclusion proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			root = merkleNode(sibling, root)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			root = merkleNode(root, sibling)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return [32]byte{}, fmt.Errorf("inclusion proof is too short")
+	}
+
+	return root, nil
+}
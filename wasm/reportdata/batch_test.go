@@ -0,0 +1,85 @@
+package reportdata
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// testReportData is the documented report data vector
+func testReportData(t *testing.T) [64]byte {
+	t.Helper()
+
+	decoded, err := hex.DecodeString("10e3cc0fa9c34530e922876314f7770f75063c612d0a5f59eafbf8169f621fff" +
+		"93a44bbb96c751218e4c00d479e4c14358122a389acca16205b1e4d0dc5f9476")
+	if err != nil {
+		t.Fatalf("Invalid report data: %v", err)
+	}
+	return [64]byte(decoded)
+}
+
+// TestBatchVectors - Checks batch leaves, roots, proofs and report data against docs/canonical-encoding.md
+func TestBatchVectors(t *testing.T) {
+	leaf := BatchLeaf(testReportData(t))
+	assertHex(t, "leaf", leaf[:], "9431170f718777f1796c5b5f7459146b8dbdae74e6c3a7db7ee6ef395c1258e6")
+
+	single := BatchReportData(leaf, 1)
+	assertHex(t, "single", single[:], "06064f42243be54442a952920d17954c5a44355d25ae4b66fead23831bb0496f"+
+		"9431170f718777f1796c5b5f7459146b8dbdae74e6c3a7db7ee6ef395c1258e6")
+
+	leaves := [][32]byte{leaf, leaf, leaf}
+	root := MerkleRoot(leaves)
+	assertHex(t, "root", root[:], "053dbce539de42d5596a0daf3af5b511b2fe49abdd016d5fd2dfbe8f4b912154")
+	batch := BatchReportData(root, 3)
+	assertHex(t, "batch", batch[:], "23a3dd9596240e30e3b45db0745d4a9958879b533f47788c0b7cd578e7855c98"+
+		"053dbce539de42d5596a0daf3af5b511b2fe49abdd016d5fd2dfbe8f4b912154")
+
+	proof, err := InclusionProof(leaves, 2)
+	if err != nil || len(proof) != 1 {
+		t.Fatalf("Unexpected proof %x, %v", proof, err)
+	}
+	assertHex(t, "proof", proof[0][:], "6be8c807f5f6460c49c06fa72fc8ce83358c287c9537dfae683732380bc620ec")
+}
+
+// TestInclusionProof - Checks that every leaf of trees of many shapes proves into the root, and only there
+func TestInclusionProof(t *testing.T) {
+	for size := 1; size <= 17; size++ {
+		leaves := make([][32]byte, size)
+		for i := range leaves {
+			leaves[i] = MerkleLeaf(binary.BigEndian.AppendUint32(nil, uint32(i)))
+		}
+		root := MerkleRoot(leaves)
+
+		for index := range leaves {
+			proof, err := InclusionProof(leaves, index)
+			if err != nil {
+				t.Fatalf("Failed to build proof of %d/%d: %v", index, size, err)
+			}
+			actual, err := InclusionRoot(leaves[index], uint64(index), uint64(size), proof)
+			if err != nil || actual != root {
+				t.Fatalf("Proof of %d/%d does not reach the root: %v", index, size, err)
+			}
+
+			if size > 1 {
+				// Another position, a shortened proof or an altered sibling must not reach the root
+				if actual, err := InclusionRoot(leaves[index], uint64((index+1)%size), uint64(size), proof); err == nil && actual == root {
+					t.Errorf("Proof of %d/%d verified at another index", index, size)
+				}
+				if _, err := InclusionRoot(leaves[index], uint64(index), uint64(size), proof[1:]); err == nil {
+					t.Errorf("Shortened proof of %d/%d was accepted", index, size)
+				}
+				proof[0][0] ^= 1
+				if actual, _ := InclusionRoot(leaves[index], uint64(index), uint64(size), proof); actual == root {
+					t.Errorf("Altered proof of %d/%d reached the root", index, size)
+				}
+			}
+		}
+	}
+
+	if _, err := InclusionProof(nil, 0); err == nil {
+		t.Errorf("Expected an error for an empty tree")
+	}
+	if _, err := InclusionRoot([32]byte{}, 3, 3, nil); err == nil {
+		t.Errorf("Expected an error for an index out of range")
+	}
+}
@@ -0,0 +1,188 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// Type tags of the canonical encoding, equal to the WasmValue oneof field numbers
+const (
+	TagBool        byte = 1
+	TagInt8        byte = 2
+	TagUint8       byte = 3
+	TagInt16       byte = 4
+	TagUint16      byte = 5
+	TagInt32       byte = 6
+	TagUint32      byte = 7
+	TagInt64       byte = 8
+	TagUint64      byte = 9
+	TagFloat32     byte = 10
+	TagFloat64     byte = 11
+	TagString      byte = 12
+	TagBytes       byte = 13
+	TagInt8Array   byte = 20
+	TagUint16Array byte = 21
+	TagInt16Array  byte = 22
+	TagUint32Array byte = 23
+	TagInt32Array  byte = 24
+	TagUint64Array byte = 25
+	TagInt64Array  byte = 26
+)
+
+// EncodeWasmValue returns the canonical encoding of a WasmValue
+//
+// The encoding is a one byte type tag followed by the payload, all integers big-endian:
+//   - bool: one byte, 0 or 1
+//   - integers: two's complement at their logical width (1, 2, 4 or 8 bytes)
+//   - floats: IEEE 754 bits at their width (4 or 8 bytes)
+//   - string, bytes: 4 byte length followed by the raw bytes
+//   - arrays: 4 byte element count followed by each element at its logical width
+//
+// Unlike protobuf serialization the output is fully specified, see docs/canonical-encoding.md.
+func EncodeWasmValue(v *types.WasmValue) ([]byte, error) {
+	if v == nil {
+		return nil, fmt.Errorf("WasmValue is nil")
+	}
+
+	switch value := v.Value.(type) {
+	case *types.WasmValue_BoolValue:
+		b := byte(0)
+		if value.BoolValue {
+			b = 1
+		}
+		return []byte{TagBool, b}, nil
+	case *types.WasmValue_Int8Value:
+		return []byte{TagInt8, byte(int8(value.Int8Value))}, nil
+	case *types.WasmValue_Uint8Value:
+		return []byte{TagUint8, uint8(value.Uint8Value)}, nil
+	case *types.WasmValue_Int16Value:
+		return binary.BigEndian.AppendUint16([]byte{TagInt16}, uint16(int16(value.Int16Value))), nil
+	case *types.WasmValue_Uint16Value:
+		return binary.BigEndian.AppendUint16([]byte{TagUint16}, uint16(value.Uint16Value)), nil
+	case *types.WasmValue_Int32Value:
+		return binary.BigEndian.AppendUint32([]byte{TagInt32}, uint32(value.Int32Value)), nil
+	case *types.WasmValue_Uint32Value:
+		return binary.BigEndian.AppendUint32([]byte{TagUint32}, value.Uint32Value), nil
+	case *types.WasmValue_Int64Value:
+		return binary.BigEndian.AppendUint64([]byte{TagInt64}, uint64(value.Int64Value)), nil
+	case *types.WasmValue_Uint64Value:
+		return binary.BigEndian.AppendUint64([]byte{TagUint64}, value.Uint64Value), nil
+	case *types.WasmValue_Float32Value:
+		return binary.BigEndian.AppendUint32([]byte{TagFloat32}, math.Float32bits(value.Float32Value)), nil
+	case *types.WasmValue_Float64Value:
+		return binary.BigEndian.AppendUint64([]byte{TagFloat64}, math.Float64bits(value.Float64Value)), nil
+	case *types.WasmValue_StringValue:
+		return appendBytes([]byte{TagString}, []byte(value.StringValue)), nil
+	case *types.WasmValue_BytesValue:
+		return appendBytes([]byte{TagBytes}, value.BytesValue), nil
+	case *types.WasmValue_Int8Array:
+		values := value.Int8Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagInt8Array}, uint32(len(values)))
+		for _, item := range values {
+			out = append(out, byte(int8(item)))
+		}
+		return out, nil
+	case *types.WasmValue_Uint16Array:
+		values := value.Uint16Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagUint16Array}, uint32(len(values)))
+		for _, item := range values {
+			out = binary.BigEndian.AppendUint16(out, uint16(item))
+		}
+		return out, nil
+	case *types.WasmValue_Int16Array:
+		values := value.Int16Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagInt16Array}, uint32(len(values)))
+		for _, item := range values {
+			out = binary.BigEndian.AppendUint16(out, uint16(int16(item)))
+		}
+		return out, nil
+	case *types.WasmValue_Uint32Array:
+		values := value.Uint32Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagUint32Array}, uint32(len(values)))
+		for _, item := range values {
+			out = binary.BigEndian.AppendUint32(out, item)
+		}
+		return out, nil
+	case *types.WasmValue_Int32Array:
+		values := value.Int32Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagInt32Array}, uint32(len(values)))
+		for _, item := range values {
+			out = binary.BigEndian.AppendUint32(out, uint32(item))
+		}
+		return out, nil
+	case *types.WasmValue_Uint64Array:
+		values := value.Uint64Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagUint64Array}, uint32(len(values)))
+		for _, item := range values {
+			out = binary.BigEndian.AppendUint64(out, item)
+		}
+		return out, nil
+	case *types.WasmValue_Int64Array:
+		values := value.Int64Array.GetValues()
+		out := binary.BigEndian.AppendUint32([]byte{TagInt64Array}, uint32(len(values)))
+		for _, item := range values {
+			out = binary.BigEndian.AppendUint64(out, uint64(item))
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported WasmValue type: %T", value)
+	}
+}
+
+// HashWasmValues computes the standard hash of an ordered list of values
+//
+//	SHA-256( for each value i: uint32(i) || uint32(len(enc_i)) || enc_i )
+//
+// where enc_i is EncodeWasmValue of the i-th value. External systems use the same method for verification.
+func HashWasmValues(values []*types.WasmValue) ([32]byte, error) {
+	var allData []byte
+
+	for i, v := range values {
+		encoded, err := EncodeWasmValue(v)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to encode value %d: %v", i, err)
+		}
+
+		// Index for ordering and length prefix for clear separation
+		allData = binary.BigEndian.AppendUint32(allData, uint32(i))
+		allData = binary.BigEndian.AppendUint32(allData, uint32(len(encoded)))
+		allData = append(allData, encoded...)
+	}
+
+	return sha256.Sum256(allData), nil
+}
+
+// InputsHash computes the inputs component of report data
+func InputsHash(inputs []*types.WasmValue) ([32]byte, error) {
+	return HashWasmValues(inputs)
+}
+
+// OutputsHash computes the outputs component of report data
+// The gas used and the applied limits are appended as uint64/uint32 values after the outputs
+// so the execution envelope is attested as well
+func OutputsHash(outputs []*types.WasmValue, gasUsed uint64, limits *types.ExecutionLimits) ([32]byte, error) {
+	values := make([]*types.WasmValue, 0, len(outputs)+4)
+	values = append(values, outputs...)
+	values = append(values,
+		uint64Value(gasUsed),
+		uint64Value(limits.GetGasLimit()),
+		uint64Value(limits.GetTimeoutMs()),
+		&types.WasmValue{Value: &types.WasmValue_Uint32Value{Uint32Value: limits.GetMaxMemoryPages()}},
+	)
+
+	return HashWasmValues(values)
+}
+
+func uint64Value(v uint64) *types.WasmValue {
+	return &types.WasmValue{Value: &types.WasmValue_Uint64Value{Uint64Value: v}}
+}
+
+// appendBytes appends a 4 byte big-endian length followed by data
+func appendBytes(out, data []byte) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(data)))
+	return append(out, data...)
+}
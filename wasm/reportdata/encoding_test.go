@@ -0,0 +1,90 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestEncodeWasmValueVectors - Checks the canonical encoding against the vectors in docs/canonical-encoding.md
+func TestEncodeWasmValueVectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *types.WasmValue
+		expected string
+	}{
+		{"bool", &types.WasmValue{Value: &types.WasmValue_BoolValue{BoolValue: true}}, "0101"},
+		{"int8", &types.WasmValue{Value: &types.WasmValue_Int8Value{Int8Value: -2}}, "02fe"},
+		{"uint16", &types.WasmValue{Value: &types.WasmValue_Uint16Value{Uint16Value: 513}}, "050201"},
+		{"int32", &types.WasmValue{Value: &types.WasmValue_Int32Value{Int32Value: -1}}, "06ffffffff"},
+		{"uint64", &types.WasmValue{Value: &types.WasmValue_Uint64Value{Uint64Value: 1}}, "090000000000000001"},
+		{"float64", &types.WasmValue{Value: &types.WasmValue_Float64Value{Float64Value: 1.5}}, "0b3ff8000000000000"},
+		{"string", &types.WasmValue{Value: &types.WasmValue_StringValue{StringValue: "hi"}}, "0c000000026869"},
+		{"bytes", &types.WasmValue{Value: &types.WasmValue_BytesValue{BytesValue: []byte{0xde, 0xad}}}, "0d00000002dead"},
+		{"int32_array", &types.WasmValue{Value: &types.WasmValue_Int32Array{Int32Array: &types.Int32Array{Values: []int32{1, -1}}}}, "180000000200000001ffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := EncodeWasmValue(tt.value)
+			if err != nil {
+				t.Fatalf("Failed to encode value: %v", err)
+			}
+
+			if actual := hex.EncodeToString(encoded); actual != tt.expected {
+				t.Errorf("Unexpected encoding. Expected %s, got %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// TestHashVectors - Checks the standard hash and report data against the documented vectors
+func TestHashVectors(t *testing.T) {
+	inputs := []*types.WasmValue{{Value: &types.WasmValue_StringValue{StringValue: "WasmEdge"}}}
+	outputs := []*types.WasmValue{{Value: &types.WasmValue_StringValue{StringValue: "hello WasmEdge"}}}
+	limits := &types.ExecutionLimits{GasLimit: 1000000, TimeoutMs: 5000, MaxMemoryPages: 4096}
+
+	emptyHash, err := HashWasmValues(nil)
+	if err != nil {
+		t.Fatalf("Failed to hash empty list: %v", err)
+	}
+	assertHex(t, "empty", emptyHash[:], "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+
+	inputsHash, err := InputsHash(inputs)
+	if err != nil {
+		t.Fatalf("Failed to hash inputs: %v", err)
+	}
+	assertHex(t, "inputs", inputsHash[:], "94beadd620032562943448c508b496bca9da23d4145ac378b04849545c6064b9")
+
+	outputsHash, err := OutputsHash(outputs, 1234, limits)
+	if err != nil {
+		t.Fatalf("Failed to hash outputs: %v", err)
+	}
+	assertHex(t, "outputs", outputsHash[:], "00118136306df9a767680c9a0189eeebe726630be82e23df2abcd61c7639a1d7")
+
+	components := Components{
+		ModuleHash:   sha256.Sum256([]byte("\x00asm\x01\x00\x00\x00")),
+		FunctionHash: sha256.Sum256([]byte("say")),
+		InputsHash:   inputsHash,
+		OutputsHash:  outputsHash,
+		NonceHash:    sha256.Sum256([]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+		// An execution without HTTP requests or secrets commits the empty roots
+		TranscriptRoot: sha256.Sum256(nil),
+		SecretsRoot:    sha256.Sum256(nil),
+	}
+	reportData := components.ReportData()
+	assertHex(t, "report_data", reportData[:], "10e3cc0fa9c34530e922876314f7770f75063c612d0a5f59eafbf8169f621fff"+
+		"93a44bbb96c751218e4c00d479e4c14358122a389acca16205b1e4d0dc5f9476")
+
+	receiptDigest := ReceiptDigest(reportData)
+	assertHex(t, "receipt_digest", receiptDigest[:], "f87962386a39860fa2e813e98abccc14d894229bab41634f4cd4a54272118f23")
+}
+
+func assertHex(t *testing.T, name string, actual []byte, expected string) {
+	t.Helper()
+	if hex.EncodeToString(actual) != expected {
+		t.Errorf("Unexpected %s. Expected %s, got %x", name, expected, actual)
+	}
+}
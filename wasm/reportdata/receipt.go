@@ -0,0 +1,19 @@
+package reportdata
+
+import "crypto/sha256"
+
+// ReceiptDomain separates receipt signatures from any other use of the receipt key
+const ReceiptDomain = "wasmvm-tee/receipt/v1"
+
+// ReceiptDigest computes the digest the receipt key signs for an execution
+//
+//	SHA-256("wasmvm-tee/receipt/v1" || report_data)
+func ReceiptDigest(reportData [64]byte) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(ReceiptDomain))
+	h.Write(reportData[:])
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
@@ -0,0 +1,105 @@
+// Package reportdata defines the canonical encoding and the report data layout
+// committed into TEE attestations, without depending on the WasmEdge runtime.
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// Version is the version of the report data layout produced by the server
+//
+// Layout v4 (64 bytes):
+//
+//	report_data[0:32]  = SHA-256("wasmvm-tee/report-data/v4" || module || function || inputs || outputs || nonce || transcript || secrets)
+//	report_data[32:64] = module
+//
+// where every component is a 32 byte hash, see Components.
+// Placing the module hash in the clear lets verifiers check code identity without recomputing anything.
+const Version = 4
+
+// Domain separates report data commitments from any other SHA-256 usage
+const Domain = "wasmvm-tee/report-data/v4"
+
+// Components holds the individual hashes committed into report data
+type Components struct {
+	ModuleHash   [32]byte // SHA-256 of the executed bytecode
+	FunctionHash [32]byte // SHA-256 of the function name
+	InputsHash   [32]byte // Hash of the input values, see InputsHash
+	OutputsHash  [32]byte // Hash of the output values, gas used and applied limits, see OutputsHash
+	NonceHash    [32]byte // SHA-256 of the request nonce
+	// TranscriptRoot is the Merkle root of the HTTP transcript, see TranscriptRoot
+	TranscriptRoot [32]byte
+	// SecretsRoot is the Merkle root of the secrets used by the execution, see SecretsRoot
+	SecretsRoot [32]byte
+}
+
+// ReportData computes the 64 byte report data for the components
+func (c Components) ReportData() [64]byte {
+	h := sha256.New()
+	h.Write([]byte(Domain))
+	h.Write(c.ModuleHash[:])
+	h.Write(c.FunctionHash[:])
+	h.Write(c.InputsHash[:])
+	h.Write(c.OutputsHash[:])
+	h.Write(c.NonceHash[:])
+	h.Write(c.TranscriptRoot[:])
+	h.Write(c.SecretsRoot[:])
+
+	var reportData [64]byte
+	copy(reportData[:32], h.Sum(nil))
+	copy(reportData[32:], c.ModuleHash[:])
+	return reportData
+}
+
+// Proto converts the components into their hex encoded protobuf representation
+func (c Components) Proto() *types.ReportDataComponents {
+	return &types.ReportDataComponents{
+		Version:        Version,
+		ModuleHash:     hex.EncodeToString(c.ModuleHash[:]),
+		FunctionHash:   hex.EncodeToString(c.FunctionHash[:]),
+		InputsHash:     hex.EncodeToString(c.InputsHash[:]),
+		OutputsHash:    hex.EncodeToString(c.OutputsHash[:]),
+		NonceHash:      hex.EncodeToString(c.NonceHash[:]),
+		TranscriptRoot: hex.EncodeToString(c.TranscriptRoot[:]),
+		SecretsRoot:    hex.EncodeToString(c.SecretsRoot[:]),
+	}
+}
+
+// ParseComponents converts hex encoded components back into their binary form
+// Components produced under another layout version are rejected
+func ParseComponents(p *types.ReportDataComponents) (Components, error) {
+	var c Components
+	if p == nil {
+		return c, fmt.Errorf("report data components are missing")
+	}
+	if p.Version != Version {
+		return c, fmt.Errorf("unsupported report data version %d, expected %d", p.Version, Version)
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		out   *[32]byte
+	}{
+		{"module_hash", p.ModuleHash, &c.ModuleHash},
+		{"function_hash", p.FunctionHash, &c.FunctionHash},
+		{"inputs_hash", p.InputsHash, &c.InputsHash},
+		{"outputs_hash", p.OutputsHash, &c.OutputsHash},
+		{"nonce_hash", p.NonceHash, &c.NonceHash},
+		{"transcript_root", p.TranscriptRoot, &c.TranscriptRoot},
+		{"secrets_root", p.SecretsRoot, &c.SecretsRoot},
+	}
+	for _, field := range fields {
+		decoded, err := hex.DecodeString(field.value)
+		if err != nil || len(decoded) != sha256.Size {
+			return c, fmt.Errorf("invalid %s: %q", field.name, field.value)
+		}
+		copy(field.out[:], decoded)
+	}
+
+	return c, nil
+}
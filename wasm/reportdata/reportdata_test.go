@@ -0,0 +1,58 @@
+package reportdata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+// TestReportDataLayout - Verifies the v4 report data layout binds every component
+func TestReportDataLayout(t *testing.T) {
+	components := Components{
+		ModuleHash:     sha256.Sum256([]byte("module")),
+		FunctionHash:   sha256.Sum256([]byte("say")),
+		InputsHash:     sha256.Sum256([]byte("inputs")),
+		OutputsHash:    sha256.Sum256([]byte("outputs")),
+		NonceHash:      sha256.Sum256([]byte("nonce")),
+		TranscriptRoot: sha256.Sum256([]byte("transcript")),
+		SecretsRoot:    sha256.Sum256([]byte("secrets")),
+	}
+
+	reportData := components.ReportData()
+	if !bytes.Equal(reportData[32:], components.ModuleHash[:]) {
+		t.Fatalf("Expected module hash in the second half of report data")
+	}
+
+	if components.Proto().Version != Version {
+		t.Errorf("Unexpected report data version %d", components.Proto().Version)
+	}
+
+	parsed, err := ParseComponents(components.Proto())
+	if err != nil {
+		t.Fatalf("Failed to parse components: %v", err)
+	}
+	if parsed != components {
+		t.Errorf("Expected parsed components to round-trip")
+	}
+
+	tampered := components
+	tampered.OutputsHash[0] ^= 0xff
+	tamperedData := tampered.ReportData()
+	if bytes.Equal(reportData[:32], tamperedData[:32]) {
+		t.Errorf("Expected report data to change when the outputs hash changes")
+	}
+
+	tampered = components
+	tampered.TranscriptRoot[0] ^= 0xff
+	tamperedData = tampered.ReportData()
+	if bytes.Equal(reportData[:32], tamperedData[:32]) {
+		t.Errorf("Expected report data to change when the transcript root changes")
+	}
+
+	tampered = components
+	tampered.SecretsRoot[0] ^= 0xff
+	tamperedData = tampered.ReportData()
+	if bytes.Equal(reportData[:32], tamperedData[:32]) {
+		t.Errorf("Expected report data to change when the secrets root changes")
+	}
+}
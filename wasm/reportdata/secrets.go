@@ -0,0 +1,76 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// KeyDomain separates key report data from execution report data
+const KeyDomain = "wasmvm-tee/key/v1"
+
+// Purposes of the keys bound to attestations through KeyReportData
+const (
+	KeyPurposeSecrets  = "secrets"  // X25519 key secrets are sealed to
+	KeyPurposeReceipts = "receipts" // secp256k1 key execution receipts are signed with
+)
+
+// KeyReportData computes the report data binding a TEE-held public key
+//
+//	report_data[0:32]  = SHA-256("wasmvm-tee/key/v1" || bytes(purpose) || bytes(public_key) || SHA-256(nonce))
+//	report_data[32:64] = zero
+//
+// where bytes(x) is a 4 byte big-endian length followed by x.
+func KeyReportData(purpose string, publicKey []byte, nonce []byte) [64]byte {
+	nonceHash := sha256.Sum256(nonce)
+
+	h := sha256.New()
+	h.Write([]byte(KeyDomain))
+	h.Write(appendBytes(nil, []byte(purpose)))
+	h.Write(appendBytes(nil, publicKey))
+	h.Write(nonceHash[:])
+
+	var reportData [64]byte
+	copy(reportData[:32], h.Sum(nil))
+	return reportData
+}
+
+// SealedSecretHash identifies a sealed secret without revealing its value
+//
+//	SHA-256(bytes(ephemeral_public_key) || bytes(nonce) || bytes(ciphertext))
+func SealedSecretHash(s *types.SealedSecret) [32]byte {
+	out := appendBytes(nil, s.GetEphemeralPublicKey())
+	out = appendBytes(out, s.GetNonce())
+	out = appendBytes(out, s.GetCiphertext())
+	return sha256.Sum256(out)
+}
+
+// SecretsRoot computes the secrets component of report data
+// Each reference is a leaf of MerkleRoot, MerkleLeaf(bytes(name) || sealed_hash), ordered by name
+func SecretsRoot(references []*types.SecretReference) ([32]byte, error) {
+	sorted := slices.Clone(references)
+	slices.SortFunc(sorted, func(a, b *types.SecretReference) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	leaves := make([][32]byte, len(sorted))
+	for i, reference := range sorted {
+		if reference == nil {
+			return [32]byte{}, fmt.Errorf("secret reference %d is nil", i)
+		}
+		if i > 0 && sorted[i-1].GetName() == reference.Name {
+			return [32]byte{}, fmt.Errorf("duplicate secret reference %q", reference.Name)
+		}
+		sealedHash, err := hex.DecodeString(reference.SealedHash)
+		if err != nil || len(sealedHash) != sha256.Size {
+			return [32]byte{}, fmt.Errorf("invalid sealed_hash of secret %q: %q", reference.Name, reference.SealedHash)
+		}
+		leaves[i] = MerkleLeaf(append(appendBytes(nil, []byte(reference.Name)), sealedHash...))
+	}
+
+	return MerkleRoot(leaves), nil
+}
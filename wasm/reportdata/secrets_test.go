@@ -0,0 +1,65 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestSecretVectors - Checks secret references and key report data against docs/canonical-encoding.md
+func TestSecretVectors(t *testing.T) {
+	sealedHash := SealedSecretHash(&types.SealedSecret{
+		EphemeralPublicKey: bytesOf(0x01, 32),
+		Nonce:              bytesOf(0x02, 12),
+		Ciphertext:         []byte("ciphertext"),
+	})
+	assertHex(t, "sealed_hash", sealedHash[:], "69515d0b00fea65bf046361240ea7dd02aa1e5cae414a79d1eb9baff08bf9a23")
+
+	hashA := sha256.Sum256([]byte("sealed-a"))
+	hashB := sha256.Sum256([]byte("sealed-b"))
+	a := &types.SecretReference{Name: "api_key", SealedHash: hex.EncodeToString(hashA[:])}
+	b := &types.SecretReference{Name: "db_password", SealedHash: hex.EncodeToString(hashB[:])}
+
+	tests := []struct {
+		name       string
+		references []*types.SecretReference
+		expected   string
+	}{
+		{"empty", nil, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"one", []*types.SecretReference{a}, "18e48711a2d8b5673c8a71df6ca2e570663c8d83f7c62bc8f76f3e8c4dd55932"},
+		{"two", []*types.SecretReference{a, b}, "be1570d05bd2887d0cd660b87cb3b64114a8013dab9589427f969917933daf5d"},
+		// References are ordered by name, whatever order they were used in
+		{"unordered", []*types.SecretReference{b, a}, "be1570d05bd2887d0cd660b87cb3b64114a8013dab9589427f969917933daf5d"},
+	}
+	for _, tt := range tests {
+		root, err := SecretsRoot(tt.references)
+		if err != nil {
+			t.Fatalf("Failed to compute %s root: %v", tt.name, err)
+		}
+		assertHex(t, tt.name, root[:], tt.expected)
+	}
+
+	for _, invalid := range [][]*types.SecretReference{
+		{a, a},
+		{{Name: "api_key", SealedHash: "abcd"}},
+	} {
+		if _, err := SecretsRoot(invalid); err == nil {
+			t.Errorf("Expected %v to be rejected", invalid)
+		}
+	}
+
+	reportData := KeyReportData(KeyPurposeSecrets, bytesOf(0x09, 32), []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	assertHex(t, "key_report_data", reportData[:], "d80190a3fb862851d4ccaf0facc498e7719a8c722b715b1d31f615fe9f5b8f12"+
+		"0000000000000000000000000000000000000000000000000000000000000000")
+}
+
+// bytesOf returns n copies of b
+func bytesOf(b byte, n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = b
+	}
+	return out
+}
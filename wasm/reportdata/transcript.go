@@ -0,0 +1,118 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// Prefixes separating Merkle leaves from interior nodes, as in RFC 6962
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+// EncodeHttpExchange returns the canonical encoding of a transcript entry
+//
+//	bytes(method) || bytes(url) || uint32(status_code) ||
+//	uint32(header count) || for each header sorted by name: bytes(name) || bytes(value) ||
+//	body_hash (32 bytes) || bytes(tls_cert_fingerprint) || bytes(error)
+//
+// where bytes(x) is a 4 byte big-endian length followed by x. The TLS fingerprint is
+// 32 bytes, or empty for plain HTTP.
+func EncodeHttpExchange(e *types.HttpExchange) ([]byte, error) {
+	if e == nil {
+		return nil, fmt.Errorf("HttpExchange is nil")
+	}
+
+	bodyHash, err := hex.DecodeString(e.BodyHash)
+	if err != nil || len(bodyHash) != sha256.Size {
+		return nil, fmt.Errorf("invalid body_hash: %q", e.BodyHash)
+	}
+	fingerprint, err := hex.DecodeString(e.TlsCertFingerprint)
+	if err != nil || (len(fingerprint) != 0 && len(fingerprint) != sha256.Size) {
+		return nil, fmt.Errorf("invalid tls_cert_fingerprint: %q", e.TlsCertFingerprint)
+	}
+
+	out := appendBytes(nil, []byte(e.Method))
+	out = appendBytes(out, []byte(e.Url))
+	out = binary.BigEndian.AppendUint32(out, uint32(e.StatusCode))
+
+	names := make([]string, 0, len(e.Headers))
+	for name := range e.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(names)))
+	for _, name := range names {
+		out = appendBytes(out, []byte(name))
+		out = appendBytes(out, []byte(e.Headers[name]))
+	}
+
+	out = append(out, bodyHash...)
+	out = appendBytes(out, fingerprint)
+	out = appendBytes(out, []byte(e.Error))
+
+	return out, nil
+}
+
+// TranscriptRoot computes the transcript component of report data
+// Each exchange is a leaf of MerkleRoot, in the order the guest made the requests
+func TranscriptRoot(transcript []*types.HttpExchange) ([32]byte, error) {
+	leaves := make([][32]byte, len(transcript))
+	for i, exchange := range transcript {
+		encoded, err := EncodeHttpExchange(exchange)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to encode exchange %d: %v", i, err)
+		}
+		leaves[i] = MerkleLeaf(encoded)
+	}
+
+	return MerkleRoot(leaves), nil
+}
+
+// MerkleLeaf hashes data into a Merkle leaf: SHA-256(0x00 || data)
+func MerkleLeaf(data []byte) [32]byte {
+	return sha256.Sum256(append([]byte{merkleLeafPrefix}, data...))
+}
+
+// MerkleRoot computes the RFC 6962 Merkle tree hash of the leaves
+//
+//	MTH({})      = SHA-256("")
+//	MTH({d0})    = d0
+//	MTH(D[0:n])  = SHA-256(0x01 || MTH(D[0:k]) || MTH(D[k:n]))
+//
+// where k is the largest power of two smaller than n. Leaves are hashed with MerkleLeaf.
+func MerkleRoot(leaves [][32]byte) [32]byte {
+	switch len(leaves) {
+	case 0:
+		return sha256.Sum256(nil)
+	case 1:
+		return leaves[0]
+	}
+
+	k := merkleSplit(len(leaves))
+	return merkleNode(MerkleRoot(leaves[:k]), MerkleRoot(leaves[k:]))
+}
+
+// merkleNode hashes two subtrees into their parent: SHA-256(0x01 || left || right)
+func merkleNode(left, right [32]byte) [32]byte {
+	node := make([]byte, 0, 1+2*sha256.Size)
+	node = append(node, merkleNodePrefix)
+	node = append(node, left[:]...)
+	node = append(node, right[:]...)
+	return sha256.Sum256(node)
+}
+
+// merkleSplit returns k, the largest power of two smaller than n > 1
+func merkleSplit(n int) int {
+	k := 1
+	for k*2 < n {
+		k *= 2
+	}
+	return k
+}
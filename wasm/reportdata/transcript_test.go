@@ -0,0 +1,85 @@
+package reportdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// testTranscript returns the exchanges of the documented transcript vectors
+func testTranscript() []*types.HttpExchange {
+	bodyHash := sha256.Sum256([]byte(`{"price":"42"}`))
+	emptyHash := sha256.Sum256(nil)
+
+	return []*types.HttpExchange{
+		{
+			Method:             "GET",
+			Url:                "https://api.example.com/price",
+			StatusCode:         200,
+			Headers:            map[string]string{"content-type": "application/json"},
+			BodyHash:           hex.EncodeToString(bodyHash[:]),
+			TlsCertFingerprint: hex.EncodeToString(make([]byte, sha256.Size)),
+		},
+		{
+			Method:   "GET",
+			Url:      "https://down.example.com/",
+			BodyHash: hex.EncodeToString(emptyHash[:]),
+			Error:    "connection refused",
+		},
+	}
+}
+
+// TestTranscriptVectors - Checks the exchange encoding and Merkle roots against docs/canonical-encoding.md
+func TestTranscriptVectors(t *testing.T) {
+	transcript := testTranscript()
+
+	encoded, err := EncodeHttpExchange(transcript[0])
+	if err != nil {
+		t.Fatalf("Failed to encode exchange: %v", err)
+	}
+	assertHex(t, "encoding", encoded, "000000034745540000001d68747470733a2f2f6170692e6578616d706c652e636f6d2f7072696365"+
+		"000000c8000000010000000c636f6e74656e742d74797065000000106170706c69636174696f6e2f6a736f6e"+
+		"555d3053e2f7e79d8215efa0265810f8bdd445c624899e37bdacae1c336ad533"+
+		"00000020000000000000000000000000000000000000000000000000000000000000000000000000")
+
+	leaf := MerkleLeaf(encoded)
+	assertHex(t, "leaf", leaf[:], "2122cb769d6cca406036c60573682ce0294d064976432d10d1ed5d076b02b216")
+
+	tests := []struct {
+		name       string
+		transcript []*types.HttpExchange
+		expected   string
+	}{
+		{"empty", nil, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"one", transcript[:1], "2122cb769d6cca406036c60573682ce0294d064976432d10d1ed5d076b02b216"},
+		{"two", transcript, "9d3e7480dc30ed7cdc85d2040c4a9798d7791f5f59c7d840fd4a02938358e778"},
+		{"three", append(transcript, transcript[0]), "4de1177079f90963068547daa5e08c0733d51b1cae45402d3e44153d90aa6844"},
+	}
+	for _, tt := range tests {
+		root, err := TranscriptRoot(tt.transcript)
+		if err != nil {
+			t.Fatalf("Failed to compute %s root: %v", tt.name, err)
+		}
+		assertHex(t, tt.name, root[:], tt.expected)
+	}
+
+	reversed := []*types.HttpExchange{transcript[1], transcript[0]}
+	if root, _ := TranscriptRoot(reversed); hex.EncodeToString(root[:]) == tests[2].expected {
+		t.Errorf("Expected the root to depend on the order of the exchanges")
+	}
+}
+
+// TestTranscriptRootInvalid - Checks that malformed hashes are rejected
+func TestTranscriptRootInvalid(t *testing.T) {
+	for _, exchange := range []*types.HttpExchange{
+		nil,
+		{BodyHash: "zz"},
+		{BodyHash: hex.EncodeToString(make([]byte, sha256.Size)), TlsCertFingerprint: "abcd"},
+	} {
+		if _, err := TranscriptRoot([]*types.HttpExchange{exchange}); err == nil {
+			t.Errorf("Expected %v to be rejected", exchange)
+		}
+	}
+}
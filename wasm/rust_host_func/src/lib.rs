@@ -2,9 +2,27 @@ use wasmedge_bindgen::*;
 use wasmedge_bindgen_macro::*;
 
 extern "C" {
+    // fetch and http return a handle to the response kept by the host
     fn fetch(url_pointer: *const u8, url_length: i32) -> i32;
     fn http(request_json_pointer: *const u8, request_json_length: i32) -> i32;
-    fn write_mem(pointer: *const u8);
+    fn result_len(handle: i32) -> i32;
+    fn read_result(handle: i32, pointer: *mut u8, length: i32) -> i32;
+    fn free_result(handle: i32) -> i32;
+}
+
+// Copy a host call result into guest memory and release its handle
+unsafe fn take_result(handle: i32) -> Vec<u8> {
+    let length = result_len(handle);
+    if length < 0 {
+        return Vec::new();
+    }
+
+    let mut buffer = vec![0u8; length as usize];
+    let written = read_result(handle, buffer.as_mut_ptr(), length);
+    free_result(handle);
+
+    buffer.truncate(written.max(0) as usize);
+    buffer
 }
 
 // Define return structure
@@ -23,18 +41,13 @@ pub unsafe extern "C" fn call_google() -> i32 {
     let url = "https://www.google.com";
     let pointer = url.as_bytes().as_ptr();
 
-    // call host function to fetch the source code, return the result length
-    let res_len = fetch(pointer, url.len() as i32) as usize;
-
-    // malloc memory
-    let mut buffer = Vec::with_capacity(res_len);
-    let pointer = buffer.as_mut_ptr();
+    // call host function to fetch the source code, return the result handle
+    let handle = fetch(pointer, url.len() as i32);
 
-    // call host function to write source code to the memory
-    write_mem(pointer);
+    // copy the source code into guest memory
+    let buffer = take_result(handle);
 
     // find occurrences from source code
-    buffer.set_len(res_len);
     let str = std::str::from_utf8(&buffer).unwrap();
     str.matches("google").count() as i32
 }
@@ -62,17 +75,10 @@ pub unsafe extern "C" fn test_http_get() -> String {
     let request_len = http_request.len() as i32;
 
     // Call host's http function
-    let response_len = http(request_ptr, request_len) as usize;
-
-    // Allocate memory to receive response
-    let mut response_buffer = Vec::with_capacity(response_len);
-    let buffer_ptr = response_buffer.as_mut_ptr();
-
-    // Call host function to write response to memory
-    write_mem(buffer_ptr);
+    let handle = http(request_ptr, request_len);
 
-    // Set buffer length and convert to string
-    response_buffer.set_len(response_len);
+    // Copy response into guest memory and convert to string
+    let response_buffer = take_result(handle);
     match std::str::from_utf8(&response_buffer) {
         Ok(response_str) => response_str.to_string(),
         Err(_) => "Failed to parse response".to_string(),
@@ -98,17 +104,10 @@ pub unsafe extern "C" fn test_http_post() -> String {
     let request_len = http_request.len() as i32;
 
     // Call host's http function
-    let response_len = http(request_ptr, request_len) as usize;
+    let handle = http(request_ptr, request_len);
 
-    // Allocate memory to receive response
-    let mut response_buffer = Vec::with_capacity(response_len);
-    let buffer_ptr = response_buffer.as_mut_ptr();
-
-    // Call host function to write response to memory
-    write_mem(buffer_ptr);
-
-    // Set buffer length and convert to string
-    response_buffer.set_len(response_len);
+    // Copy response into guest memory and convert to string
+    let response_buffer = take_result(handle);
     match std::str::from_utf8(&response_buffer) {
         Ok(response_str) => response_str.to_string(),
         Err(_) => "Failed to parse response".to_string(),
@@ -135,17 +134,10 @@ pub unsafe extern "C" fn test_http_with_headers() -> String {
     let request_len = http_request.len() as i32;
 
     // Call host's http function
-    let response_len = http(request_ptr, request_len) as usize;
-
-    // Allocate memory to receive response
-    let mut response_buffer = Vec::with_capacity(response_len);
-    let buffer_ptr = response_buffer.as_mut_ptr();
-
-    // Call host function to write response to memory
-    write_mem(buffer_ptr);
+    let handle = http(request_ptr, request_len);
 
-    // Set buffer length and convert to string
-    response_buffer.set_len(response_len);
+    // Copy response into guest memory and convert to string
+    let response_buffer = take_result(handle);
     match std::str::from_utf8(&response_buffer) {
         Ok(response_str) => response_str.to_string(),
         Err(_) => "Failed to parse response".to_string(),
@@ -178,3 +170,13 @@ pub unsafe extern "C" fn process_complex_types_json(
         returned_vector
     )
 }
+
+// Busy loop for cancellation tests, runs until the host stops it for large counts
+#[wasmedge_bindgen]
+pub unsafe extern "C" fn spin(iterations: u64) -> u64 {
+    let mut acc: u64 = 0;
+    for i in 0..iterations {
+        acc = std::hint::black_box(acc.wrapping_add(i));
+    }
+    acc
+}
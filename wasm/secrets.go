@@ -0,0 +1,568 @@
+package wasm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// SecretSealingAlgorithm names the scheme secrets are sealed to the vault key with, see docs/secrets.md
+const SecretSealingAlgorithm = "X25519-HKDF-SHA256-AES256GCM"
+
+// HKDF info strings of the sealing scheme and of the vault key derivation
+const (
+	secretSealingInfo = "wasmvm-tee/secret/v1"
+	secretKeyInfo     = "wasmvm-tee/secret-key/v1"
+)
+
+var (
+	// ErrSecretNotFound is returned when no secret available to the module has the requested name
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrInvalidSecretName is returned for names outside secretNamePattern
+	ErrInvalidSecretName = errors.New("invalid secret name")
+	// ErrInvalidSealedSecret is returned when a sealed secret does not open with the vault key
+	ErrInvalidSealedSecret = errors.New("invalid sealed secret")
+	// ErrSecretOwner is returned when a secret is replaced or deleted without the owner token it was stored with
+	ErrSecretOwner = errors.New("owner token does not match the secret")
+	// ErrSecretRedirect is returned when a request carrying secrets is redirected to another host or to plain http
+	ErrSecretRedirect = errors.New("secrets are not sent to redirect targets on other hosts")
+)
+
+// secretNamePattern restricts secret names to characters safe in file names and placeholders
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+// SecretSource opens the secrets available to an execution
+type SecretSource interface {
+	// OpenSecret returns the value of a secret and the reference committed into report data
+	OpenSecret(name string) ([]byte, *types.SecretReference, error)
+}
+
+// SealSecret encrypts a secret to the vault public key returned by GetSecretKey
+// The name and the allowed modules are authenticated, so the server cannot rename the secret
+// or widen the modules that may use it.
+func SealSecret(publicKey []byte, name string, allowedModules []string, value []byte) (*types.SealedSecret, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault public key: %v", err)
+	}
+	aad, _, err := secretAAD(name, allowedModules)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %v", err)
+	}
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree on a key: %v", err)
+	}
+	aead, err := secretAEAD(shared, ephemeral.PublicKey().Bytes(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	return &types.SealedSecret{
+		EphemeralPublicKey: ephemeral.PublicKey().Bytes(),
+		Nonce:              nonce,
+		Ciphertext:         aead.Seal(nil, nonce, value, aad),
+	}, nil
+}
+
+// secretAEAD derives the AES-256-GCM key of a sealed secret from the X25519 shared secret
+func secretAEAD(shared, ephemeralPublicKey, recipientPublicKey []byte) (cipher.AEAD, error) {
+	salt := append(slices.Clone(ephemeralPublicKey), recipientPublicKey...)
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(secretSealingInfo)), key); err != nil {
+		return nil, fmt.Errorf("failed to derive sealing key: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// secretAAD returns the authenticated data of a sealed secret and the normalized module hashes
+//
+//	uint32(len(name)) || name || uint32(module count) || for each sorted module hash: uint32(len(hash)) || lower case hex hash
+func secretAAD(name string, allowedModules []string) ([]byte, []string, error) {
+	if !secretNamePattern.MatchString(name) {
+		return nil, nil, fmt.Errorf("%w: %q", ErrInvalidSecretName, name)
+	}
+	if len(allowedModules) == 0 {
+		return nil, nil, fmt.Errorf("%w: allowed_modules must list at least one module hash", ErrInvalidModuleHash)
+	}
+
+	modules := make([]string, 0, len(allowedModules))
+	for _, hash := range allowedModules {
+		normalized, err := normalizeModuleHash(hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		modules = append(modules, normalized)
+	}
+	slices.Sort(modules)
+	modules = slices.Compact(modules)
+
+	aad := binary.BigEndian.AppendUint32(nil, uint32(len(name)))
+	aad = append(aad, name...)
+	aad = binary.BigEndian.AppendUint32(aad, uint32(len(modules)))
+	for _, hash := range modules {
+		aad = binary.BigEndian.AppendUint32(aad, uint32(len(hash)))
+		aad = append(aad, hash...)
+	}
+
+	return aad, modules, nil
+}
+
+// storedSecret is the at-rest form of a secret, the value stays sealed to the vault key
+type storedSecret struct {
+	Name               string   `json:"name"`
+	EphemeralPublicKey []byte   `json:"ephemeral_public_key"`
+	Nonce              []byte   `json:"nonce"`
+	Ciphertext         []byte   `json:"ciphertext"`
+	AllowedModules     []string `json:"allowed_modules"`
+	CreatedAt          int64    `json:"created_at"`
+	OwnerHash          []byte   `json:"owner_hash"` // SHA-256 of the owner token
+}
+
+// ownedBy reports whether ownerToken is the token the secret was stored with
+// Secrets stored without one can only be removed from the store directory.
+func (s *storedSecret) ownedBy(ownerToken []byte) bool {
+	return ownedBy(s.OwnerHash, ownerToken)
+}
+
+func (s *storedSecret) sealed() *types.SealedSecret {
+	return &types.SealedSecret{EphemeralPublicKey: s.EphemeralPublicKey, Nonce: s.Nonce, Ciphertext: s.Ciphertext}
+}
+
+func (s *storedSecret) reference() *types.SecretReference {
+	sealedHash := reportdata.SealedSecretHash(s.sealed())
+	return &types.SecretReference{Name: s.Name, SealedHash: hex.EncodeToString(sealedHash[:])}
+}
+
+func (s *storedSecret) info() *types.SecretInfo {
+	return &types.SecretInfo{Reference: s.reference(), AllowedModules: s.AllowedModules, CreatedAt: s.CreatedAt}
+}
+
+// SecretVault holds the TEE secret key and the sealed secrets uploaded by clients
+// Secrets stay sealed in memory and, when a directory is configured, on disk outside the TEE.
+// A secret is only opened when a module it was sealed for uses it.
+type SecretVault struct {
+	key        *ecdh.PrivateKey
+	persistent bool
+	dir        string
+
+	mu      sync.RWMutex
+	secrets map[string]*storedSecret
+}
+
+// NewSecretVault creates a vault, persisting sealed secrets in dir unless it is empty
+// With a platform sealing key the vault key is derived from it and survives restarts,
+// otherwise a fresh key is generated and secrets stored by earlier runs cannot be opened.
+func NewSecretVault(dir string, sealingKey []byte) (*SecretVault, error) {
+	v := &SecretVault{dir: dir, secrets: make(map[string]*storedSecret)}
+
+	if len(sealingKey) > 0 {
+		seed := make([]byte, 32)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, sealingKey, nil, []byte(secretKeyInfo)), seed); err != nil {
+			return nil, fmt.Errorf("failed to derive secret key: %v", err)
+		}
+		key, err := ecdh.X25519().NewPrivateKey(seed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive secret key: %v", err)
+		}
+		v.key, v.persistent = key, true
+	} else {
+		key, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate secret key: %v", err)
+		}
+		v.key = key
+	}
+
+	if dir == "" {
+		return v, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create secret store directory: %w", err)
+	}
+	if err := v.load(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// load reads the sealed secrets of the store directory, skipping those sealed to another key
+func (v *SecretVault) load() error {
+	paths, err := filepath.Glob(filepath.Join(v.dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read secret: %w", err)
+		}
+		secret := &storedSecret{}
+		if err := json.Unmarshal(data, secret); err != nil {
+			return fmt.Errorf("failed to parse secret %s: %v", filepath.Base(path), err)
+		}
+		if _, err := v.open(secret); err != nil {
+			log.Printf("Skipping secret %q: %v", secret.Name, err)
+			continue
+		}
+		v.secrets[secret.Name] = secret
+	}
+
+	return nil
+}
+
+// PublicKey returns the X25519 public key secrets are sealed to
+func (v *SecretVault) PublicKey() []byte {
+	return v.key.PublicKey().Bytes()
+}
+
+// Persistent reports whether the vault key is derived from the platform sealing key
+func (v *SecretVault) Persistent() bool {
+	return v.persistent
+}
+
+// Put stores a sealed secret after checking that it opens
+// The name is bound to ownerToken: a secret of the same name is only replaced when ownerToken is the one it was
+// stored with, so callers cannot take over the secrets of others.
+func (v *SecretVault) Put(name string, sealed *types.SealedSecret, allowedModules []string, ownerToken []byte) (*types.SecretInfo, error) {
+	_, modules, err := secretAAD(name, allowedModules)
+	if err != nil {
+		return nil, err
+	}
+	if sealed == nil {
+		return nil, fmt.Errorf("%w: sealed secret is missing", ErrInvalidSealedSecret)
+	}
+	owner, err := ownerHash(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &storedSecret{
+		Name:               name,
+		EphemeralPublicKey: sealed.EphemeralPublicKey,
+		Nonce:              sealed.Nonce,
+		Ciphertext:         sealed.Ciphertext,
+		AllowedModules:     modules,
+		CreatedAt:          time.Now().Unix(),
+		OwnerHash:          owner[:],
+	}
+	if _, err := v.open(secret); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if existing, ok := v.secrets[name]; ok && !existing.ownedBy(ownerToken) {
+		return nil, fmt.Errorf("%w: %s", ErrSecretOwner, name)
+	}
+	if v.dir != "" {
+		if err := v.write(secret); err != nil {
+			return nil, err
+		}
+	}
+	v.secrets[name] = secret
+
+	return secret.info(), nil
+}
+
+// write persists a sealed secret, renaming a temporary file so readers never observe a partial one
+func (v *SecretVault) write(secret *storedSecret) error {
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("failed to encode secret: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(v.dir, "upload-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create secret file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), v.path(secret.Name)); err != nil {
+		return fmt.Errorf("failed to store secret file: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the descriptions of all stored secrets ordered by name
+func (v *SecretVault) List() []*types.SecretInfo {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	secrets := make([]*types.SecretInfo, 0, len(v.secrets))
+	for _, secret := range v.secrets {
+		secrets = append(secrets, secret.info())
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Reference.Name < secrets[j].Reference.Name })
+
+	return secrets
+}
+
+// Delete removes a stored secret, ownerToken must be the one it was stored with
+func (v *SecretVault) Delete(name string, ownerToken []byte) error {
+	if !secretNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidSecretName, name)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	secret, ok := v.secrets[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if !secret.ownedBy(ownerToken) {
+		return fmt.Errorf("%w: %s", ErrSecretOwner, name)
+	}
+	if v.dir != "" {
+		if err := os.Remove(v.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete secret file: %w", err)
+		}
+	}
+	delete(v.secrets, name)
+
+	return nil
+}
+
+// ForModule returns the secrets available to the module with the given hash
+func (v *SecretVault) ForModule(moduleHash string) SecretSource {
+	if v == nil {
+		return nil
+	}
+	return &moduleSecrets{vault: v, moduleHash: strings.ToLower(moduleHash)}
+}
+
+// open decrypts a stored secret with the vault key
+func (v *SecretVault) open(secret *storedSecret) ([]byte, error) {
+	aad, _, err := secretAAD(secret.Name, secret.AllowedModules)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(secret.EphemeralPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSealedSecret, err)
+	}
+	shared, err := v.key.ECDH(ephemeral)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSealedSecret, err)
+	}
+	aead, err := secretAEAD(shared, secret.EphemeralPublicKey, v.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	if len(secret.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: nonce must be %d bytes", ErrInvalidSealedSecret, aead.NonceSize())
+	}
+
+	value, err := aead.Open(nil, secret.Nonce, secret.Ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not sealed to this vault for this name and these modules", ErrInvalidSealedSecret)
+	}
+
+	return value, nil
+}
+
+func (v *SecretVault) path(name string) string {
+	return filepath.Join(v.dir, name+".json")
+}
+
+// moduleSecrets is the view of a vault available to one module
+type moduleSecrets struct {
+	vault      *SecretVault
+	moduleHash string
+}
+
+func (m *moduleSecrets) OpenSecret(name string) ([]byte, *types.SecretReference, error) {
+	m.vault.mu.RLock()
+	secret, ok := m.vault.secrets[name]
+	m.vault.mu.RUnlock()
+
+	// Secrets sealed for other modules are indistinguishable from missing ones
+	if !ok || !slices.Contains(secret.AllowedModules, m.moduleHash) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+
+	value, err := m.vault.open(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, secret.reference(), nil
+}
+
+// secretErrNotFound is returned by get_secret when no secret of that name is available to the module
+const secretErrNotFound int32 = -1
+
+// openSecret opens a secret for the guest and records its reference for report data
+func (h *host) openSecret(name string) ([]byte, error) {
+	if h.secrets == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+
+	value, reference, err := h.secrets.OpenSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.secretRefs == nil {
+		h.secretRefs = make(map[string]*types.SecretReference)
+	}
+	h.secretRefs[name] = reference
+
+	return value, nil
+}
+
+// secretReferences returns the secrets used so far ordered by name
+func (h *host) secretReferences() []*types.SecretReference {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	references := make([]*types.SecretReference, 0, len(h.secretRefs))
+	for _, reference := range h.secretRefs {
+		references = append(references, reference)
+	}
+	sort.Slice(references, func(i, j int) bool { return references[i].Name < references[j].Name })
+
+	return references
+}
+
+// Host function returning a secret: get_secret(name_ptr, name_len) -> handle
+// The value is kept under the returned handle like a fetch response, secretErrNotFound is
+// returned when the module may not use a secret of that name
+func (h *host) getSecret(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
+	if h.stopped.Load() {
+		return nil, wasmedge.Result_Terminate
+	}
+
+	name, err := readGuestMemory(callframe, uint32(params[0].(int32)), uint32(params[1].(int32)))
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
+
+	value, err := h.openSecret(string(name))
+	if err != nil {
+		return []any{secretErrNotFound}, wasmedge.Result_Success
+	}
+
+	handle, err := h.storeResult(value)
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
+
+	return []any{handle}, wasmedge.Result_Success
+}
+
+// secretPlaceholder matches the {{secret:name}} placeholders of http request headers
+var secretPlaceholder = regexp.MustCompile(`\{\{secret:([^{}]*)\}\}`)
+
+// resolveSecretPlaceholders returns the headers with every {{secret:name}} replaced by the secret value
+// The guest never sees the values, they are only inserted into the outgoing request
+func resolveSecretPlaceholders(headers http.Header, open func(name string) ([]byte, error)) (http.Header, error) {
+	resolved := make(http.Header, len(headers))
+	for key, values := range headers {
+		for _, value := range values {
+			var openErr error
+			value = secretPlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+				name := secretPlaceholder.FindStringSubmatch(placeholder)[1]
+				if open == nil {
+					openErr = fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+					return ""
+				}
+				secret, err := open(name)
+				if err != nil && openErr == nil {
+					openErr = err
+				}
+				return string(secret)
+			})
+			if openErr != nil {
+				return nil, openErr
+			}
+			resolved[key] = append(resolved[key], value)
+		}
+	}
+
+	return resolved, nil
+}
+
+// hasSecretPlaceholders reports whether any header value holds a {{secret:name}} placeholder
+func hasSecretPlaceholders(headers http.Header) bool {
+	for _, values := range headers {
+		for _, value := range values {
+			if secretPlaceholder.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keepSecretsOnHost stops redirects of client to other hosts and from https to http
+// Go only drops Authorization, Cookie and WWW-Authenticate on cross-host redirects, a secret
+// placed in any other header would follow the redirect to a host the guest did not name.
+func keepSecretsOnHost(client *http.Client) *http.Client {
+	checkRedirect := client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !strings.EqualFold(req.URL.Host, via[0].URL.Host) || (via[0].URL.Scheme == "https" && req.URL.Scheme != "https") {
+			return fmt.Errorf("%w: %s", ErrSecretRedirect, req.URL.Redacted())
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		return nil
+	}
+
+	return client
+}
@@ -0,0 +1,292 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestSecretVault - Verifies sealing, module scoping, the authenticated name and modules, and persistence
+func TestSecretVault(t *testing.T) {
+	dir := t.TempDir()
+	sealingKey := bytes.Repeat([]byte{7}, 32)
+	vault, err := NewSecretVault(dir, sealingKey)
+	if err != nil {
+		t.Fatalf("Failed to create vault: %v", err)
+	}
+
+	module := ModuleHash([]byte("module"))
+	other := ModuleHash([]byte("other"))
+	owner, intruder := bytes.Repeat([]byte{1}, 16), bytes.Repeat([]byte{2}, 16)
+	sealed, err := SealSecret(vault.PublicKey(), "api_key", []string{strings.ToUpper(module)}, []byte("s3cr3t"))
+	if err != nil {
+		t.Fatalf("Failed to seal secret: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		modules []string
+		sealed  *types.SealedSecret
+		owner   []byte
+		error   error
+	}{
+		{name: "renamed", secret: "other_key", modules: []string{module}, sealed: sealed, error: ErrInvalidSealedSecret},
+		{name: "widened_modules", secret: "api_key", modules: []string{module, other}, sealed: sealed, error: ErrInvalidSealedSecret},
+		{name: "tampered", secret: "api_key", modules: []string{module}, sealed: &types.SealedSecret{
+			EphemeralPublicKey: sealed.EphemeralPublicKey,
+			Nonce:              sealed.Nonce,
+			Ciphertext:         append([]byte{sealed.Ciphertext[0] ^ 1}, sealed.Ciphertext[1:]...),
+		}, error: ErrInvalidSealedSecret},
+		{name: "no_modules", secret: "api_key", sealed: sealed, error: ErrInvalidModuleHash},
+		{name: "invalid_name", secret: "../api_key", modules: []string{module}, sealed: sealed, error: ErrInvalidSecretName},
+		{name: "short_owner_token", secret: "api_key", modules: []string{module}, sealed: sealed, owner: []byte("short"), error: ErrInvalidOwnerToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownerToken := owner
+			if tt.owner != nil {
+				ownerToken = tt.owner
+			}
+			if _, err := vault.Put(tt.secret, tt.sealed, tt.modules, ownerToken); !errors.Is(err, tt.error) {
+				t.Errorf("Expected %v, got %v", tt.error, err)
+			}
+		})
+	}
+
+	info, err := vault.Put("api_key", sealed, []string{module}, owner)
+	if err != nil {
+		t.Fatalf("Failed to store secret: %v", err)
+	}
+	// Only the owner replaces the secret
+	if _, err := vault.Put("api_key", sealed, []string{module}, intruder); !errors.Is(err, ErrSecretOwner) {
+		t.Errorf("Expected %v, got %v", ErrSecretOwner, err)
+	}
+	if _, err := vault.Put("api_key", sealed, []string{module}, owner); err != nil {
+		t.Fatalf("Failed to replace secret: %v", err)
+	}
+	sealedHash := reportdata.SealedSecretHash(sealed)
+	if info.Reference.SealedHash != hex.EncodeToString(sealedHash[:]) || info.AllowedModules[0] != module {
+		t.Errorf("Unexpected secret info %+v", info)
+	}
+
+	value, reference, err := vault.ForModule(module).OpenSecret("api_key")
+	if err != nil || string(value) != "s3cr3t" || reference.SealedHash != info.Reference.SealedHash {
+		t.Fatalf("Expected the secret to open, got %q, %v, %v", value, reference, err)
+	}
+	if _, _, err := vault.ForModule(other).OpenSecret("api_key"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected the secret to be hidden from other modules, got %v", err)
+	}
+
+	// The same sealing key reopens stored secrets, another one cannot
+	reloaded, err := NewSecretVault(dir, sealingKey)
+	if err != nil {
+		t.Fatalf("Failed to reload vault: %v", err)
+	}
+	if value, _, err := reloaded.ForModule(module).OpenSecret("api_key"); err != nil || string(value) != "s3cr3t" {
+		t.Errorf("Expected the secret to survive a restart, got %q, %v", value, err)
+	}
+	rekeyed, err := NewSecretVault(dir, nil)
+	if err != nil {
+		t.Fatalf("Failed to reload vault: %v", err)
+	}
+	if len(rekeyed.List()) != 0 {
+		t.Errorf("Expected secrets sealed to another key to be skipped, got %v", rekeyed.List())
+	}
+
+	if err := reloaded.Delete("api_key", intruder); !errors.Is(err, ErrSecretOwner) {
+		t.Errorf("Expected %v, got %v", ErrSecretOwner, err)
+	}
+	if err := reloaded.Delete("api_key", owner); err != nil {
+		t.Fatalf("Failed to delete secret: %v", err)
+	}
+	if err := reloaded.Delete("api_key", owner); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected %v, got %v", ErrSecretNotFound, err)
+	}
+}
+
+// TestHttpSecretPlaceholders - Verifies that header placeholders are filled on the host and the secrets recorded
+func TestHttpSecretPlaceholders(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	vault, err := NewSecretVault("", nil)
+	if err != nil {
+		t.Fatalf("Failed to create vault: %v", err)
+	}
+	module := ModuleHash([]byte("module"))
+	sealed, _ := SealSecret(vault.PublicKey(), "api_key", []string{module}, []byte("s3cr3t"))
+	if _, err := vault.Put("api_key", sealed, []string{module}, bytes.Repeat([]byte{1}, 16)); err != nil {
+		t.Fatalf("Failed to store secret: %v", err)
+	}
+
+	h := &host{ctx: context.Background(), secrets: vault.ForModule(module)}
+	opts := httpOptions{
+		egress:  &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}},
+		limits:  DefaultHttpLimits,
+		secrets: h.openSecret,
+	}
+	call := func(authorization string) HttpResponse {
+		request, _ := json.Marshal(HttpRequest{URL: server.URL, Headers: map[string]string{"Authorization": authorization}})
+		respJSON, _ := performHttpRequest(context.Background(), opts, string(request))
+
+		var response HttpResponse
+		if err := json.Unmarshal(respJSON, &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	if response := call("Bearer {{secret:api_key}}"); response.Body != "Bearer s3cr3t" {
+		t.Fatalf("Expected the secret in the request header, got %+v", response)
+	}
+	references := h.secretReferences()
+	if len(references) != 1 || references[0].Name != "api_key" {
+		t.Errorf("Expected the secret to be recorded, got %v", references)
+	}
+
+	// Unknown secrets fail the call before anything is sent
+	if response := call("Bearer {{secret:missing}}"); !strings.Contains(response.Error, "secret not found") {
+		t.Errorf("Expected unknown secret error, got %+v", response)
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request to reach the server, got %d", requests)
+	}
+}
+
+// TestHttpSecretRedirect - Verifies that headers carrying secrets do not follow redirects to other hosts
+func TestHttpSecretRedirect(t *testing.T) {
+	leaked := make(chan string, 4)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked <- r.Header.Get("X-Api-Key")
+		fmt.Fprint(w, "target")
+	}))
+	defer target.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/elsewhere":
+			http.Redirect(w, r, target.URL, http.StatusFound)
+		case "/same":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		default:
+			fmt.Fprint(w, r.Header.Get("X-Api-Key"))
+		}
+	}))
+	defer origin.Close()
+
+	vault, err := NewSecretVault("", nil)
+	if err != nil {
+		t.Fatalf("Failed to create vault: %v", err)
+	}
+	module := ModuleHash([]byte("module"))
+	sealed, _ := SealSecret(vault.PublicKey(), "api_key", []string{module}, []byte("s3cr3t"))
+	if _, err := vault.Put("api_key", sealed, []string{module}, bytes.Repeat([]byte{1}, 16)); err != nil {
+		t.Fatalf("Failed to store secret: %v", err)
+	}
+
+	h := &host{ctx: context.Background(), secrets: vault.ForModule(module)}
+	opts := httpOptions{
+		egress:  &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}},
+		limits:  DefaultHttpLimits,
+		secrets: h.openSecret,
+	}
+	call := func(path, apiKey string) HttpResponse {
+		request, _ := json.Marshal(HttpRequest{URL: origin.URL + path, Headers: map[string]string{"X-Api-Key": apiKey}})
+		respJSON, _ := performHttpRequest(context.Background(), opts, string(request))
+
+		var response HttpResponse
+		if err := json.Unmarshal(respJSON, &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	if response := call("/elsewhere", "{{secret:api_key}}"); !strings.Contains(response.Error, ErrSecretRedirect.Error()) {
+		t.Errorf("Expected %v, got %+v", ErrSecretRedirect, response)
+	}
+	select {
+	case value := <-leaked:
+		t.Fatalf("Expected the redirect not to be followed, the target got %q", value)
+	default:
+	}
+
+	// Redirects on the same host keep the secret, requests without secrets follow any redirect
+	if response := call("/same", "{{secret:api_key}}"); response.Body != "s3cr3t" {
+		t.Errorf("Expected the same-host redirect to be followed, got %+v", response)
+	}
+	if response := call("/elsewhere", "public"); response.Body != "target" || <-leaked != "public" {
+		t.Errorf("Expected the redirect to be followed, got %+v", response)
+	}
+}
+
+// TestSecretService - Verifies the attested secret key and the secret management RPCs
+func TestSecretService(t *testing.T) {
+	attester, err := NewMockAttester()
+	if err != nil {
+		t.Fatalf("Failed to create attester: %v", err)
+	}
+	s, err := NewServer(Config{Attester: attester})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	key, err := s.GetSecretKey(ctx, &types.GetSecretKeyRequest{Nonce: []byte("nonce")})
+	if err != nil {
+		t.Fatalf("GetSecretKey failed: %v", err)
+	}
+	reportData := reportdata.KeyReportData(reportdata.KeyPurposeSecrets, key.PublicKey, []byte("nonce"))
+	if key.ReportData != hex.EncodeToString(reportData[:]) || key.Algorithm != SecretSealingAlgorithm || key.Persistent {
+		t.Errorf("Unexpected secret key response %+v", key)
+	}
+
+	module := ModuleHash([]byte("module"))
+	sealed, _ := SealSecret(key.PublicKey, "api_key", []string{module}, []byte("s3cr3t"))
+	owner := []byte("0123456789abcdef")
+	if _, err := s.PutSecret(ctx, &types.PutSecretRequest{Name: "api_key", Sealed: sealed, AllowedModules: []string{module}, OwnerToken: owner}); err != nil {
+		t.Fatalf("PutSecret failed: %v", err)
+	}
+	if _, err := s.PutSecret(ctx, &types.PutSecretRequest{Name: "renamed", Sealed: sealed, AllowedModules: []string{module}, OwnerToken: owner}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %v, got %v", codes.InvalidArgument, err)
+	}
+	if _, err := s.PutSecret(ctx, &types.PutSecretRequest{Name: "api_key", Sealed: sealed, AllowedModules: []string{module}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %v without owner token, got %v", codes.InvalidArgument, err)
+	}
+	if _, err := s.PutSecret(ctx, &types.PutSecretRequest{Name: "api_key", Sealed: sealed, AllowedModules: []string{module}, OwnerToken: []byte("fedcba9876543210")}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected %v, got %v", codes.PermissionDenied, err)
+	}
+
+	list, err := s.ListSecrets(ctx, &types.ListSecretsRequest{})
+	if err != nil || len(list.Secrets) != 1 || list.Secrets[0].Reference.Name != "api_key" {
+		t.Fatalf("Unexpected secrets %v, %v", list, err)
+	}
+
+	if _, err := s.DeleteSecret(ctx, &types.DeleteSecretRequest{Name: "api_key"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected %v, got %v", codes.PermissionDenied, err)
+	}
+	if _, err := s.DeleteSecret(ctx, &types.DeleteSecretRequest{Name: "api_key", OwnerToken: owner}); err != nil {
+		t.Fatalf("DeleteSecret failed: %v", err)
+	}
+	if _, err := s.DeleteSecret(ctx, &types.DeleteSecretRequest{Name: "api_key", OwnerToken: owner}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected %v, got %v", codes.NotFound, err)
+	}
+}
@@ -2,17 +2,95 @@ package wasm
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/evm"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
 	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
 )
 
+// DefaultMaxTimeout is the execution timeout applied when Config.MaxTimeout is zero
+const DefaultMaxTimeout = time.Minute
+
 var _ types.WASMVMTeeServiceServer = (*Server)(nil)
 
 type Server struct {
 	types.UnimplementedWASMVMTeeServiceServer
+
+	config   Config
+	aotCache *AOTCache
+	modules  ModuleStore
+	attester Attester
+	secrets  *SecretVault
+	receipts *ReceiptSigner
+	batcher  *attestationBatcher
+	requests *idempotencyStore
+	jobs     *jobManager
+	pool     *executionPool
+}
+
+// NewServer creates a WASMVM TEE server using the given configuration
+func NewServer(config Config) (*Server, error) {
+	s := &Server{
+		config:   config,
+		modules:  NewMemoryModuleStore(),
+		attester: config.Attester,
+	}
+	if s.attester == nil {
+		s.attester = SEVSNPAttester{}
+	}
+
+	if config.ModuleStoreDir != "" {
+		modules, err := NewFileModuleStore(config.ModuleStoreDir)
+		if err != nil {
+			return nil, err
+		}
+		s.modules = modules
+	}
+
+	secrets, err := newServerSecretVault(config.SecretStoreDir, s.attester)
+	if err != nil {
+		return nil, err
+	}
+	s.secrets = secrets
+
+	receipts, err := NewReceiptSigner(s.attester)
+	if err != nil {
+		return nil, err
+	}
+	s.receipts = receipts
+
+	if config.IdempotencyTTL > 0 {
+		s.requests = newIdempotencyStore(config.IdempotencyTTL, config.IdempotencyMaxEntries)
+	}
+
+	s.pool = newExecutionPool(config.MaxConcurrentExecutions, config.ExecutionQueueSize)
+	s.jobs = newJobManager(func(ctx context.Context, execution *types.WASMVMExecution, client string) (*types.WASMVMExecutionResult, error) {
+		// Jobs were admitted by the job queue, they wait for a worker rather than being rejected
+		return s.runExecution(ctx, execution, client, true, nil)
+	}, config.JobWorkers, config.JobQueueSize, config.JobRetention, config.JobMaxRetained)
+
+	if config.BatchWindow > 0 {
+		s.batcher = newAttestationBatcher(s.attester, config.BatchWindow, config.BatchMaxSize)
+	}
+
+	if config.AOTCacheDir != "" {
+		aotCache, err := NewAOTCache(config.AOTCacheDir, config.AOTCacheMaxBytes)
+		if err != nil {
+			return nil, err
+		}
+		s.aotCache = aotCache
+	}
+
+	return s, nil
 }
 
 // Execute handles WASMVM execution requests in TEE environment
@@ -24,9 +102,9 @@ func (s *Server) Execute(ctx context.Context, req *types.WASMVMExecutionRequest)
 	}
 
 	// Execute WASMVM (pass the entire execution object)
-	result, err := s.executeWASMVM(req.Execution)
+	result, err := s.execute(ctx, req.Execution, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute WASMVM: %v", err)
+		return nil, executionStatusError(err)
 	}
 
 	// Build response
@@ -38,76 +116,312 @@ func (s *Server) Execute(ctx context.Context, req *types.WASMVMExecutionRequest)
 	return response, nil
 }
 
+// execute checks the freshness of an execution and runs it once the worker pool admits it
+// events receives the progress of the execution, nil discards it
+func (s *Server) execute(ctx context.Context, execution *types.WASMVMExecution, events func(*types.ExecutionEvent)) (*types.WASMVMExecutionResult, error) {
+	if err := s.checkFreshness(execution, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.runExecution(ctx, execution, clientID(ctx), false, events)
+}
+
+// runExecution runs an execution on a worker of the pool, unless its request_id already has a cached result
+// client is the fair queuing key, with wait the execution waits for a worker even when the queue is full.
+// A result served from the cache comes without progress events.
+func (s *Server) runExecution(ctx context.Context, execution *types.WASMVMExecution, client string, wait bool, events func(*types.ExecutionEvent)) (*types.WASMVMExecutionResult, error) {
+	run := func() (*types.WASMVMExecutionResult, error) {
+		release, err := s.pool.Acquire(ctx, client, wait)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return s.executeWASMVM(ctx, execution, events)
+	}
+
+	if s.requests == nil {
+		return run()
+	}
+	return s.requests.Do(ctx, execution, run)
+}
+
+// PoolStats returns the load of the execution worker pool
+func (s *Server) PoolStats() PoolStats {
+	return s.pool.Stats()
+}
+
 // executeWASMVM performs the actual WASMVM execution with WasmEdge
 // Decodes bytecode, converts inputs, and executes the specified function
-func (s *Server) executeWASMVM(execution *types.WASMVMExecution) (*types.WASMVMExecutionResult, error) {
-	// Decode bytecode
-	bytecode, err := base64.StdEncoding.DecodeString(execution.Bytecode)
+// The execution is bounded by ctx and by its timeout, which starts before the module is loaded and compiled
+// events receives the progress of the execution, nil discards it
+func (s *Server) executeWASMVM(ctx context.Context, execution *types.WASMVMExecution, events func(*types.ExecutionEvent)) (*types.WASMVMExecutionResult, error) {
+	if err := checkEvmOutput(execution.EvmOutput); err != nil {
+		return nil, err
+	}
+
+	// Resolve bytecode from the request or the module registry
+	bytecode, err := s.resolveBytecode(execution)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := s.executionLimits(execution)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(limits.TimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	opts := s.executeOptions(bytecode, execution, limits)
+	opts.Events = events
+	loadStarted := time.Now()
+	module, err := LoadModule(ctx, bytecode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute WASM function: %w", err)
+	}
+	defer module.Release()
+
+	if events != nil {
+		events(&types.ExecutionEvent{Event: &types.ExecutionEvent_ModuleLoaded{ModuleLoaded: &types.ModuleLoaded{
+			ModuleHash:    ModuleHash(bytecode),
+			SizeBytes:     uint64(len(bytecode)),
+			ExecutionMode: executionMode(module.AOT()),
+			LoadMs:        uint64(time.Since(loadStarted).Milliseconds()),
+		}}})
+	}
+
+	result, components, err := s.executeModule(ctx, module, execution, bytecode, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate attestation based on execution data
+	result.Attestation, result.BatchProof, err = s.attest(ctx, components.ReportData())
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode bytecode: %v", err)
+		return nil, fmt.Errorf("failed to build attestation: %w", err)
 	}
 
+	return result, nil
+}
+
+// executeOptions returns the WasmEdge settings of an execution of bytecode
+func (s *Server) executeOptions(bytecode []byte, execution *types.WASMVMExecution, limits *types.ExecutionLimits) ExecuteOptions {
+	moduleHash := ModuleHash(bytecode)
+	opts := ExecuteOptions{
+		GasLimit:         limits.GasLimit,
+		MaxMemoryPages:   limits.MaxMemoryPages,
+		ForceInterpreter: execution.IsForceInterpreter,
+		Egress:           s.config.Egress.PolicyFor(moduleHash),
+		HttpLimits:       s.config.HttpLimits,
+		TLS:              s.config.TLS.ForModule(moduleHash),
+		Secrets:          s.secrets.ForModule(moduleHash),
+	}
+	// Only registered modules are compiled, each distinct inline bytecode would otherwise leave an artifact behind
+	if execution.ModuleHash != "" {
+		opts.AOTCache = s.aotCache
+	}
+	return opts
+}
+
+// executeModule calls the function of execution on the loaded module and returns its result without attestation,
+// together with the report data components the attestation has to cover
+// The call is bounded by ctx, which carries the deadline of limits.TimeoutMs
+func (s *Server) executeModule(ctx context.Context, module *LoadedModule, execution *types.WASMVMExecution, bytecode []byte, limits *types.ExecutionLimits) (*types.WASMVMExecutionResult, reportdata.Components, error) {
 	// Convert string inputs to appropriate types for WasmEdge
 	params, err := ConvertWasmValuesToInterface(execution.Inputs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert inputs: %v", err)
+		return nil, reportdata.Components{}, fmt.Errorf("failed to convert inputs: %v", err)
 	}
 
 	// Execute WASM function using WasmEdge and get proto Value results
-	output, err := ExecuteWasm(bytecode, execution.FnName, params)
+	output, err := module.Execute(ctx, execution.FnName, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute WASM function: %v", err)
+		return nil, reportdata.Components{}, fmt.Errorf("failed to execute WASM function: %w", err)
 	}
 
-	outputValues, err := ConvertBindgenExecuteResultToWasmValues(output)
+	outputValues, err := ConvertBindgenExecuteResultToWasmValues(output.Values)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert output values: %v", err)
+		return nil, reportdata.Components{}, fmt.Errorf("failed to convert output values: %v", err)
 	}
 
-	// Generate attestation based on execution data
-	attestation, reportData, err := s.buildAttestationByExecution(execution, outputValues)
+	components, err := executionComponents(execution, bytecode, outputValues, output.GasUsed, limits, output.Transcript, output.SecretReferences)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build attestation: %v", err)
+		return nil, components, fmt.Errorf("failed to build attestation: %w", err)
+	}
+	reportData := components.ReportData()
+
+	// Sign the report data so results can be checked against the attested receipt key
+	receiptSignature := s.receipts.Sign(reportData)
+
+	var evmResult *types.EvmResult
+	if execution.EvmOutput != nil {
+		if evmResult, err = s.evmResult(execution, outputValues, components); err != nil {
+			return nil, components, fmt.Errorf("failed to encode EVM output: %w", err)
+		}
 	}
 
 	return &types.WASMVMExecutionResult{
-		Inputs:       execution.Inputs,
-		OutputValues: outputValues,
-		Attestation:  attestation,
-		ReportData:   reportData,
-	}, nil
+		Inputs:               execution.Inputs,
+		OutputValues:         outputValues,
+		ReportData:           hex.EncodeToString(reportData[:]),
+		GasUsed:              output.GasUsed,
+		Limits:               limits,
+		ExecutionMode:        executionMode(output.AOT),
+		ReportDataComponents: components.Proto(),
+		AttestationProvider:  s.attester.Provider(),
+		HttpTranscript:       output.Transcript,
+		SecretReferences:     output.SecretReferences,
+		ReceiptSignature:     hex.EncodeToString(receiptSignature),
+		ReceiptPublicKey:     hex.EncodeToString(s.receipts.PublicKey()),
+		EvmResult:            evmResult,
+	}, components, nil
 }
 
-// buildAttestationByExecution creates attestation data based on execution inputs and outputs
-// Calculates cryptographic hashes for integrity verification and generates TEE attestation
-func (s *Server) buildAttestationByExecution(execution *types.WASMVMExecution, outputValues []*types.WasmValue) (string, string, error) {
+// executionMode returns the execution mode of a module run AOT-compiled or interpreted
+func executionMode(aot bool) types.ExecutionMode {
+	if aot {
+		return types.ExecutionMode_EXECUTION_MODE_AOT
+	}
+	return types.ExecutionMode_EXECUTION_MODE_INTERPRETER
+}
+
+// resolveBytecode returns the bytecode to execute, either inline or from the module registry
+func (s *Server) resolveBytecode(execution *types.WASMVMExecution) ([]byte, error) {
+	if execution.ModuleHash == "" {
+		bytecode, err := base64.StdEncoding.DecodeString(execution.Bytecode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode bytecode: %v", err)
+		}
+		return bytecode, nil
+	}
+
+	if execution.Bytecode != "" {
+		return nil, fmt.Errorf("bytecode and module_hash are mutually exclusive")
+	}
+
+	bytecode, _, err := s.modules.Get(execution.ModuleHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load module: %w", err)
+	}
+
+	return bytecode, nil
+}
+
+// executionLimits resolves the resource limits for an execution
+// The server-wide memory cap and timeout are the defaults and can only be lowered by the request
+func (s *Server) executionLimits(execution *types.WASMVMExecution) *types.ExecutionLimits {
+	maxMemoryPages := s.config.MaxMemoryPages
+	if execution.MaxMemoryPages > 0 && (maxMemoryPages == 0 || execution.MaxMemoryPages < maxMemoryPages) {
+		maxMemoryPages = execution.MaxMemoryPages
+	}
+
+	maxTimeout := s.config.MaxTimeout
+	if maxTimeout <= 0 {
+		maxTimeout = DefaultMaxTimeout
+	}
+	timeoutMs := uint64(maxTimeout.Milliseconds())
+	if execution.TimeoutMs > 0 && execution.TimeoutMs < timeoutMs {
+		timeoutMs = execution.TimeoutMs
+	}
+
+	gasLimit := execution.GasLimit
+	if gasLimit == 0 {
+		gasLimit = s.config.DefaultGasLimit
+	}
+	if maxGasLimit := s.config.MaxGasLimit; maxGasLimit > 0 && (gasLimit == 0 || gasLimit > maxGasLimit) {
+		gasLimit = maxGasLimit
+	}
+
+	return &types.ExecutionLimits{
+		GasLimit:       gasLimit,
+		TimeoutMs:      timeoutMs,
+		MaxMemoryPages: maxMemoryPages,
+	}
+}
+
+// executionComponents calculates the report data components (see reportdata.Version) of an execution
+// The module hash is taken over the executed bytecode, whether it was sent inline or by module hash
+// The HTTP transcript is committed through its Merkle root so verifiers can prove which external data was consumed
+// The secrets used are committed by name and sealed hash, never by value
+func executionComponents(execution *types.WASMVMExecution, bytecode []byte, outputValues []*types.WasmValue, gasUsed uint64, limits *types.ExecutionLimits, transcript []*types.HttpExchange, secretRefs []*types.SecretReference) (reportdata.Components, error) {
+	components := reportdata.Components{
+		ModuleHash:   sha256.Sum256(bytecode),
+		FunctionHash: sha256.Sum256([]byte(execution.FnName)),
+		NonceHash:    sha256.Sum256(execution.Nonce),
+	}
+
 	// Calculate cryptographic hashes for integrity verification
-	inputHash, err := s.calculateStandardHash(execution)
+	inputHash, err := reportdata.InputsHash(execution.Inputs)
+	if err != nil {
+		return components, fmt.Errorf("failed to calculate input hash: %v", err)
+	}
+	components.InputsHash = inputHash
+
+	outputHash, err := reportdata.OutputsHash(outputValues, gasUsed, limits)
+	if err != nil {
+		return components, fmt.Errorf("failed to calculate output hash: %v", err)
+	}
+	components.OutputsHash = outputHash
+
+	transcriptRoot, err := reportdata.TranscriptRoot(transcript)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to calculate input hash: %v", err)
+		return components, fmt.Errorf("failed to calculate transcript root: %v", err)
 	}
+	components.TranscriptRoot = transcriptRoot
 
-	outputHash, err := s.calculateOutputHash(outputValues)
+	secretsRoot, err := reportdata.SecretsRoot(secretRefs)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to calculate output hash: %v", err)
+		return components, fmt.Errorf("failed to calculate secrets root: %v", err)
+	}
+	components.SecretsRoot = secretsRoot
+
+	return components, nil
+}
+
+// attest generates the TEE attestation of an execution's report data
+// With Config.ReceiptsOnly no attestation is requested, results then rely on their receipt signature
+// With Config.BatchWindow the attestation covers a batch of executions, returned with the inclusion proof of this one
+func (s *Server) attest(ctx context.Context, reportData [64]byte) (string, *types.BatchProof, error) {
+	if s.config.ReceiptsOnly {
+		return "", nil, nil
 	}
 
-	// Combine input and output hashes
-	combined := s.combineHashes(inputHash, outputHash)
+	if s.batcher != nil {
+		attestation, batchProof, err := s.batcher.Attest(ctx, reportData)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate batch attestation: %w", err)
+		}
+		return string(attestation), batchProof, nil
+	}
 
 	// Generate TEE attestation
-	attestation, err := generateAttestation(combined)
+	attestation, err := s.attester.Attest(reportData)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to generate attestation: %v", err)
+		return "", nil, fmt.Errorf("failed to generate attestation: %v", err)
 	}
 
-	return string(attestation), hex.EncodeToString(combined[:]), nil
+	return string(attestation), nil, nil
 }
 
-// combineHashes combines input and output hashes into a single 64-byte array
-func (s *Server) combineHashes(inputHash, outputHash [32]byte) [64]byte {
-	var combined [64]byte
-	copy(combined[:32], inputHash[:])
-	copy(combined[32:], outputHash[:])
-	return combined
+// executionStatusError maps execution failures to gRPC status errors
+// Terminated executions get a distinct code so clients can tell them apart from guest failures
+func executionStatusError(err error) error {
+	msg := fmt.Sprintf("failed to execute WASMVM: %v", err)
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, msg)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, msg)
+	case errors.Is(err, ErrGasLimitExceeded), errors.Is(err, ErrMemoryLimitExceeded), errors.Is(err, ErrQueueFull):
+		return status.Error(codes.ResourceExhausted, msg)
+	case errors.Is(err, ErrModuleNotFound):
+		return status.Error(codes.NotFound, msg)
+	case errors.Is(err, ErrInvalidModuleHash), errors.Is(err, evm.ErrInvalidABI), errors.Is(err, ErrNonceRequired), errors.Is(err, ErrInvalidBatch):
+		return status.Error(codes.InvalidArgument, msg)
+	case errors.Is(err, ErrStaleRequest):
+		return status.Error(codes.FailedPrecondition, msg)
+	case errors.Is(err, ErrRequestIDConflict):
+		return status.Error(codes.AlreadyExists, msg)
+	default:
+		return status.Error(codes.Unknown, msg)
+	}
 }
@@ -0,0 +1,165 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// DefaultMaxBatchItems is the number of items ExecuteBatch accepts when Config.MaxBatchItems is zero
+const DefaultMaxBatchItems = 1000
+
+// ErrInvalidBatch is returned when a batch execution has no items or more than Config.MaxBatchItems
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// ExecuteBatch calls one function of one module on every input set of the request
+// The module is loaded, validated and compiled once, then instantiated afresh for each item so no guest state
+// carries over between items. Every item runs under the request's limits, in order, on a single worker.
+// The timeout applies to the whole batch, loading and compiling the module included, so a batch holds its
+// worker no longer than a single execution. Items left when it expires fail with DEADLINE_EXCEEDED.
+// A failed item is reported with its error and the next items still run, unless stop_on_error is set.
+func (s *Server) ExecuteBatch(ctx context.Context, req *types.ExecuteBatchRequest) (*types.ExecuteBatchResponse, error) {
+	if req.Execution == nil {
+		return nil, status.Error(codes.InvalidArgument, "execution request is nil")
+	}
+	if err := s.checkFreshness(req.Execution, time.Now()); err != nil {
+		return nil, executionStatusError(err)
+	}
+
+	release, err := s.pool.Acquire(ctx, clientID(ctx), false)
+	if err != nil {
+		return nil, executionStatusError(err)
+	}
+	defer release()
+
+	items, err := s.executeBatch(ctx, req)
+	if err != nil {
+		return nil, executionStatusError(err)
+	}
+
+	response := &types.ExecuteBatchResponse{RequestId: req.Execution.RequestId, Items: items}
+	for _, item := range items {
+		if item.Result != nil {
+			response.Succeeded++
+		}
+	}
+
+	return response, nil
+}
+
+// executeBatch runs the items of a batch on one loaded module and attests the successful ones together
+func (s *Server) executeBatch(ctx context.Context, req *types.ExecuteBatchRequest) ([]*types.BatchItemResult, error) {
+	maxItems := s.config.MaxBatchItems
+	if maxItems <= 0 {
+		maxItems = DefaultMaxBatchItems
+	}
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: no items", ErrInvalidBatch)
+	}
+	if len(req.Items) > maxItems {
+		return nil, fmt.Errorf("%w: %d items, at most %d are allowed", ErrInvalidBatch, len(req.Items), maxItems)
+	}
+	if err := checkEvmOutput(req.Execution.EvmOutput); err != nil {
+		return nil, err
+	}
+
+	bytecode, err := s.resolveBytecode(req.Execution)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := s.executionLimits(req.Execution)
+	batchCtx, cancel := context.WithTimeout(ctx, time.Duration(limits.TimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	module, err := LoadModule(batchCtx, bytecode, s.executeOptions(bytecode, req.Execution, limits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute WASM function: %w", err)
+	}
+	defer module.Release()
+
+	// Each item is the shared execution with its own inputs, the bytecode is loaded already
+	shared := proto.Clone(req.Execution).(*types.WASMVMExecution)
+	shared.Bytecode, shared.Inputs = "", nil
+
+	items := make([]*types.BatchItemResult, len(req.Items))
+	var attested []*types.WASMVMExecutionResult
+	var leaves [][32]byte
+	failed := -1
+	for i, batchInputs := range req.Items {
+		items[i] = &types.BatchItemResult{Index: uint64(i)}
+		if failed >= 0 && req.StopOnError {
+			items[i].Error = fmt.Sprintf("not executed, item %d failed", failed)
+			items[i].ErrorCode = int32(codes.Aborted)
+			continue
+		}
+		if batchCtx.Err() != nil && ctx.Err() == nil {
+			items[i].Error = "not executed, batch deadline exceeded"
+			items[i].ErrorCode = int32(codes.DeadlineExceeded)
+			if failed < 0 {
+				failed = i
+			}
+			continue
+		}
+
+		execution := proto.Clone(shared).(*types.WASMVMExecution)
+		execution.Inputs = batchInputs.GetInputs()
+		result, components, err := s.executeModule(batchCtx, module, execution, bytecode, limits)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("execution terminated: %w", ctxErr)
+		}
+		if err != nil {
+			statusErr := status.Convert(executionStatusError(err))
+			items[i].Error = statusErr.Message()
+			items[i].ErrorCode = int32(statusErr.Code())
+			if failed < 0 {
+				failed = i
+			}
+			continue
+		}
+
+		items[i].Result = result
+		attested = append(attested, result)
+		leaves = append(leaves, reportdata.BatchLeaf(components.ReportData()))
+	}
+
+	if err := s.attestBatch(attested, leaves); err != nil {
+		return nil, fmt.Errorf("failed to build attestation: %w", err)
+	}
+
+	return items, nil
+}
+
+// attestBatch attests results with one report of the Merkle root of their leaves, the batch report data
+// Every result then carries the shared attestation and its inclusion proof, as under Config.BatchWindow.
+// With Config.ReceiptsOnly no attestation is requested.
+func (s *Server) attestBatch(results []*types.WASMVMExecutionResult, leaves [][32]byte) error {
+	if len(results) == 0 || s.config.ReceiptsOnly {
+		return nil
+	}
+
+	root := reportdata.MerkleRoot(leaves)
+	attestation, err := s.attester.Attest(reportdata.BatchReportData(root, uint64(len(leaves))))
+	if err != nil {
+		return fmt.Errorf("failed to attest batch of %d executions: %v", len(leaves), err)
+	}
+
+	for i, result := range results {
+		proof, err := batchProof(leaves, root, i)
+		if err != nil {
+			return err
+		}
+		result.Attestation = string(attestation)
+		result.BatchProof = proof
+	}
+
+	return nil
+}
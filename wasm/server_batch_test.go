@@ -0,0 +1,182 @@
+package wasm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/verify"
+)
+
+// TestExecuteBatch - Runs a batch with a failing item and checks the per-item results and shared attestation
+func TestExecuteBatch(t *testing.T) {
+	wasmBytes, err := os.ReadFile(wasmFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read WASM file %s: %v", wasmFilePath, err)
+	}
+	attester, err := NewMockAttester()
+	if err != nil {
+		t.Fatalf("Failed to create attester: %v", err)
+	}
+	s, err := NewServer(Config{Attester: attester})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	stringInput := func(value string) *types.BatchInputs {
+		return &types.BatchInputs{Inputs: []*types.WasmValue{{Value: &types.WasmValue_StringValue{StringValue: value}}}}
+	}
+	req := &types.ExecuteBatchRequest{
+		Execution: &types.WASMVMExecution{
+			RequestId: "batch-1",
+			Bytecode:  base64.StdEncoding.EncodeToString(wasmBytes),
+			FnName:    "say",
+		},
+		// say takes a string, the guest traps on the item without inputs
+		Items: []*types.BatchInputs{stringInput("alice"), {}, stringInput("bob")},
+	}
+
+	t.Run("partial_failure", func(t *testing.T) {
+		response, err := s.ExecuteBatch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ExecuteBatch failed: %v", err)
+		}
+		if response.RequestId != "batch-1" || response.Succeeded != 2 || len(response.Items) != 3 {
+			t.Fatalf("Unexpected response %v", response)
+		}
+		if failed := response.Items[1]; failed.Result != nil || failed.Error == "" || failed.ErrorCode == int32(codes.OK) {
+			t.Errorf("Expected the item without inputs to fail, got %v", failed)
+		}
+
+		for i, name := range map[int]string{0: "alice", 2: "bob"} {
+			result := response.Items[i].Result
+			if result == nil || result.OutputValues[0].GetStringValue() != "hello "+name {
+				t.Fatalf("Unexpected result of item %d: %v", i, response.Items[i])
+			}
+
+			// Every successful item is proven against the batch report data of the shared attestation
+			reportData, err := hex.DecodeString(result.ReportData)
+			if err != nil {
+				t.Fatalf("Invalid report data: %v", err)
+			}
+			batchReportData, err := verify.BatchInclusion([64]byte(reportData), result.BatchProof)
+			if err != nil {
+				t.Fatalf("Inclusion proof of item %d failed: %v", i, err)
+			}
+			root, _ := hex.DecodeString(result.BatchProof.Root)
+			if batchReportData != reportdata.BatchReportData([32]byte(root), 2) || result.Attestation != response.Items[0].Result.Attestation {
+				t.Errorf("Item %d is not covered by the batch attestation", i)
+			}
+		}
+	})
+
+	t.Run("stop_on_error", func(t *testing.T) {
+		req.StopOnError = true
+		defer func() { req.StopOnError = false }()
+
+		response, err := s.ExecuteBatch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ExecuteBatch failed: %v", err)
+		}
+		if response.Succeeded != 1 || response.Items[2].Result != nil || response.Items[2].ErrorCode != int32(codes.Aborted) {
+			t.Errorf("Expected the items after the failure to be skipped, got %v", response)
+		}
+	})
+	t.Run("deadline", func(t *testing.T) {
+		spin := &types.BatchInputs{Inputs: []*types.WasmValue{{Value: &types.WasmValue_Uint64Value{Uint64Value: math.MaxUint64}}}}
+		spinning := &types.ExecuteBatchRequest{
+			Execution: &types.WASMVMExecution{
+				Bytecode:  req.Execution.Bytecode,
+				FnName:    "spin",
+				TimeoutMs: 200,
+			},
+			Items: []*types.BatchInputs{spin, spin, spin},
+		}
+
+		// The timeout bounds the whole batch, not each item
+		started := time.Now()
+		response, err := s.ExecuteBatch(context.Background(), spinning)
+		if err != nil {
+			t.Fatalf("ExecuteBatch failed: %v", err)
+		}
+		if elapsed := time.Since(started); elapsed > 2*time.Second {
+			t.Errorf("Batch took %v, expected it to stop at its deadline", elapsed)
+		}
+		for i, item := range response.Items {
+			if item.Result != nil || item.ErrorCode != int32(codes.DeadlineExceeded) {
+				t.Errorf("Expected item %d to exceed the batch deadline, got %v", i, item)
+			}
+		}
+	})
+}
+
+// TestExecuteBatchValidation - Verifies that malformed batches are rejected before any execution
+func TestExecuteBatchValidation(t *testing.T) {
+	s, err := NewServer(Config{MaxBatchItems: 2, Attester: newTestAttester(t)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *types.ExecuteBatchRequest
+	}{
+		{"no_execution", &types.ExecuteBatchRequest{Items: []*types.BatchInputs{{}}}},
+		{"no_items", &types.ExecuteBatchRequest{Execution: &types.WASMVMExecution{FnName: "say"}}},
+		{"too_many_items", &types.ExecuteBatchRequest{Execution: &types.WASMVMExecution{FnName: "say"}, Items: []*types.BatchInputs{{}, {}, {}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.ExecuteBatch(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected %v, got %v", codes.InvalidArgument, err)
+			}
+		})
+	}
+}
+
+// TestAttestBatch - Verifies that batch results share one attestation of the Merkle root of their report data
+func TestAttestBatch(t *testing.T) {
+	attester, err := NewMockAttester()
+	if err != nil {
+		t.Fatalf("Failed to create attester: %v", err)
+	}
+	s := &Server{attester: attester}
+
+	reportData := [][64]byte{{1}, {2}, {3}}
+	results := make([]*types.WASMVMExecutionResult, len(reportData))
+	leaves := make([][32]byte, len(reportData))
+	for i := range reportData {
+		results[i] = &types.WASMVMExecutionResult{}
+		leaves[i] = reportdata.BatchLeaf(reportData[i])
+	}
+
+	if err := s.attestBatch(results, leaves); err != nil {
+		t.Fatalf("attestBatch failed: %v", err)
+	}
+	expected := reportdata.BatchReportData(reportdata.MerkleRoot(leaves), 3)
+	for i, result := range results {
+		if result.Attestation == "" || result.Attestation != results[0].Attestation {
+			t.Errorf("Expected result %d to carry the shared attestation", i)
+		}
+		if batchReportData, err := verify.BatchInclusion(reportData[i], result.BatchProof); err != nil || batchReportData != expected {
+			t.Errorf("Inclusion proof of result %d failed: %v", i, err)
+		}
+	}
+
+	// Receipt-only servers leave results unattested
+	s.config.ReceiptsOnly = true
+	unattested := []*types.WASMVMExecutionResult{{}}
+	if err := s.attestBatch(unattested, leaves[:1]); err != nil || unattested[0].Attestation != "" || unattested[0].BatchProof != nil {
+		t.Errorf("Expected no attestation with receipts only, got %v, %v", unattested[0], err)
+	}
+}
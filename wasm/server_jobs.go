@@ -0,0 +1,84 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// SubmitExecution queues an execution and returns its job without waiting for the result
+// Freshness is checked on submission, so a job waiting in the queue does not turn stale.
+// The job is bound to the request's owner token, a random one is generated and returned when it is empty.
+func (s *Server) SubmitExecution(ctx context.Context, req *types.SubmitExecutionRequest) (*types.SubmitExecutionResponse, error) {
+	if req.Execution == nil {
+		return nil, status.Error(codes.InvalidArgument, "execution request is nil")
+	}
+	if err := s.checkFreshness(req.Execution, time.Now()); err != nil {
+		return nil, executionStatusError(err)
+	}
+
+	ownerToken := req.OwnerToken
+	if len(ownerToken) == 0 {
+		token, err := newOwnerToken()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ownerToken = token
+	}
+
+	job, err := s.jobs.Submit(req.Execution, clientID(ctx), ownerToken)
+	if err != nil {
+		return nil, jobStatusError(err)
+	}
+
+	return &types.SubmitExecutionResponse{Job: job, OwnerToken: ownerToken}, nil
+}
+
+// GetExecution returns a submitted execution with its result once it has finished, given its owner token
+func (s *Server) GetExecution(ctx context.Context, req *types.GetExecutionRequest) (*types.GetExecutionResponse, error) {
+	job, err := s.jobs.Get(req.Id, req.OwnerToken)
+	if err != nil {
+		return nil, jobStatusError(err)
+	}
+
+	return &types.GetExecutionResponse{Job: job}, nil
+}
+
+// CancelExecution cancels a queued or running execution, given its owner token
+func (s *Server) CancelExecution(ctx context.Context, req *types.CancelExecutionRequest) (*types.CancelExecutionResponse, error) {
+	job, err := s.jobs.Cancel(req.Id, req.OwnerToken)
+	if err != nil {
+		return nil, jobStatusError(err)
+	}
+
+	return &types.CancelExecutionResponse{Job: job}, nil
+}
+
+// ListExecutions returns the submitted executions of an owner token that have not expired, without their results
+func (s *Server) ListExecutions(ctx context.Context, req *types.ListExecutionsRequest) (*types.ListExecutionsResponse, error) {
+	jobs, err := s.jobs.List(req.OwnerToken, req.State)
+	if err != nil {
+		return nil, jobStatusError(err)
+	}
+
+	return &types.ListExecutionsResponse{Jobs: jobs}, nil
+}
+
+// jobStatusError maps job manager failures to gRPC status errors
+func jobStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrJobQueueFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrInvalidOwnerToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+package wasm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// UploadModule stores bytecode in the module registry so it can be executed by hash
+// The upload is bound to the request's owner token, a random one is generated and returned when it is empty.
+func (s *Server) UploadModule(ctx context.Context, req *types.UploadModuleRequest) (*types.UploadModuleResponse, error) {
+	bytecode, err := base64.StdEncoding.DecodeString(req.Bytecode)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode bytecode: %v", err)
+	}
+	if len(bytecode) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bytecode is empty")
+	}
+
+	ownerToken := req.OwnerToken
+	if len(ownerToken) == 0 {
+		token, err := newOwnerToken()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ownerToken = token
+	}
+
+	module, err := s.modules.Put(bytecode, ownerToken)
+	if err != nil {
+		return nil, moduleStatusError(err)
+	}
+
+	return &types.UploadModuleResponse{Module: module, OwnerToken: ownerToken}, nil
+}
+
+// GetModule returns a registered module, optionally including its bytecode
+func (s *Server) GetModule(ctx context.Context, req *types.GetModuleRequest) (*types.GetModuleResponse, error) {
+	bytecode, module, err := s.modules.Get(req.Hash)
+	if err != nil {
+		return nil, moduleStatusError(err)
+	}
+
+	response := &types.GetModuleResponse{Module: module}
+	if req.IncludeBytecode {
+		response.Bytecode = base64.StdEncoding.EncodeToString(bytecode)
+	}
+
+	return response, nil
+}
+
+// ListModules returns all registered modules
+func (s *Server) ListModules(ctx context.Context, req *types.ListModulesRequest) (*types.ListModulesResponse, error) {
+	modules, err := s.modules.List()
+	if err != nil {
+		return nil, moduleStatusError(err)
+	}
+
+	return &types.ListModulesResponse{Modules: modules}, nil
+}
+
+// DeleteModule withdraws an upload given its owner token, the module is removed once no upload of it is left
+func (s *Server) DeleteModule(ctx context.Context, req *types.DeleteModuleRequest) (*types.DeleteModuleResponse, error) {
+	removed, err := s.modules.Delete(req.Hash, req.OwnerToken)
+	if err != nil {
+		return nil, moduleStatusError(err)
+	}
+
+	return &types.DeleteModuleResponse{Removed: removed}, nil
+}
+
+// moduleStatusError maps module store failures to gRPC status errors
+func moduleStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrModuleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidModuleHash), errors.Is(err, ErrInvalidOwnerToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrModuleOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,13 @@
+package wasm
+
+import (
+	"context"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// GetAttestedKey returns the key execution receipts are signed with and its attestation
+// The attestation is obtained when the server starts, the key lives as long as the server process.
+func (s *Server) GetAttestedKey(ctx context.Context, req *types.GetAttestedKeyRequest) (*types.GetAttestedKeyResponse, error) {
+	return s.receipts.Attested(), nil
+}
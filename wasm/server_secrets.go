@@ -0,0 +1,90 @@
+package wasm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// GetSecretKey returns the key secrets are sealed to, attested together with the client nonce
+// Clients check the evidence before sealing so secrets can only be opened inside the TEE.
+func (s *Server) GetSecretKey(ctx context.Context, req *types.GetSecretKeyRequest) (*types.GetSecretKeyResponse, error) {
+	publicKey := s.secrets.PublicKey()
+	reportData := reportdata.KeyReportData(reportdata.KeyPurposeSecrets, publicKey, req.Nonce)
+
+	attestation, err := s.attester.Attest(reportData)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate attestation: %v", err)
+	}
+
+	return &types.GetSecretKeyResponse{
+		PublicKey:           publicKey,
+		Algorithm:           SecretSealingAlgorithm,
+		Attestation:         string(attestation),
+		AttestationProvider: s.attester.Provider(),
+		ReportData:          hex.EncodeToString(reportData[:]),
+		Persistent:          s.secrets.Persistent(),
+	}, nil
+}
+
+// PutSecret stores a secret sealed to the key returned by GetSecretKey
+// An existing secret is only replaced by a request carrying the owner token it was stored with
+func (s *Server) PutSecret(ctx context.Context, req *types.PutSecretRequest) (*types.PutSecretResponse, error) {
+	secret, err := s.secrets.Put(req.Name, req.Sealed, req.AllowedModules, req.OwnerToken)
+	if err != nil {
+		return nil, secretStatusError(err)
+	}
+
+	return &types.PutSecretResponse{Secret: secret}, nil
+}
+
+// ListSecrets returns all stored secrets, without their values
+func (s *Server) ListSecrets(ctx context.Context, req *types.ListSecretsRequest) (*types.ListSecretsResponse, error) {
+	return &types.ListSecretsResponse{Secrets: s.secrets.List()}, nil
+}
+
+// DeleteSecret removes a stored secret, given the owner token it was stored with
+func (s *Server) DeleteSecret(ctx context.Context, req *types.DeleteSecretRequest) (*types.DeleteSecretResponse, error) {
+	if err := s.secrets.Delete(req.Name, req.OwnerToken); err != nil {
+		return nil, secretStatusError(err)
+	}
+
+	return &types.DeleteSecretResponse{}, nil
+}
+
+// newServerSecretVault creates the secret vault, keyed by the attester's sealing key when it has one
+// Without a sealing key the vault key changes on every restart and stored secrets must be uploaded again.
+func newServerSecretVault(dir string, attester Attester) (*SecretVault, error) {
+	var sealingKey []byte
+	if sealer, ok := attester.(Sealer); ok {
+		key, err := sealer.SealingKey()
+		if err != nil {
+			log.Printf("Sealing key unavailable, using an ephemeral secret key: %v", err)
+		}
+		sealingKey = key
+	}
+
+	return NewSecretVault(dir, sealingKey)
+}
+
+// secretStatusError maps secret vault failures to gRPC status errors
+func secretStatusError(err error) error {
+	switch {
+	case errors.Is(err, ErrSecretNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidSecretName), errors.Is(err, ErrInvalidSealedSecret), errors.Is(err, ErrInvalidModuleHash),
+		errors.Is(err, ErrInvalidOwnerToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrSecretOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
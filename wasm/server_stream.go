@@ -0,0 +1,55 @@
+package wasm
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// ExecuteStream runs an execution like Execute and streams its progress: the module loaded, the host HTTP calls,
+// the guest log lines and periodic gas checkpoints, ending with the attested result
+// A failed execution ends the stream with the status Execute would have returned.
+func (s *Server) ExecuteStream(req *types.WASMVMExecutionRequest, stream grpc.ServerStreamingServer[types.ExecutionEvent]) error {
+	if req.Execution == nil {
+		return status.Error(codes.InvalidArgument, "execution request is nil")
+	}
+
+	events := &eventStream{stream: stream}
+	result, err := s.execute(stream.Context(), req.Execution, events.send)
+	if err != nil {
+		return executionStatusError(err)
+	}
+
+	events.send(&types.ExecutionEvent{Event: &types.ExecutionEvent_Result{Result: result}})
+	return events.err
+}
+
+// eventStream numbers the events of an execution and sends them in order
+// Events come from the guest and from the gas checkpoints at the same time, sending is serialized.
+type eventStream struct {
+	stream grpc.ServerStreamingServer[types.ExecutionEvent]
+
+	mu       sync.Mutex
+	sequence uint64
+	err      error // First send failure, later events are dropped
+}
+
+// send stamps an event and sends it, blocking while the client is slow to receive
+// A failed send means the client is gone, the execution is then cancelled through the stream context.
+func (e *eventStream) send(event *types.ExecutionEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return
+	}
+
+	e.sequence++
+	event.Sequence = e.sequence
+	event.TimestampMs = time.Now().UnixMilli()
+	e.err = e.stream.Send(event)
+}
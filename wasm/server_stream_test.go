@@ -0,0 +1,96 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// recordingStream collects the events sent on a server stream
+type recordingStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*types.ExecutionEvent
+	err    error
+}
+
+// Context returns the context of the call
+func (r *recordingStream) Context() context.Context {
+	return r.ctx
+}
+
+// Send records an event, or fails with err
+func (r *recordingStream) Send(event *types.ExecutionEvent) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+// TestExecuteStream - Verifies that rejected executions end the stream with their status before any event
+func TestExecuteStream(t *testing.T) {
+	s, err := NewServer(Config{RequireNonce: true, Attester: newTestAttester(t)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		req          *types.WASMVMExecutionRequest
+		expectedCode codes.Code
+	}{
+		{"no_execution", &types.WASMVMExecutionRequest{}, codes.InvalidArgument},
+		{"no_nonce", &types.WASMVMExecutionRequest{Execution: &types.WASMVMExecution{FnName: "say"}}, codes.InvalidArgument},
+		{"unknown_module", &types.WASMVMExecutionRequest{Execution: &types.WASMVMExecution{ModuleHash: "00", Nonce: []byte{1}}}, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &recordingStream{ctx: context.Background()}
+			if err := s.ExecuteStream(tt.req, stream); status.Code(err) != tt.expectedCode {
+				t.Errorf("Expected %v, got %v", tt.expectedCode, err)
+			}
+			if len(stream.events) != 0 {
+				t.Errorf("Expected no events, got %v", stream.events)
+			}
+		})
+	}
+}
+
+// TestEventStream - Verifies that concurrent events are numbered in order and dropped once the client is gone
+func TestEventStream(t *testing.T) {
+	stream := &recordingStream{ctx: context.Background()}
+	events := &eventStream{stream: stream}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events.send(&types.ExecutionEvent{Event: &types.ExecutionEvent_Log{Log: &types.GuestLog{Message: "line"}}})
+		}()
+	}
+	wg.Wait()
+
+	for i, event := range stream.events {
+		if event.Sequence != uint64(i+1) || event.TimestampMs == 0 {
+			t.Errorf("Unexpected event %d: %v", i, event)
+		}
+	}
+
+	stream.err = errors.New("client gone")
+	events.send(&types.ExecutionEvent{})
+	stream.err = nil
+	events.send(&types.ExecutionEvent{})
+	if len(stream.events) != 10 || events.err == nil {
+		t.Errorf("Expected events after a failed send to be dropped, got %d events and %v", len(stream.events), events.err)
+	}
+}
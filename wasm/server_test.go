@@ -0,0 +1,226 @@
+package wasm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/evm"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// failingAttester is an attester without a TEE
+type failingAttester struct{}
+
+func (failingAttester) Provider() types.AttestationProvider {
+	return types.AttestationProvider_ATTESTATION_PROVIDER_SEV_SNP
+}
+
+func (failingAttester) Attest(reportData [64]byte) ([]byte, error) {
+	return nil, errors.New("no TEE")
+}
+
+// newTestAttester returns a MockAttester, failing the test when it cannot be created
+func newTestAttester(t *testing.T) *MockAttester {
+	t.Helper()
+
+	attester, err := NewMockAttester()
+	if err != nil {
+		t.Fatalf("Failed to create attester: %v", err)
+	}
+	return attester
+}
+
+// TestExecutionLimits - Verifies how request limits are combined with the server configuration
+func TestExecutionLimits(t *testing.T) {
+	s, err := NewServer(Config{MaxMemoryPages: 256, MaxTimeout: 2 * time.Second, Attester: newTestAttester(t)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		requested       uint32
+		requestedMs     uint64
+		expectedPages   uint32
+		expectedTimeout uint64
+	}{
+		{name: "server_default", requested: 0, requestedMs: 0, expectedPages: 256, expectedTimeout: 2000},
+		{name: "request_lowers_cap", requested: 16, requestedMs: 500, expectedPages: 16, expectedTimeout: 500},
+		{name: "request_cannot_raise_cap", requested: 1024, requestedMs: 5000, expectedPages: 256, expectedTimeout: 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := s.executionLimits(&types.WASMVMExecution{
+				GasLimit:       1000,
+				TimeoutMs:      tt.requestedMs,
+				MaxMemoryPages: tt.requested,
+			})
+
+			if limits.MaxMemoryPages != tt.expectedPages {
+				t.Errorf("Unexpected max memory pages. Expected %d, got %d", tt.expectedPages, limits.MaxMemoryPages)
+			}
+			if limits.TimeoutMs != tt.expectedTimeout {
+				t.Errorf("Unexpected timeout. Expected %d, got %d", tt.expectedTimeout, limits.TimeoutMs)
+			}
+			if limits.GasLimit != 1000 {
+				t.Errorf("Request limits were not echoed: %v", limits)
+			}
+		})
+	}
+
+	// Without a configured cap the default applies
+	s.config.MaxTimeout = 0
+	if limits := s.executionLimits(&types.WASMVMExecution{}); limits.TimeoutMs != uint64(DefaultMaxTimeout.Milliseconds()) {
+		t.Errorf("Expected the default timeout, got %d", limits.TimeoutMs)
+	}
+
+	gasTests := []struct {
+		name            string
+		defaultGasLimit uint64
+		maxGasLimit     uint64
+		requested       uint64
+		expected        uint64
+	}{
+		{name: "unmetered", requested: 0, expected: 0},
+		{name: "uncapped_request", requested: 5000, expected: 5000},
+		{name: "server_default", defaultGasLimit: 100, maxGasLimit: 1000, requested: 0, expected: 100},
+		{name: "max_as_default", maxGasLimit: 1000, requested: 0, expected: 1000},
+		{name: "default_above_max", defaultGasLimit: 5000, maxGasLimit: 1000, requested: 0, expected: 1000},
+		{name: "request_lowers_cap", defaultGasLimit: 100, maxGasLimit: 1000, requested: 500, expected: 500},
+		{name: "request_cannot_raise_cap", defaultGasLimit: 100, maxGasLimit: 1000, requested: 5000, expected: 1000},
+	}
+
+	for _, tt := range gasTests {
+		t.Run("gas_"+tt.name, func(t *testing.T) {
+			s.config.DefaultGasLimit, s.config.MaxGasLimit = tt.defaultGasLimit, tt.maxGasLimit
+			if limits := s.executionLimits(&types.WASMVMExecution{GasLimit: tt.requested}); limits.GasLimit != tt.expected {
+				t.Errorf("Unexpected gas limit. Expected %d, got %d", tt.expected, limits.GasLimit)
+			}
+		})
+	}
+}
+
+// TestVerifyExecution - Checks that failed verification is reported in the response, not as an RPC error
+func TestVerifyExecution(t *testing.T) {
+	s, err := NewServer(Config{Attester: newTestAttester(t)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	_, err = s.VerifyExecution(context.Background(), &types.VerifyExecutionRequest{
+		Result: &types.WASMVMExecutionResult{},
+		Policy: &types.VerificationPolicy{Measurement: "not hex"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a malformed policy, got %v", err)
+	}
+
+	response, err := s.VerifyExecution(context.Background(), &types.VerifyExecutionRequest{
+		Result: &types.WASMVMExecutionResult{Attestation: "{}"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to verify execution: %v", err)
+	}
+	if response.Verified || response.Error == "" {
+		t.Errorf("Expected an unattested result to fail verification, got %v", response)
+	}
+}
+
+// TestGetAttestedKey - Verifies that the receipt key is attested once and signs receipts
+func TestGetAttestedKey(t *testing.T) {
+	attester, err := NewMockAttester()
+	if err != nil {
+		t.Fatalf("Failed to create attester: %v", err)
+	}
+	s, err := NewServer(Config{Attester: attester})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	key, err := s.GetAttestedKey(context.Background(), &types.GetAttestedKeyRequest{})
+	if err != nil {
+		t.Fatalf("GetAttestedKey failed: %v", err)
+	}
+	reportData := reportdata.KeyReportData(reportdata.KeyPurposeReceipts, key.PublicKey, nil)
+	if key.ReportData != hex.EncodeToString(reportData[:]) || key.Algorithm != ReceiptSigningAlgorithm {
+		t.Errorf("Unexpected attested key %+v", key)
+	}
+	if again, _ := s.GetAttestedKey(context.Background(), &types.GetAttestedKeyRequest{}); again.Attestation != key.Attestation {
+		t.Errorf("Expected the attestation to be requested once")
+	}
+
+	// A server whose receipt key cannot be attested does not start
+	if _, err := NewServer(Config{Attester: failingAttester{}}); err == nil || !strings.Contains(err.Error(), "no TEE") {
+		t.Errorf("Expected the attestation failure, got %v", err)
+	}
+
+	// Receipts verify against the attested key
+	executionReportData := [64]byte{1, 2, 3}
+	signature := s.receipts.Sign(executionReportData)
+	publicKey, err := secp256k1.ParsePubKey(key.PublicKey)
+	if err != nil || len(signature) != 64 {
+		t.Fatalf("Unexpected key %v or signature %x", err, signature)
+	}
+	var r, sig secp256k1.ModNScalar
+	r.SetByteSlice(signature[:32])
+	sig.SetByteSlice(signature[32:])
+	digest := reportdata.ReceiptDigest(executionReportData)
+	if !ecdsa.NewSignature(&r, &sig).Verify(digest[:], publicKey) {
+		t.Errorf("Expected the receipt signature to verify")
+	}
+}
+
+// TestEvmResult - Verifies that EVM results are signed by the attested key and bad options are rejected early
+func TestEvmResult(t *testing.T) {
+	attester, err := NewMockAttester()
+	if err != nil {
+		t.Fatalf("Failed to create attester: %v", err)
+	}
+	s, err := NewServer(Config{Attester: attester})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	key, err := s.GetAttestedKey(context.Background(), &types.GetAttestedKeyRequest{})
+	if err != nil {
+		t.Fatalf("GetAttestedKey failed: %v", err)
+	}
+
+	execution := &types.WASMVMExecution{
+		RequestId: "req-1",
+		EvmOutput: &types.EvmOutputOptions{
+			OutputTypes:       []string{"uint256"},
+			Scheme:            types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP712,
+			ChainId:           1,
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+	}
+	outputs := []*types.WasmValue{{Value: &types.WasmValue_StringValue{StringValue: "42000000000000000000"}}}
+	result, err := s.evmResult(execution, outputs, reportdata.Components{ModuleHash: [32]byte{1}})
+	if err != nil {
+		t.Fatalf("Failed to build EVM result: %v", err)
+	}
+
+	digest, _ := hex.DecodeString(strings.TrimPrefix(result.Digest, "0x"))
+	signature, _ := hex.DecodeString(strings.TrimPrefix(result.Signature, "0x"))
+	signer, err := evm.RecoverAddress([32]byte(digest), signature)
+	if err != nil || signer != key.Address || result.Signer != key.Address {
+		t.Errorf("Expected the result to be signed by %s, recovered %s (%v), reported %s", key.Address, signer, err, result.Signer)
+	}
+
+	// Invalid options fail before the module runs
+	execution.EvmOutput.OutputTypes = []string{"uint7"}
+	if _, err := s.executeWASMVM(context.Background(), execution, nil); status.Code(executionStatusError(err)) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unsupported ABI type, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package wasm
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/google/go-sev-guest/kds"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/verify"
+)
+
+// VerifyExecution checks a result returned by Execute against the platform policy in the request
+// A result failing verification is reported in the response, only malformed requests return an error
+func (s *Server) VerifyExecution(ctx context.Context, req *types.VerifyExecutionRequest) (*types.VerifyExecutionResponse, error) {
+	if req.Result == nil {
+		return nil, status.Error(codes.InvalidArgument, "result is nil")
+	}
+
+	policy, err := verificationPolicy(req.Policy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	attestation, err := verify.Execution(req.Result, verify.Options{
+		Bundle:     s.config.VerifyBundle,
+		Policy:     policy,
+		ModuleHash: req.ModuleHash,
+		FnName:     req.FnName,
+		Nonce:      req.Nonce,
+	})
+	if err != nil {
+		return &types.VerifyExecutionResponse{Error: err.Error()}, nil
+	}
+
+	return &types.VerifyExecutionResponse{
+		Verified:    true,
+		Measurement: hex.EncodeToString(attestation.Report.Measurement),
+		ReportedTcb: attestation.Report.ReportedTcb,
+	}, nil
+}
+
+// verificationPolicy converts the policy of a request, an absent policy only rejects debug guests
+func verificationPolicy(p *types.VerificationPolicy) (verify.Policy, error) {
+	measurement, err := hex.DecodeString(p.GetMeasurement())
+	if err != nil {
+		return verify.Policy{}, err
+	}
+
+	return verify.Policy{
+		Measurement: measurement,
+		MinimumTCB:  kds.DecomposeTCBVersion(kds.TCBVersion(p.GetMinimumTcb())),
+		AllowDebug:  p.GetAllowDebug(),
+	}, nil
+}
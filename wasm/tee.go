@@ -0,0 +1,36 @@
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// Attester produces TEE evidence committing to report data
+type Attester interface {
+	// Provider identifies the evidence format in execution results
+	Provider() types.AttestationProvider
+	// Attest returns the serialized evidence binding reportData
+	Attest(reportData [64]byte) ([]byte, error)
+}
+
+// Sealer is implemented by attesters able to derive a key bound to the platform and the measurement
+// Keys derived from it survive restarts of the same image, so secrets sealed to them can be persisted.
+type Sealer interface {
+	// SealingKey returns the platform derived key material
+	SealingKey() ([]byte, error)
+}
+
+// NewAttester creates the attester selected by name: sev-snp, tdx or mock
+func NewAttester(name string) (Attester, error) {
+	switch name {
+	case "sev-snp":
+		return SEVSNPAttester{}, nil
+	case "tdx":
+		return TDXAttester{}, nil
+	case "mock":
+		return NewMockAttester()
+	default:
+		return nil, fmt.Errorf("unknown attestation provider %q", name)
+	}
+}
@@ -0,0 +1,108 @@
+package wasm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/google/go-sev-guest/abi"
+	spb "github.com/google/go-sev-guest/proto/sevsnp"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// MockAttester produces SEV-SNP shaped reports signed by a local ECDSA P-384 key
+// The evidence has the same JSON layout as SEVSNPAttester so clients can be exercised on
+// ordinary hosts, but it proves nothing: the signing key and its certificate are self-issued
+type MockAttester struct {
+	key         *ecdsa.PrivateKey
+	certificate []byte
+	measurement [abi.MeasurementSize]byte
+}
+
+var _ Attester = (*MockAttester)(nil)
+
+// NewMockAttester creates a mock attester with a fresh signing key
+// The measurement is the SHA-384 of the running executable, standing in for the launch measurement
+func NewMockAttester() (*MockAttester, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mock signing key: %v", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: "wasmvm-tee mock attester"},
+		NotBefore:    now,
+		NotAfter:     now.AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mock certificate: %v", err)
+	}
+
+	m := &MockAttester{key: key, certificate: certificate}
+	if executable, err := os.Executable(); err == nil {
+		if data, err := os.ReadFile(executable); err == nil {
+			m.measurement = sha512.Sum384(data)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *MockAttester) Provider() types.AttestationProvider {
+	return types.AttestationProvider_ATTESTATION_PROVIDER_MOCK
+}
+
+// Certificate returns the DER encoded self-signed certificate of the signing key
+func (m *MockAttester) Certificate() []byte {
+	return m.certificate
+}
+
+func (m *MockAttester) Attest(reportData [64]byte) ([]byte, error) {
+	// Lay the report out like the SEV-SNP firmware does, see the SEV-SNP ABI specification
+	report := make([]byte, abi.ReportSize)
+	binary.LittleEndian.PutUint32(report[0x00:0x04], 2)
+	binary.LittleEndian.PutUint64(report[0x08:0x10], abi.SnpPolicyToBytes(abi.SnpPolicy{SMT: true}))
+	// Signature algorithm ECDSA P-384 with SHA-384
+	binary.LittleEndian.PutUint32(report[0x34:0x38], 1)
+	copy(report[0x50:0x90], reportData[:])
+	copy(report[0x90:0xC0], m.measurement[:])
+
+	digest := sha512.Sum384(abi.SignedComponent(report))
+	r, s, err := ecdsa.Sign(rand.Reader, m.key, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing mock report: %w", err)
+	}
+	if err := abi.SetSignature(r, s, report); err != nil {
+		return nil, fmt.Errorf("signing mock report: %w", err)
+	}
+
+	reportProto, err := abi.ReportToProto(report)
+	if err != nil {
+		return nil, fmt.Errorf("parsing mock report: %w", err)
+	}
+
+	// Convert attestation to JSON string
+	jsonBytes, err := protojson.Marshal(&spb.Attestation{
+		Report:           reportProto,
+		CertificateChain: &spb.CertificateChain{VcekCert: m.certificate},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling attestation to JSON: %w", err)
+	}
+
+	return jsonBytes, nil
+}
@@ -5,9 +5,21 @@ import (
 
 	"github.com/google/go-sev-guest/client"
 	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
 )
 
-func generateAttestation(reportData [64]byte) ([]byte, error) {
+// SEVSNPAttester requests AMD SEV-SNP attestation reports from the guest firmware
+type SEVSNPAttester struct{}
+
+var _ Attester = SEVSNPAttester{}
+var _ Sealer = SEVSNPAttester{}
+
+func (SEVSNPAttester) Provider() types.AttestationProvider {
+	return types.AttestationProvider_ATTESTATION_PROVIDER_SEV_SNP
+}
+
+func (SEVSNPAttester) Attest(reportData [64]byte) ([]byte, error) {
 	// Get quote provider instead of opening device directly
 	quoteProvider, err := client.GetQuoteProvider()
 	if err != nil {
@@ -28,3 +40,26 @@ func generateAttestation(reportData [64]byte) ([]byte, error) {
 
 	return jsonBytes, nil
 }
+
+// SealingKey derives a key from the VCEK, the launch measurement and the guest policy
+// The key is the same for every launch of the same image on the same chip.
+func (SEVSNPAttester) SealingKey() ([]byte, error) {
+	device, err := client.OpenDevice()
+	if err != nil {
+		return nil, fmt.Errorf("opening SEV-SNP guest device: %w", err)
+	}
+	defer device.Close()
+
+	response, err := client.GetDerivedKeyAcknowledgingItsLimitations(device, &client.SnpDerivedKeyReq{
+		UseVCEK: true,
+		GuestFieldSelect: client.GuestFieldSelect{
+			Measurement: true,
+			GuestPolicy: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deriving sealing key: %w", err)
+	}
+
+	return response.Data[:], nil
+}
@@ -0,0 +1,46 @@
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/google/go-tdx-guest/client"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TDXAttester requests Intel TDX quotes through configfs-tsm or the TDX guest device
+type TDXAttester struct{}
+
+var _ Attester = TDXAttester{}
+
+func (TDXAttester) Provider() types.AttestationProvider {
+	return types.AttestationProvider_ATTESTATION_PROVIDER_TDX
+}
+
+func (TDXAttester) Attest(reportData [64]byte) ([]byte, error) {
+	// Get quote provider, the client falls back to the TDX guest device when configfs-tsm is missing
+	quoteProvider, err := client.GetQuoteProvider()
+	if err != nil {
+		return nil, fmt.Errorf("getting quote provider: %w", err)
+	}
+
+	quote, err := client.GetQuote(quoteProvider, reportData)
+	if err != nil {
+		return nil, fmt.Errorf("getting TDX quote: %w", err)
+	}
+
+	message, ok := quote.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unsupported TDX quote format %T", quote)
+	}
+
+	// Convert quote to JSON string
+	jsonBytes, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling quote to JSON: %w", err)
+	}
+
+	return jsonBytes, nil
+}
@@ -0,0 +1,57 @@
+package wasm
+
+import (
+	"bytes"
+	"crypto/x509"
+	"testing"
+
+	"github.com/google/go-sev-guest/abi"
+	spb "github.com/google/go-sev-guest/proto/sevsnp"
+	sevverify "github.com/google/go-sev-guest/verify"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// TestMockAttester - Checks that mock evidence has the SEV-SNP layout and a valid local signature
+func TestMockAttester(t *testing.T) {
+	attester, err := NewAttester("mock")
+	if err != nil {
+		t.Fatalf("Failed to create mock attester: %v", err)
+	}
+	if attester.Provider() != types.AttestationProvider_ATTESTATION_PROVIDER_MOCK {
+		t.Errorf("Unexpected provider %v", attester.Provider())
+	}
+
+	var reportData [64]byte
+	copy(reportData[:], "wasmvm-tee mock report data")
+
+	evidence, err := attester.Attest(reportData)
+	if err != nil {
+		t.Fatalf("Failed to attest: %v", err)
+	}
+
+	attestation := &spb.Attestation{}
+	if err := protojson.Unmarshal(evidence, attestation); err != nil {
+		t.Fatalf("Failed to parse evidence: %v", err)
+	}
+	if !bytes.Equal(attestation.Report.ReportData, reportData[:]) {
+		t.Errorf("Unexpected report data %x", attestation.Report.ReportData)
+	}
+
+	certificate, err := x509.ParseCertificate(attestation.CertificateChain.VcekCert)
+	if err != nil {
+		t.Fatalf("Failed to parse mock certificate: %v", err)
+	}
+	raw, err := abi.ReportToAbiBytes(attestation.Report)
+	if err != nil {
+		t.Fatalf("Failed to encode report: %v", err)
+	}
+	if err := sevverify.SnpReportSignature(raw, certificate); err != nil {
+		t.Errorf("Expected mock signature to verify: %v", err)
+	}
+
+	if _, err := NewAttester("unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown provider")
+	}
+}
@@ -0,0 +1,308 @@
+package wasm
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// HttpTLSOptions selects the host-held TLS material of an http request
+type HttpTLSOptions struct {
+	PinnedSPKI        []string `json:"pinned_spki,omitempty"`        // SPKI pins, at least one certificate of the verified chain must match
+	CABundle          string   `json:"ca_bundle,omitempty"`          // Named CA bundle replacing the system roots
+	ClientCertificate string   `json:"client_certificate,omitempty"` // Named client certificate presented for mTLS
+}
+
+// HttpTLSInfo describes the verified TLS connection a response was served over
+type HttpTLSInfo struct {
+	Version    string   `json:"version"`     // Negotiated protocol version, e.g. "TLS 1.3"
+	ServerName string   `json:"server_name"` // Host name the chain was verified for
+	PeerChain  []string `json:"peer_chain"`  // Verified chain as base64 DER, leaf first
+	SPKIPins   []string `json:"spki_pins"`   // SPKIPin of each certificate of PeerChain
+}
+
+// TLSError describes a connection rejected by pinning or a request naming unknown TLS material
+type TLSError struct {
+	Host   string `json:"host,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (e *TLSError) Error() string {
+	if e.Host == "" {
+		return fmt.Sprintf("tls policy: %s", e.Reason)
+	}
+	return fmt.Sprintf("tls policy: %s: %s", e.Host, e.Reason)
+}
+
+// ClientCertificateConfig locates a key pair the host presents on behalf of guests
+type ClientCertificateConfig struct {
+	CertFile string `json:"cert_file"` // PEM certificate chain, leaf first
+	KeyFile  string `json:"key_file"`  // PEM private key
+	// Modules lists the hashes of the modules allowed to use the certificate, empty allows every module
+	Modules []string `json:"modules,omitempty"`
+}
+
+// TLSConfig holds the CA bundles, client certificates and pins available to outbound calls
+// The material stays on the host, guests only refer to it by name through HttpTLSOptions.
+type TLSConfig struct {
+	// CABundles maps bundle names to PEM files of trusted roots
+	CABundles map[string]string `json:"ca_bundles,omitempty"`
+	// ClientCertificates maps certificate names to the key pairs used for mTLS
+	ClientCertificates map[string]*ClientCertificateConfig `json:"client_certificates,omitempty"`
+	// Pins maps host names to SPKI pins enforced on every connection to that host, whatever the request asks
+	Pins map[string][]string `json:"pins,omitempty"`
+
+	caBundles   map[string]*x509.CertPool
+	clientCerts map[string]tls.Certificate
+}
+
+// LoadTLSConfig reads a TLSConfig from a JSON file and loads the files it refers to
+func LoadTLSConfig(path string) (*TLSConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS config: %w", err)
+	}
+
+	config := &TLSConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse TLS config: %v", err)
+	}
+	if err := config.Load(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Load reads the CA bundles and client certificates and validates the pins
+func (c *TLSConfig) Load() error {
+	c.caBundles = make(map[string]*x509.CertPool, len(c.CABundles))
+	for name, path := range c.CABundles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle %q: %w", name, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("CA bundle %q holds no PEM certificates", name)
+		}
+		c.caBundles[name] = pool
+	}
+
+	c.clientCerts = make(map[string]tls.Certificate, len(c.ClientCertificates))
+	for name, cert := range c.ClientCertificates {
+		if cert == nil {
+			return fmt.Errorf("client certificate %q is empty", name)
+		}
+		pair, err := tls.LoadX509KeyPair(cert.CertFile, cert.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate %q: %v", name, err)
+		}
+		for i, hash := range cert.Modules {
+			normalized, err := normalizeModuleHash(hash)
+			if err != nil {
+				return fmt.Errorf("client certificate %q: %w", name, err)
+			}
+			cert.Modules[i] = normalized
+		}
+		c.clientCerts[name] = pair
+	}
+
+	pins := make(map[string][]string, len(c.Pins))
+	for host, hostPins := range c.Pins {
+		if err := validatePins(hostPins); err != nil {
+			return fmt.Errorf("pins of %s: %v", host, err)
+		}
+		pins[normalizeHost(host)] = hostPins
+	}
+	c.Pins = pins
+
+	return nil
+}
+
+// ForModule returns the configuration visible to the module with the given hash
+// Client certificates restricted to other modules are left out.
+func (c *TLSConfig) ForModule(moduleHash string) *TLSConfig {
+	if c == nil {
+		return nil
+	}
+
+	moduleHash = strings.ToLower(moduleHash)
+	scoped := *c
+	scoped.clientCerts = make(map[string]tls.Certificate, len(c.clientCerts))
+	for name, pair := range c.clientCerts {
+		modules := c.ClientCertificates[name].Modules
+		if len(modules) == 0 || slices.Contains(modules, moduleHash) {
+			scoped.clientCerts[name] = pair
+		}
+	}
+
+	return &scoped
+}
+
+// clientConfig returns the TLS configuration of each connection of a request, opts may be nil
+// Certificates are verified as usual, against the named CA bundle if any, and the chain must
+// additionally match the request pins and the configured pins of the host connected to.
+func (c *TLSConfig) clientConfig(opts *HttpTLSOptions) (func(host string) *tls.Config, error) {
+	if opts == nil {
+		opts = &HttpTLSOptions{}
+	}
+	base := &tls.Config{MinVersion: tls.VersionTLS12, NextProtos: []string{"h2", "http/1.1"}}
+
+	if opts.CABundle != "" {
+		if c == nil || c.caBundles[opts.CABundle] == nil {
+			return nil, &TLSError{Reason: fmt.Sprintf("unknown CA bundle %q", opts.CABundle)}
+		}
+		base.RootCAs = c.caBundles[opts.CABundle]
+	}
+
+	if opts.ClientCertificate != "" {
+		pair, ok := c.clientCertificate(opts.ClientCertificate)
+		if !ok {
+			return nil, &TLSError{Reason: fmt.Sprintf("unknown client certificate %q", opts.ClientCertificate)}
+		}
+		base.Certificates = []tls.Certificate{pair}
+	}
+
+	if err := validatePins(opts.PinnedSPKI); err != nil {
+		return nil, &TLSError{Reason: err.Error()}
+	}
+
+	return func(host string) *tls.Config {
+		config := base.Clone()
+		config.ServerName = host
+
+		host = normalizeHost(host)
+		var configured []string
+		if c != nil {
+			configured = c.Pins[host]
+		}
+		if len(configured) == 0 && len(opts.PinnedSPKI) == 0 {
+			return config
+		}
+
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(configured) > 0 && !chainMatchesPins(cs.VerifiedChains, configured) {
+				return &TLSError{Host: host, Reason: "no certificate matches the configured pins"}
+			}
+			if len(opts.PinnedSPKI) > 0 && !chainMatchesPins(cs.VerifiedChains, opts.PinnedSPKI) {
+				return &TLSError{Host: host, Reason: "no certificate matches the requested pins"}
+			}
+			return nil
+		}
+		return config
+	}, nil
+}
+
+// requireHTTPS rejects plain HTTP to pinned hosts and requests using TLS options
+// Without it a pin could be bypassed by simply asking for, or redirecting to, http.
+func (c *TLSConfig) requireHTTPS(opts *HttpTLSOptions, target *url.URL) error {
+	if strings.EqualFold(target.Scheme, "https") {
+		return nil
+	}
+
+	host := normalizeHost(target.Hostname())
+	if opts != nil && (len(opts.PinnedSPKI) > 0 || opts.CABundle != "" || opts.ClientCertificate != "") {
+		return &TLSError{Host: host, Reason: "tls options require https"}
+	}
+	if c != nil && len(c.Pins[host]) > 0 {
+		return &TLSError{Host: host, Reason: "pinned host requires https"}
+	}
+
+	return nil
+}
+
+// restrictRedirects keeps redirects of client within the TLS policy
+// Every redirect must satisfy requireHTTPS, and a client certificate is never presented
+// to a host other than the one the request was sent to.
+func (c *TLSConfig) restrictRedirects(client *http.Client, opts *HttpTLSOptions) *http.Client {
+	checkRedirect := client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := c.requireHTTPS(opts, req.URL); err != nil {
+			return err
+		}
+		if opts != nil && opts.ClientCertificate != "" && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+			return &TLSError{Host: normalizeHost(req.URL.Hostname()), Reason: "client certificate is not sent to redirect targets on other hosts"}
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		return nil
+	}
+
+	return client
+}
+
+// clientCertificate looks up a named client certificate
+func (c *TLSConfig) clientCertificate(name string) (tls.Certificate, bool) {
+	if c == nil {
+		return tls.Certificate{}, false
+	}
+	pair, ok := c.clientCerts[name]
+	return pair, ok
+}
+
+// SPKIPin returns the pin of a certificate: base64 of the SHA-256 of its SubjectPublicKeyInfo
+// This is the pin-sha256 format of RFC 7469, e.g. as printed by
+// openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// newHttpTLSInfo describes the verified chain of a connection, nil for plain HTTP
+func newHttpTLSInfo(cs *tls.ConnectionState) *HttpTLSInfo {
+	if cs == nil || len(cs.VerifiedChains) == 0 {
+		return nil
+	}
+
+	chain := cs.VerifiedChains[0]
+	info := &HttpTLSInfo{
+		Version:    tls.VersionName(cs.Version),
+		ServerName: cs.ServerName,
+		PeerChain:  make([]string, len(chain)),
+		SPKIPins:   make([]string, len(chain)),
+	}
+	for i, cert := range chain {
+		info.PeerChain[i] = base64.StdEncoding.EncodeToString(cert.Raw)
+		info.SPKIPins[i] = SPKIPin(cert)
+	}
+
+	return info
+}
+
+// chainMatchesPins reports whether any certificate of any verified chain has one of the pins
+func chainMatchesPins(chains [][]*x509.Certificate, pins []string) bool {
+	for _, chain := range chains {
+		for _, cert := range chain {
+			if slices.Contains(pins, SPKIPin(cert)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validatePins checks that every pin is a base64 SHA-256 digest
+func validatePins(pins []string) error {
+	for _, pin := range pins {
+		digest, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil || len(digest) != sha256.Size {
+			return fmt.Errorf("invalid SPKI pin %q", pin)
+		}
+	}
+	return nil
+}
+
+// normalizeHost lowercases a host name and strips its trailing dot
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
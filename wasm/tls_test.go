@@ -0,0 +1,182 @@
+package wasm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePEM writes a single PEM block to a file in dir
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// tlsRequest performs an http call with TLS options and decodes the response
+func tlsRequest(t *testing.T, config *TLSConfig, target string, opts *HttpTLSOptions) HttpResponse {
+	t.Helper()
+
+	policy := &EgressPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}}
+	request, _ := json.Marshal(HttpRequest{URL: target, TLS: opts})
+	respJSON, _ := performHttpRequest(context.Background(), httpOptions{egress: policy, limits: DefaultHttpLimits, tls: config}, string(request))
+
+	var response HttpResponse
+	if err := json.Unmarshal(respJSON, &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response
+}
+
+// TestHttpTLSPinning - Verifies CA bundles, request pins, configured host pins and the returned peer chain
+func TestHttpTLSPinning(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "pinned")
+	}))
+	defer server.Close()
+
+	serverCert := server.Certificate()
+	config := &TLSConfig{CABundles: map[string]string{
+		"test": writePEM(t, t.TempDir(), "ca.pem", "CERTIFICATE", serverCert.Raw),
+	}}
+	if err := config.Load(); err != nil {
+		t.Fatalf("Failed to load TLS config: %v", err)
+	}
+	pin := SPKIPin(serverCert)
+	otherPin := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+	tests := []struct {
+		name  string
+		opts  *HttpTLSOptions
+		error string
+	}{
+		{name: "bundle", opts: &HttpTLSOptions{CABundle: "test"}},
+		{name: "pinned", opts: &HttpTLSOptions{CABundle: "test", PinnedSPKI: []string{otherPin, pin}}},
+		{name: "system_roots", error: "certificate"},
+		{name: "pin_mismatch", opts: &HttpTLSOptions{CABundle: "test", PinnedSPKI: []string{otherPin}}, error: "requested pins"},
+		{name: "invalid_pin", opts: &HttpTLSOptions{CABundle: "test", PinnedSPKI: []string{"not-a-pin"}}, error: "invalid SPKI pin"},
+		{name: "unknown_bundle", opts: &HttpTLSOptions{CABundle: "missing"}, error: "unknown CA bundle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tlsRequest(t, config, server.URL, tt.opts)
+			if tt.error != "" {
+				if !strings.Contains(response.Error, tt.error) || response.StatusCode != 0 {
+					t.Errorf("Expected error containing %q, got %+v", tt.error, response)
+				}
+				return
+			}
+
+			if response.StatusCode != http.StatusOK || response.Body != "pinned" {
+				t.Fatalf("Expected request to succeed, got %+v", response)
+			}
+			if response.TLS == nil || len(response.TLS.PeerChain) == 0 {
+				t.Fatalf("Expected the verified peer chain, got %+v", response.TLS)
+			}
+			if response.TLS.PeerChain[0] != base64.StdEncoding.EncodeToString(serverCert.Raw) || response.TLS.SPKIPins[0] != pin {
+				t.Errorf("Unexpected peer chain %+v", response.TLS)
+			}
+		})
+	}
+
+	// Configured pins apply whatever the request asks, and cannot be dodged over plain HTTP
+	config.Pins = map[string][]string{"127.0.0.1": {otherPin}}
+	if err := config.Load(); err != nil {
+		t.Fatalf("Failed to load TLS config: %v", err)
+	}
+	if response := tlsRequest(t, config, server.URL, &HttpTLSOptions{CABundle: "test"}); !strings.Contains(response.Error, "configured pins") {
+		t.Errorf("Expected configured pin mismatch, got %+v", response)
+	}
+	plain := strings.Replace(server.URL, "https://", "http://", 1)
+	if response := tlsRequest(t, config, plain, nil); !strings.Contains(response.Error, "requires https") {
+		t.Errorf("Expected plain HTTP to a pinned host to be rejected, got %+v", response)
+	}
+}
+
+// TestHttpMutualTLS - Verifies that host-held client certificates are presented and scoped to modules
+func TestHttpMutualTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "wasmvm-tee"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	clientCert, _ := x509.ParseCertificate(certDER)
+	keyDER, _ := x509.MarshalPKCS8PrivateKey(key)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	module := ModuleHash([]byte("module"))
+	path := filepath.Join(dir, "tls.json")
+	data, _ := json.Marshal(map[string]any{
+		"ca_bundles": map[string]string{"test": writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw)},
+		"client_certificates": map[string]any{
+			"provider": map[string]any{
+				"cert_file": writePEM(t, dir, "client.pem", "CERTIFICATE", certDER),
+				"key_file":  writePEM(t, dir, "client.key", "PRIVATE KEY", keyDER),
+				"modules":   []string{strings.ToUpper(module)},
+			},
+		},
+	})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write TLS config: %v", err)
+	}
+	config, err := LoadTLSConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	opts := &HttpTLSOptions{CABundle: "test", ClientCertificate: "provider"}
+	response := tlsRequest(t, config.ForModule(module), server.URL, opts)
+	if response.StatusCode != http.StatusOK || response.Body != "wasmvm-tee" {
+		t.Fatalf("Expected the client certificate to be accepted, got %+v", response)
+	}
+
+	if response := tlsRequest(t, config.ForModule(module), server.URL, &HttpTLSOptions{CABundle: "test"}); response.StatusCode != 0 || response.Error == "" {
+		t.Errorf("Expected the server to require a client certificate, got %+v", response)
+	}
+
+	// Other modules cannot use the certificate
+	response = tlsRequest(t, config.ForModule(ModuleHash([]byte("other"))), server.URL, opts)
+	if !strings.Contains(response.Error, "unknown client certificate") {
+		t.Errorf("Expected the certificate to be scoped to its module, got %+v", response)
+	}
+}
@@ -22,17 +22,236 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// EvmSignatureScheme selects the digest the receipt key signs for EVM
+// contracts, see docs/evm.md
+type EvmSignatureScheme int32
+
+const (
+	EvmSignatureScheme_EVM_SIGNATURE_SCHEME_UNSPECIFIED EvmSignatureScheme = 0 // Same as EIP-191
+	EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP191      EvmSignatureScheme = 1 // Signed message of the encoding hash
+	EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP712      EvmSignatureScheme = 2 // Typed data ExecutionResult
+)
+
+// Enum value maps for EvmSignatureScheme.
+var (
+	EvmSignatureScheme_name = map[int32]string{
+		0: "EVM_SIGNATURE_SCHEME_UNSPECIFIED",
+		1: "EVM_SIGNATURE_SCHEME_EIP191",
+		2: "EVM_SIGNATURE_SCHEME_EIP712",
+	}
+	EvmSignatureScheme_value = map[string]int32{
+		"EVM_SIGNATURE_SCHEME_UNSPECIFIED": 0,
+		"EVM_SIGNATURE_SCHEME_EIP191":      1,
+		"EVM_SIGNATURE_SCHEME_EIP712":      2,
+	}
+)
+
+func (x EvmSignatureScheme) Enum() *EvmSignatureScheme {
+	p := new(EvmSignatureScheme)
+	*p = x
+	return p
+}
+
+func (x EvmSignatureScheme) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EvmSignatureScheme) Descriptor() protoreflect.EnumDescriptor {
+	return file_wasm_wasm_server_proto_enumTypes[0].Descriptor()
+}
+
+func (EvmSignatureScheme) Type() protoreflect.EnumType {
+	return &file_wasm_wasm_server_proto_enumTypes[0]
+}
+
+func (x EvmSignatureScheme) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EvmSignatureScheme.Descriptor instead.
+func (EvmSignatureScheme) EnumDescriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{0}
+}
+
+// ExecutionMode identifies how the module was executed
+type ExecutionMode int32
+
+const (
+	ExecutionMode_EXECUTION_MODE_UNSPECIFIED ExecutionMode = 0
+	ExecutionMode_EXECUTION_MODE_INTERPRETER ExecutionMode = 1 // WasmEdge interpreter
+	ExecutionMode_EXECUTION_MODE_AOT         ExecutionMode = 2 // Native code from the WasmEdge AOT compiler
+)
+
+// Enum value maps for ExecutionMode.
+var (
+	ExecutionMode_name = map[int32]string{
+		0: "EXECUTION_MODE_UNSPECIFIED",
+		1: "EXECUTION_MODE_INTERPRETER",
+		2: "EXECUTION_MODE_AOT",
+	}
+	ExecutionMode_value = map[string]int32{
+		"EXECUTION_MODE_UNSPECIFIED": 0,
+		"EXECUTION_MODE_INTERPRETER": 1,
+		"EXECUTION_MODE_AOT":         2,
+	}
+)
+
+func (x ExecutionMode) Enum() *ExecutionMode {
+	p := new(ExecutionMode)
+	*p = x
+	return p
+}
+
+func (x ExecutionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_wasm_wasm_server_proto_enumTypes[1].Descriptor()
+}
+
+func (ExecutionMode) Type() protoreflect.EnumType {
+	return &file_wasm_wasm_server_proto_enumTypes[1]
+}
+
+func (x ExecutionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutionMode.Descriptor instead.
+func (ExecutionMode) EnumDescriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{1}
+}
+
+// AttestationProvider identifies the TEE that produced the attestation
+type AttestationProvider int32
+
+const (
+	AttestationProvider_ATTESTATION_PROVIDER_UNSPECIFIED AttestationProvider = 0
+	AttestationProvider_ATTESTATION_PROVIDER_SEV_SNP     AttestationProvider = 1 // AMD SEV-SNP attestation report
+	AttestationProvider_ATTESTATION_PROVIDER_TDX         AttestationProvider = 2 // Intel TDX quote
+	AttestationProvider_ATTESTATION_PROVIDER_MOCK        AttestationProvider = 3 // Software report signed by a local key
+)
+
+// Enum value maps for AttestationProvider.
+var (
+	AttestationProvider_name = map[int32]string{
+		0: "ATTESTATION_PROVIDER_UNSPECIFIED",
+		1: "ATTESTATION_PROVIDER_SEV_SNP",
+		2: "ATTESTATION_PROVIDER_TDX",
+		3: "ATTESTATION_PROVIDER_MOCK",
+	}
+	AttestationProvider_value = map[string]int32{
+		"ATTESTATION_PROVIDER_UNSPECIFIED": 0,
+		"ATTESTATION_PROVIDER_SEV_SNP":     1,
+		"ATTESTATION_PROVIDER_TDX":         2,
+		"ATTESTATION_PROVIDER_MOCK":        3,
+	}
+)
+
+func (x AttestationProvider) Enum() *AttestationProvider {
+	p := new(AttestationProvider)
+	*p = x
+	return p
+}
+
+func (x AttestationProvider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttestationProvider) Descriptor() protoreflect.EnumDescriptor {
+	return file_wasm_wasm_server_proto_enumTypes[2].Descriptor()
+}
+
+func (AttestationProvider) Type() protoreflect.EnumType {
+	return &file_wasm_wasm_server_proto_enumTypes[2]
+}
+
+func (x AttestationProvider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttestationProvider.Descriptor instead.
+func (AttestationProvider) EnumDescriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{2}
+}
+
+// ExecutionState is the lifecycle state of a submitted execution
+type ExecutionState int32
+
+const (
+	ExecutionState_EXECUTION_STATE_UNSPECIFIED ExecutionState = 0
+	ExecutionState_EXECUTION_STATE_QUEUED      ExecutionState = 1 // Waiting for a worker
+	ExecutionState_EXECUTION_STATE_RUNNING     ExecutionState = 2 // Executing
+	ExecutionState_EXECUTION_STATE_SUCCEEDED   ExecutionState = 3 // Finished, result is set
+	ExecutionState_EXECUTION_STATE_FAILED      ExecutionState = 4 // Finished, error is set
+	ExecutionState_EXECUTION_STATE_CANCELLED   ExecutionState = 5 // Cancelled before it finished
+)
+
+// Enum value maps for ExecutionState.
+var (
+	ExecutionState_name = map[int32]string{
+		0: "EXECUTION_STATE_UNSPECIFIED",
+		1: "EXECUTION_STATE_QUEUED",
+		2: "EXECUTION_STATE_RUNNING",
+		3: "EXECUTION_STATE_SUCCEEDED",
+		4: "EXECUTION_STATE_FAILED",
+		5: "EXECUTION_STATE_CANCELLED",
+	}
+	ExecutionState_value = map[string]int32{
+		"EXECUTION_STATE_UNSPECIFIED": 0,
+		"EXECUTION_STATE_QUEUED":      1,
+		"EXECUTION_STATE_RUNNING":     2,
+		"EXECUTION_STATE_SUCCEEDED":   3,
+		"EXECUTION_STATE_FAILED":      4,
+		"EXECUTION_STATE_CANCELLED":   5,
+	}
+)
+
+func (x ExecutionState) Enum() *ExecutionState {
+	p := new(ExecutionState)
+	*p = x
+	return p
+}
+
+func (x ExecutionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_wasm_wasm_server_proto_enumTypes[3].Descriptor()
+}
+
+func (ExecutionState) Type() protoreflect.EnumType {
+	return &file_wasm_wasm_server_proto_enumTypes[3]
+}
+
+func (x ExecutionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutionState.Descriptor instead.
+func (ExecutionState) EnumDescriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{3}
+}
+
 // WASMVMExecution represents a WASMVM execution request containing
 // the bytecode and input parameters to be executed in TEE environment
 type WASMVMExecution struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Version            string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                                                    // Protocol version
-	RequestId          string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                               // Unique request identifier
+	RequestId          string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                               // Unique request identifier, idempotency key
 	Bytecode           string                 `protobuf:"bytes,3,opt,name=bytecode,proto3" json:"bytecode,omitempty"`                                                  // WASMVM bytecode (base64 encoded)
 	FnName             string                 `protobuf:"bytes,4,opt,name=fn_name,json=fnName,proto3" json:"fn_name,omitempty"`                                        // Function name to execute
 	Inputs             []*WasmValue           `protobuf:"bytes,5,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                      // Input parameters
-	Timestamp          int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                               // Timestamp of the execution request
+	Timestamp          int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                               // Request time in Unix seconds
 	IsForceInterpreter bool                   `protobuf:"varint,7,opt,name=is_force_interpreter,json=isForceInterpreter,proto3" json:"is_force_interpreter,omitempty"` // Whether to force interpreter mode
+	GasLimit           uint64                 `protobuf:"varint,8,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`                                 // Gas budget for the execution, 0 = default
+	TimeoutMs          uint64                 `protobuf:"varint,9,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`                              // Wall-clock timeout in milliseconds, 0 = max
+	MaxMemoryPages     uint32                 `protobuf:"varint,10,opt,name=max_memory_pages,json=maxMemoryPages,proto3" json:"max_memory_pages,omitempty"`            // Memory cap in 64 KiB pages, 0 = default
+	ModuleHash         string                 `protobuf:"bytes,11,opt,name=module_hash,json=moduleHash,proto3" json:"module_hash,omitempty"`                           // Registered module SHA-256 (hex), or bytecode
+	Nonce              []byte                 `protobuf:"bytes,12,opt,name=nonce,proto3" json:"nonce,omitempty"`                                                       // Client nonce committed into report data
+	EvmOutput          *EvmOutputOptions      `protobuf:"bytes,13,opt,name=evm_output,json=evmOutput,proto3" json:"evm_output,omitempty"`                              // Also sign the outputs for EVM contracts
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -116,32 +335,74 @@ func (x *WASMVMExecution) GetIsForceInterpreter() bool {
 	return false
 }
 
-// WASMVMExecutionResult contains the complete execution result
-// including inputs, outputs, hashes, and TEE attestation data
-type WASMVMExecutionResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inputs        []*WasmValue           `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`                                 // Original input parameters (base64 encoded)
-	OutputValues  []*WasmValue           `protobuf:"bytes,3,rep,name=output_values,json=outputValues,proto3" json:"output_values,omitempty"` // Execution output values
-	Attestation   string                 `protobuf:"bytes,5,opt,name=attestation,proto3" json:"attestation,omitempty"`                       // TEE attestation report (JSON string)
-	ReportData    string                 `protobuf:"bytes,6,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`       // TEE report data (hex encoded), hash(inputs+outputs)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WASMVMExecution) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
 }
 
-func (x *WASMVMExecutionResult) Reset() {
-	*x = WASMVMExecutionResult{}
+func (x *WASMVMExecution) GetTimeoutMs() uint64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *WASMVMExecution) GetMaxMemoryPages() uint32 {
+	if x != nil {
+		return x.MaxMemoryPages
+	}
+	return 0
+}
+
+func (x *WASMVMExecution) GetModuleHash() string {
+	if x != nil {
+		return x.ModuleHash
+	}
+	return ""
+}
+
+func (x *WASMVMExecution) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *WASMVMExecution) GetEvmOutput() *EvmOutputOptions {
+	if x != nil {
+		return x.EvmOutput
+	}
+	return nil
+}
+
+// EvmOutputOptions asks for the outputs ABI encoded and signed for EVM
+// contracts
+type EvmOutputOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OutputTypes       []string               `protobuf:"bytes,1,rep,name=output_types,json=outputTypes,proto3" json:"output_types,omitempty"`                   // ABI type of each output, default by type
+	Scheme            EvmSignatureScheme     `protobuf:"varint,2,opt,name=scheme,proto3,enum=wasm.EvmSignatureScheme" json:"scheme,omitempty"`                  // Digest signed by the receipt key
+	ChainId           uint64                 `protobuf:"varint,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`                              // EIP-712 domain chain id
+	VerifyingContract string                 `protobuf:"bytes,4,opt,name=verifying_contract,json=verifyingContract,proto3" json:"verifying_contract,omitempty"` // EIP-712 domain contract address
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EvmOutputOptions) Reset() {
+	*x = EvmOutputOptions{}
 	mi := &file_wasm_wasm_server_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WASMVMExecutionResult) String() string {
+func (x *EvmOutputOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WASMVMExecutionResult) ProtoMessage() {}
+func (*EvmOutputOptions) ProtoMessage() {}
 
-func (x *WASMVMExecutionResult) ProtoReflect() protoreflect.Message {
+func (x *EvmOutputOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_wasm_wasm_server_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -153,62 +414,71 @@ func (x *WASMVMExecutionResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WASMVMExecutionResult.ProtoReflect.Descriptor instead.
-func (*WASMVMExecutionResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use EvmOutputOptions.ProtoReflect.Descriptor instead.
+func (*EvmOutputOptions) Descriptor() ([]byte, []int) {
 	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *WASMVMExecutionResult) GetInputs() []*WasmValue {
+func (x *EvmOutputOptions) GetOutputTypes() []string {
 	if x != nil {
-		return x.Inputs
+		return x.OutputTypes
 	}
 	return nil
 }
 
-func (x *WASMVMExecutionResult) GetOutputValues() []*WasmValue {
+func (x *EvmOutputOptions) GetScheme() EvmSignatureScheme {
 	if x != nil {
-		return x.OutputValues
+		return x.Scheme
 	}
-	return nil
+	return EvmSignatureScheme_EVM_SIGNATURE_SCHEME_UNSPECIFIED
 }
 
-func (x *WASMVMExecutionResult) GetAttestation() string {
+func (x *EvmOutputOptions) GetChainId() uint64 {
 	if x != nil {
-		return x.Attestation
+		return x.ChainId
 	}
-	return ""
+	return 0
 }
 
-func (x *WASMVMExecutionResult) GetReportData() string {
+func (x *EvmOutputOptions) GetVerifyingContract() string {
 	if x != nil {
-		return x.ReportData
+		return x.VerifyingContract
 	}
 	return ""
 }
 
-// WASMVMExecutionRequest combines execution parameters and runtime
-// configuration
-type WASMVMExecutionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Execution     *WASMVMExecution       `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"` // Execution parameters (bytecode, inputs)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// EvmResult holds the outputs ABI encoded with the request id, module hash
+// and report data digest, and their signature by the receipt key
+type EvmResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	RequestId         string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                         // Request id the result was signed for
+	OutputTypes       []string               `protobuf:"bytes,2,rep,name=output_types,json=outputTypes,proto3" json:"output_types,omitempty"`                   // ABI types of the outputs
+	Outputs           string                 `protobuf:"bytes,3,opt,name=outputs,proto3" json:"outputs,omitempty"`                                              // abi.encode of the outputs (0x hex)
+	Encoded           string                 `protobuf:"bytes,4,opt,name=encoded,proto3" json:"encoded,omitempty"`                                              // abi.encode of the result (0x hex)
+	Scheme            EvmSignatureScheme     `protobuf:"varint,5,opt,name=scheme,proto3,enum=wasm.EvmSignatureScheme" json:"scheme,omitempty"`                  // Digest scheme
+	ChainId           uint64                 `protobuf:"varint,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`                              // EIP-712 domain chain id
+	VerifyingContract string                 `protobuf:"bytes,7,opt,name=verifying_contract,json=verifyingContract,proto3" json:"verifying_contract,omitempty"` // EIP-712 domain contract address
+	Digest            string                 `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"`                                                // Signed digest (0x hex)
+	Signature         string                 `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`                                          // r || s || v, v is 27 or 28 (0x hex)
+	Signer            string                 `protobuf:"bytes,10,opt,name=signer,proto3" json:"signer,omitempty"`                                               // Address of the receipt key
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *WASMVMExecutionRequest) Reset() {
-	*x = WASMVMExecutionRequest{}
+func (x *EvmResult) Reset() {
+	*x = EvmResult{}
 	mi := &file_wasm_wasm_server_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WASMVMExecutionRequest) String() string {
+func (x *EvmResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WASMVMExecutionRequest) ProtoMessage() {}
+func (*EvmResult) ProtoMessage() {}
 
-func (x *WASMVMExecutionRequest) ProtoReflect() protoreflect.Message {
+func (x *EvmResult) ProtoReflect() protoreflect.Message {
 	mi := &file_wasm_wasm_server_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -220,41 +490,105 @@ func (x *WASMVMExecutionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WASMVMExecutionRequest.ProtoReflect.Descriptor instead.
-func (*WASMVMExecutionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EvmResult.ProtoReflect.Descriptor instead.
+func (*EvmResult) Descriptor() ([]byte, []int) {
 	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *WASMVMExecutionRequest) GetExecution() *WASMVMExecution {
+func (x *EvmResult) GetRequestId() string {
 	if x != nil {
-		return x.Execution
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *EvmResult) GetOutputTypes() []string {
+	if x != nil {
+		return x.OutputTypes
 	}
 	return nil
 }
 
-// WASMVMExecutionResponse contains the execution result with request tracking
-type WASMVMExecutionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Request identifier for tracking
-	Result        *WASMVMExecutionResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`                        // Complete execution result
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *EvmResult) GetOutputs() string {
+	if x != nil {
+		return x.Outputs
+	}
+	return ""
 }
 
-func (x *WASMVMExecutionResponse) Reset() {
-	*x = WASMVMExecutionResponse{}
+func (x *EvmResult) GetEncoded() string {
+	if x != nil {
+		return x.Encoded
+	}
+	return ""
+}
+
+func (x *EvmResult) GetScheme() EvmSignatureScheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return EvmSignatureScheme_EVM_SIGNATURE_SCHEME_UNSPECIFIED
+}
+
+func (x *EvmResult) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *EvmResult) GetVerifyingContract() string {
+	if x != nil {
+		return x.VerifyingContract
+	}
+	return ""
+}
+
+func (x *EvmResult) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *EvmResult) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *EvmResult) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+// ExecutionLimits describes the resource envelope an execution ran under
+type ExecutionLimits struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GasLimit       uint64                 `protobuf:"varint,1,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`                     // Gas budget, 0 = unlimited
+	TimeoutMs      uint64                 `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`                  // Wall-clock timeout in milliseconds
+	MaxMemoryPages uint32                 `protobuf:"varint,3,opt,name=max_memory_pages,json=maxMemoryPages,proto3" json:"max_memory_pages,omitempty"` // Linear memory cap in 64 KiB pages, 0 = none
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecutionLimits) Reset() {
+	*x = ExecutionLimits{}
 	mi := &file_wasm_wasm_server_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WASMVMExecutionResponse) String() string {
+func (x *ExecutionLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WASMVMExecutionResponse) ProtoMessage() {}
+func (*ExecutionLimits) ProtoMessage() {}
 
-func (x *WASMVMExecutionResponse) ProtoReflect() protoreflect.Message {
+func (x *ExecutionLimits) ProtoReflect() protoreflect.Message {
 	mi := &file_wasm_wasm_server_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -266,55 +600,3538 @@ func (x *WASMVMExecutionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WASMVMExecutionResponse.ProtoReflect.Descriptor instead.
-func (*WASMVMExecutionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExecutionLimits.ProtoReflect.Descriptor instead.
+func (*ExecutionLimits) Descriptor() ([]byte, []int) {
 	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *WASMVMExecutionResponse) GetRequestId() string {
+func (x *ExecutionLimits) GetGasLimit() uint64 {
 	if x != nil {
-		return x.RequestId
+		return x.GasLimit
 	}
-	return ""
+	return 0
 }
 
-func (x *WASMVMExecutionResponse) GetResult() *WASMVMExecutionResult {
+func (x *ExecutionLimits) GetTimeoutMs() uint64 {
 	if x != nil {
-		return x.Result
+		return x.TimeoutMs
 	}
-	return nil
+	return 0
 }
 
-var File_wasm_wasm_server_proto protoreflect.FileDescriptor
+func (x *ExecutionLimits) GetMaxMemoryPages() uint32 {
+	if x != nil {
+		return x.MaxMemoryPages
+	}
+	return 0
+}
 
-const file_wasm_wasm_server_proto_rawDesc = "" +
-	"\n" +
-	"\x16wasm/wasm_server.proto\x12\x04wasm\x1a\x1cgoogle/api/annotations.proto\x1a\x15wasm/wasm_input.proto\"\xf8\x01\n" +
-	"\x0fWASMVMExecution\x12\x18\n" +
-	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1d\n" +
-	"\n" +
-	"request_id\x18\x02 \x01(\tR\trequestId\x12\x1a\n" +
-	"\bbytecode\x18\x03 \x01(\tR\bbytecode\x12\x17\n" +
-	"\afn_name\x18\x04 \x01(\tR\x06fnName\x12'\n" +
-	"\x06inputs\x18\x05 \x03(\v2\x0f.wasm.WasmValueR\x06inputs\x12\x1c\n" +
-	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x120\n" +
-	"\x14is_force_interpreter\x18\a \x01(\bR\x12isForceInterpreter\"\xb9\x01\n" +
-	"\x15WASMVMExecutionResult\x12'\n" +
-	"\x06inputs\x18\x01 \x03(\v2\x0f.wasm.WasmValueR\x06inputs\x124\n" +
-	"\routput_values\x18\x03 \x03(\v2\x0f.wasm.WasmValueR\foutputValues\x12 \n" +
-	"\vattestation\x18\x05 \x01(\tR\vattestation\x12\x1f\n" +
-	"\vreport_data\x18\x06 \x01(\tR\n" +
-	"reportData\"M\n" +
-	"\x16WASMVMExecutionRequest\x123\n" +
-	"\texecution\x18\x01 \x01(\v2\x15.wasm.WASMVMExecutionR\texecution\"m\n" +
-	"\x17WASMVMExecutionResponse\x12\x1d\n" +
-	"\n" +
-	"request_id\x18\x01 \x01(\tR\trequestId\x123\n" +
-	"\x06result\x18\x02 \x01(\v2\x1b.wasm.WASMVMExecutionResultR\x06result2w\n" +
-	"\x10WASMVMTeeService\x12c\n" +
-	"\aExecute\x12\x1c.wasm.WASMVMExecutionRequest\x1a\x1d.wasm.WASMVMExecutionResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/dtvm/executeB/Z-github.com/IntelliXLabs/wasmvm-tee/wasm/typesb\x06proto3"
+// WASMVMExecutionResult contains the complete execution result
+// including inputs, outputs, hashes, and TEE attestation data
+type WASMVMExecutionResult struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Inputs               []*WasmValue           `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                      // Original input parameters (base64 encoded)
+	OutputValues         []*WasmValue           `protobuf:"bytes,3,rep,name=output_values,json=outputValues,proto3" json:"output_values,omitempty"`                                                      // Execution output values
+	Attestation          string                 `protobuf:"bytes,5,opt,name=attestation,proto3" json:"attestation,omitempty"`                                                                            // TEE attestation report (JSON string)
+	ReportData           string                 `protobuf:"bytes,6,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`                                                            // TEE report data (hex encoded), ReportDataComponents
+	GasUsed              uint64                 `protobuf:"varint,7,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`                                                                    // Gas consumed by the execution
+	Limits               *ExecutionLimits       `protobuf:"bytes,8,opt,name=limits,proto3" json:"limits,omitempty"`                                                                                      // Resource limits the execution ran under
+	ExecutionMode        ExecutionMode          `protobuf:"varint,9,opt,name=execution_mode,json=executionMode,proto3,enum=wasm.ExecutionMode" json:"execution_mode,omitempty"`                          // Interpreter or AOT-compiled execution
+	ReportDataComponents *ReportDataComponents  `protobuf:"bytes,10,opt,name=report_data_components,json=reportDataComponents,proto3" json:"report_data_components,omitempty"`                           // Hashes in report_data
+	AttestationProvider  AttestationProvider    `protobuf:"varint,11,opt,name=attestation_provider,json=attestationProvider,proto3,enum=wasm.AttestationProvider" json:"attestation_provider,omitempty"` // Producer of attestation
+	HttpTranscript       []*HttpExchange        `protobuf:"bytes,12,rep,name=http_transcript,json=httpTranscript,proto3" json:"http_transcript,omitempty"`                                               // Outbound requests in order
+	SecretReferences     []*SecretReference     `protobuf:"bytes,13,rep,name=secret_references,json=secretReferences,proto3" json:"secret_references,omitempty"`                                         // Secrets used, by name
+	ReceiptSignature     string                 `protobuf:"bytes,14,opt,name=receipt_signature,json=receiptSignature,proto3" json:"receipt_signature,omitempty"`                                         // Signature of report_data, GetAttestedKey
+	ReceiptPublicKey     string                 `protobuf:"bytes,15,opt,name=receipt_public_key,json=receiptPublicKey,proto3" json:"receipt_public_key,omitempty"`                                       // Key of receipt_signature (hex)
+	EvmResult            *EvmResult             `protobuf:"bytes,16,opt,name=evm_result,json=evmResult,proto3" json:"evm_result,omitempty"`                                                              // Outputs signed for EVM contracts
+	BatchProof           *BatchProof            `protobuf:"bytes,17,opt,name=batch_proof,json=batchProof,proto3" json:"batch_proof,omitempty"`                                                           // Set when attestation covers a batch
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
 
-var (
+func (x *WASMVMExecutionResult) Reset() {
+	*x = WASMVMExecutionResult{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WASMVMExecutionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WASMVMExecutionResult) ProtoMessage() {}
+
+func (x *WASMVMExecutionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WASMVMExecutionResult.ProtoReflect.Descriptor instead.
+func (*WASMVMExecutionResult) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WASMVMExecutionResult) GetInputs() []*WasmValue {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetOutputValues() []*WasmValue {
+	if x != nil {
+		return x.OutputValues
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetAttestation() string {
+	if x != nil {
+		return x.Attestation
+	}
+	return ""
+}
+
+func (x *WASMVMExecutionResult) GetReportData() string {
+	if x != nil {
+		return x.ReportData
+	}
+	return ""
+}
+
+func (x *WASMVMExecutionResult) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *WASMVMExecutionResult) GetLimits() *ExecutionLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetExecutionMode() ExecutionMode {
+	if x != nil {
+		return x.ExecutionMode
+	}
+	return ExecutionMode_EXECUTION_MODE_UNSPECIFIED
+}
+
+func (x *WASMVMExecutionResult) GetReportDataComponents() *ReportDataComponents {
+	if x != nil {
+		return x.ReportDataComponents
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetAttestationProvider() AttestationProvider {
+	if x != nil {
+		return x.AttestationProvider
+	}
+	return AttestationProvider_ATTESTATION_PROVIDER_UNSPECIFIED
+}
+
+func (x *WASMVMExecutionResult) GetHttpTranscript() []*HttpExchange {
+	if x != nil {
+		return x.HttpTranscript
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetSecretReferences() []*SecretReference {
+	if x != nil {
+		return x.SecretReferences
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetReceiptSignature() string {
+	if x != nil {
+		return x.ReceiptSignature
+	}
+	return ""
+}
+
+func (x *WASMVMExecutionResult) GetReceiptPublicKey() string {
+	if x != nil {
+		return x.ReceiptPublicKey
+	}
+	return ""
+}
+
+func (x *WASMVMExecutionResult) GetEvmResult() *EvmResult {
+	if x != nil {
+		return x.EvmResult
+	}
+	return nil
+}
+
+func (x *WASMVMExecutionResult) GetBatchProof() *BatchProof {
+	if x != nil {
+		return x.BatchProof
+	}
+	return nil
+}
+
+// BatchProof places an execution in a batch whose Merkle root is attested
+// once. The attestation of the result then carries the batch report data,
+// see docs/canonical-encoding.md
+type BatchProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`                             // Merkle root of the batch (hex)
+	LeafIndex     uint64                 `protobuf:"varint,2,opt,name=leaf_index,json=leafIndex,proto3" json:"leaf_index,omitempty"` // Position of the execution in the batch
+	BatchSize     uint64                 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // Number of executions in the batch
+	Proof         []string               `protobuf:"bytes,4,rep,name=proof,proto3" json:"proof,omitempty"`                           // Sibling hashes from the leaf up (hex)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchProof) Reset() {
+	*x = BatchProof{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProof) ProtoMessage() {}
+
+func (x *BatchProof) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProof.ProtoReflect.Descriptor instead.
+func (*BatchProof) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchProof) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *BatchProof) GetLeafIndex() uint64 {
+	if x != nil {
+		return x.LeafIndex
+	}
+	return 0
+}
+
+func (x *BatchProof) GetBatchSize() uint64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *BatchProof) GetProof() []string {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// HttpExchange records a request made through the fetch or http host
+// functions. The ordered list is committed into report data as a Merkle
+// root, see docs/canonical-encoding.md
+type HttpExchange struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Method             string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`                                                                             // Request method
+	Url                string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                                                                                   // URL the response was served from
+	StatusCode         int32                  `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`                                                  // Response status, 0 when none received
+	Headers            map[string]string      `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Recorded response headers, lower case
+	BodyHash           string                 `protobuf:"bytes,5,opt,name=body_hash,json=bodyHash,proto3" json:"body_hash,omitempty"`                                                         // SHA-256 of the response body (hex)
+	TlsCertFingerprint string                 `protobuf:"bytes,6,opt,name=tls_cert_fingerprint,json=tlsCertFingerprint,proto3" json:"tls_cert_fingerprint,omitempty"`                         // SHA-256 of the server leaf cert (hex)
+	Error              string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`                                                                               // Transport error, if any
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HttpExchange) Reset() {
+	*x = HttpExchange{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpExchange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpExchange) ProtoMessage() {}
+
+func (x *HttpExchange) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpExchange.ProtoReflect.Descriptor instead.
+func (*HttpExchange) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HttpExchange) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *HttpExchange) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HttpExchange) GetBodyHash() string {
+	if x != nil {
+		return x.BodyHash
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetTlsCertFingerprint() string {
+	if x != nil {
+		return x.TlsCertFingerprint
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// SecretReference identifies the sealed secret an execution used without
+// revealing its value. The references are committed into report data as a
+// Merkle root, see docs/canonical-encoding.md
+type SecretReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                               // Secret name
+	SealedHash    string                 `protobuf:"bytes,2,opt,name=sealed_hash,json=sealedHash,proto3" json:"sealed_hash,omitempty"` // SHA-256 of the sealed secret (hex)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretReference) Reset() {
+	*x = SecretReference{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretReference) ProtoMessage() {}
+
+func (x *SecretReference) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretReference.ProtoReflect.Descriptor instead.
+func (*SecretReference) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SecretReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretReference) GetSealedHash() string {
+	if x != nil {
+		return x.SealedHash
+	}
+	return ""
+}
+
+// ReportDataComponents lists the hashes committed into report data.
+// Layout v4: report_data = SHA-256("wasmvm-tee/report-data/v4" ||
+// module_hash || function_hash || inputs_hash || outputs_hash || nonce_hash
+// || transcript_root || secrets_root) || module_hash
+type ReportDataComponents struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                    // Report data layout version
+	ModuleHash     string                 `protobuf:"bytes,2,opt,name=module_hash,json=moduleHash,proto3" json:"module_hash,omitempty"`             // SHA-256 of the bytecode (hex)
+	FunctionHash   string                 `protobuf:"bytes,3,opt,name=function_hash,json=functionHash,proto3" json:"function_hash,omitempty"`       // SHA-256 of the function name (hex)
+	InputsHash     string                 `protobuf:"bytes,4,opt,name=inputs_hash,json=inputsHash,proto3" json:"inputs_hash,omitempty"`             // Hash of the input values (hex)
+	OutputsHash    string                 `protobuf:"bytes,5,opt,name=outputs_hash,json=outputsHash,proto3" json:"outputs_hash,omitempty"`          // Hash of outputs, gas used and limits (hex)
+	NonceHash      string                 `protobuf:"bytes,6,opt,name=nonce_hash,json=nonceHash,proto3" json:"nonce_hash,omitempty"`                // SHA-256 of the execution nonce (hex)
+	TranscriptRoot string                 `protobuf:"bytes,7,opt,name=transcript_root,json=transcriptRoot,proto3" json:"transcript_root,omitempty"` // Merkle root of the HTTP transcript (hex)
+	SecretsRoot    string                 `protobuf:"bytes,8,opt,name=secrets_root,json=secretsRoot,proto3" json:"secrets_root,omitempty"`          // Merkle root of the secret references (hex)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReportDataComponents) Reset() {
+	*x = ReportDataComponents{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportDataComponents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportDataComponents) ProtoMessage() {}
+
+func (x *ReportDataComponents) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportDataComponents.ProtoReflect.Descriptor instead.
+func (*ReportDataComponents) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReportDataComponents) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ReportDataComponents) GetModuleHash() string {
+	if x != nil {
+		return x.ModuleHash
+	}
+	return ""
+}
+
+func (x *ReportDataComponents) GetFunctionHash() string {
+	if x != nil {
+		return x.FunctionHash
+	}
+	return ""
+}
+
+func (x *ReportDataComponents) GetInputsHash() string {
+	if x != nil {
+		return x.InputsHash
+	}
+	return ""
+}
+
+func (x *ReportDataComponents) GetOutputsHash() string {
+	if x != nil {
+		return x.OutputsHash
+	}
+	return ""
+}
+
+func (x *ReportDataComponents) GetNonceHash() string {
+	if x != nil {
+		return x.NonceHash
+	}
+	return ""
+}
+
+func (x *ReportDataComponents) GetTranscriptRoot() string {
+	if x != nil {
+		return x.TranscriptRoot
+	}
+	return ""
+}
+
+func (x *ReportDataComponents) GetSecretsRoot() string {
+	if x != nil {
+		return x.SecretsRoot
+	}
+	return ""
+}
+
+// WASMVMExecutionRequest combines execution parameters and runtime
+// configuration
+type WASMVMExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Execution     *WASMVMExecution       `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"` // Execution parameters (bytecode, inputs)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WASMVMExecutionRequest) Reset() {
+	*x = WASMVMExecutionRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WASMVMExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WASMVMExecutionRequest) ProtoMessage() {}
+
+func (x *WASMVMExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WASMVMExecutionRequest.ProtoReflect.Descriptor instead.
+func (*WASMVMExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WASMVMExecutionRequest) GetExecution() *WASMVMExecution {
+	if x != nil {
+		return x.Execution
+	}
+	return nil
+}
+
+// WASMVMExecutionResponse contains the execution result with request tracking
+type WASMVMExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Request identifier for tracking
+	Result        *WASMVMExecutionResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`                        // Complete execution result
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WASMVMExecutionResponse) Reset() {
+	*x = WASMVMExecutionResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WASMVMExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WASMVMExecutionResponse) ProtoMessage() {}
+
+func (x *WASMVMExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WASMVMExecutionResponse.ProtoReflect.Descriptor instead.
+func (*WASMVMExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WASMVMExecutionResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *WASMVMExecutionResponse) GetResult() *WASMVMExecutionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// WasmModule describes a module stored in the module registry
+type WasmModule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`                             // Hex encoded SHA-256 of the bytecode
+	Size          uint64                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                            // Bytecode size in bytes
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp of the upload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WasmModule) Reset() {
+	*x = WasmModule{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WasmModule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WasmModule) ProtoMessage() {}
+
+func (x *WasmModule) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WasmModule.ProtoReflect.Descriptor instead.
+func (*WasmModule) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WasmModule) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *WasmModule) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WasmModule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// UploadModuleRequest stores bytecode in the module registry
+type UploadModuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bytecode      string                 `protobuf:"bytes,1,opt,name=bytecode,proto3" json:"bytecode,omitempty"`                       // WASMVM bytecode (base64 encoded)
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner proof of the upload, empty = generated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadModuleRequest) Reset() {
+	*x = UploadModuleRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadModuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadModuleRequest) ProtoMessage() {}
+
+func (x *UploadModuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadModuleRequest.ProtoReflect.Descriptor instead.
+func (*UploadModuleRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UploadModuleRequest) GetBytecode() string {
+	if x != nil {
+		return x.Bytecode
+	}
+	return ""
+}
+
+func (x *UploadModuleRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// UploadModuleResponse describes the stored module
+type UploadModuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        *WasmModule            `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`                           // Stored module, addressable by its hash
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token required to delete the upload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadModuleResponse) Reset() {
+	*x = UploadModuleResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadModuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadModuleResponse) ProtoMessage() {}
+
+func (x *UploadModuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadModuleResponse.ProtoReflect.Descriptor instead.
+func (*UploadModuleResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UploadModuleResponse) GetModule() *WasmModule {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+func (x *UploadModuleResponse) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// GetModuleRequest looks up a registered module by hash
+type GetModuleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Hash            string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`                                               // Hex encoded SHA-256 of the bytecode
+	IncludeBytecode bool                   `protobuf:"varint,2,opt,name=include_bytecode,json=includeBytecode,proto3" json:"include_bytecode,omitempty"` // Whether to return the bytecode as well
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetModuleRequest) Reset() {
+	*x = GetModuleRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModuleRequest) ProtoMessage() {}
+
+func (x *GetModuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModuleRequest.ProtoReflect.Descriptor instead.
+func (*GetModuleRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetModuleRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetModuleRequest) GetIncludeBytecode() bool {
+	if x != nil {
+		return x.IncludeBytecode
+	}
+	return false
+}
+
+// GetModuleResponse contains the registered module
+type GetModuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        *WasmModule            `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`     // Module description
+	Bytecode      string                 `protobuf:"bytes,2,opt,name=bytecode,proto3" json:"bytecode,omitempty"` // WASMVM bytecode (base64 encoded), if requested
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModuleResponse) Reset() {
+	*x = GetModuleResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModuleResponse) ProtoMessage() {}
+
+func (x *GetModuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModuleResponse.ProtoReflect.Descriptor instead.
+func (*GetModuleResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetModuleResponse) GetModule() *WasmModule {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+func (x *GetModuleResponse) GetBytecode() string {
+	if x != nil {
+		return x.Bytecode
+	}
+	return ""
+}
+
+// ListModulesRequest lists all registered modules
+type ListModulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModulesRequest) Reset() {
+	*x = ListModulesRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModulesRequest) ProtoMessage() {}
+
+func (x *ListModulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModulesRequest.ProtoReflect.Descriptor instead.
+func (*ListModulesRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{16}
+}
+
+// ListModulesResponse contains all registered modules ordered by hash
+type ListModulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Modules       []*WasmModule          `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty"` // Registered modules
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModulesResponse) Reset() {
+	*x = ListModulesResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModulesResponse) ProtoMessage() {}
+
+func (x *ListModulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModulesResponse.ProtoReflect.Descriptor instead.
+func (*ListModulesResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListModulesResponse) GetModules() []*WasmModule {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// DeleteModuleRequest withdraws an upload of a module, the module is removed
+// from the registry once every upload of it has been withdrawn
+type DeleteModuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`                               // Hex encoded SHA-256 of the bytecode
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token the module was uploaded with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteModuleRequest) Reset() {
+	*x = DeleteModuleRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteModuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteModuleRequest) ProtoMessage() {}
+
+func (x *DeleteModuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteModuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteModuleRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteModuleRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *DeleteModuleRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// DeleteModuleResponse is returned once the upload has been withdrawn
+type DeleteModuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       bool                   `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"` // Whether the module was removed from the registry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteModuleResponse) Reset() {
+	*x = DeleteModuleResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteModuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteModuleResponse) ProtoMessage() {}
+
+func (x *DeleteModuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteModuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteModuleResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteModuleResponse) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+// VerificationPolicy is the platform policy enforced on the attestation
+type VerificationPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Measurement   string                 `protobuf:"bytes,1,opt,name=measurement,proto3" json:"measurement,omitempty"`                  // Expected launch measurement (hex), or empty
+	MinimumTcb    uint64                 `protobuf:"varint,2,opt,name=minimum_tcb,json=minimumTcb,proto3" json:"minimum_tcb,omitempty"` // Minimum reported TCB version
+	AllowDebug    bool                   `protobuf:"varint,3,opt,name=allow_debug,json=allowDebug,proto3" json:"allow_debug,omitempty"` // Accept guests launched with debugging enabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerificationPolicy) Reset() {
+	*x = VerificationPolicy{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerificationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerificationPolicy) ProtoMessage() {}
+
+func (x *VerificationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerificationPolicy.ProtoReflect.Descriptor instead.
+func (*VerificationPolicy) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VerificationPolicy) GetMeasurement() string {
+	if x != nil {
+		return x.Measurement
+	}
+	return ""
+}
+
+func (x *VerificationPolicy) GetMinimumTcb() uint64 {
+	if x != nil {
+		return x.MinimumTcb
+	}
+	return 0
+}
+
+func (x *VerificationPolicy) GetAllowDebug() bool {
+	if x != nil {
+		return x.AllowDebug
+	}
+	return false
+}
+
+// VerifyExecutionRequest checks a result returned by Execute
+type VerifyExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *WASMVMExecutionResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`                           // Result to verify
+	Policy        *VerificationPolicy    `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`                           // Platform policy
+	ModuleHash    string                 `protobuf:"bytes,3,opt,name=module_hash,json=moduleHash,proto3" json:"module_hash,omitempty"` // Expected module SHA-256 (hex), unchecked if empty
+	FnName        string                 `protobuf:"bytes,4,opt,name=fn_name,json=fnName,proto3" json:"fn_name,omitempty"`             // Expected function name, unchecked if empty
+	Nonce         []byte                 `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`                             // Expected execution nonce, unchecked if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyExecutionRequest) Reset() {
+	*x = VerifyExecutionRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyExecutionRequest) ProtoMessage() {}
+
+func (x *VerifyExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyExecutionRequest.ProtoReflect.Descriptor instead.
+func (*VerifyExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerifyExecutionRequest) GetResult() *WASMVMExecutionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *VerifyExecutionRequest) GetPolicy() *VerificationPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *VerifyExecutionRequest) GetModuleHash() string {
+	if x != nil {
+		return x.ModuleHash
+	}
+	return ""
+}
+
+func (x *VerifyExecutionRequest) GetFnName() string {
+	if x != nil {
+		return x.FnName
+	}
+	return ""
+}
+
+func (x *VerifyExecutionRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+// VerifyExecutionResponse reports the verification outcome
+type VerifyExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`                          // Whether every check passed
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                                 // Reason verification failed
+	Measurement   string                 `protobuf:"bytes,3,opt,name=measurement,proto3" json:"measurement,omitempty"`                     // Launch measurement from the report (hex)
+	ReportedTcb   uint64                 `protobuf:"varint,4,opt,name=reported_tcb,json=reportedTcb,proto3" json:"reported_tcb,omitempty"` // Reported TCB version from the report
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyExecutionResponse) Reset() {
+	*x = VerifyExecutionResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyExecutionResponse) ProtoMessage() {}
+
+func (x *VerifyExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyExecutionResponse.ProtoReflect.Descriptor instead.
+func (*VerifyExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyExecutionResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyExecutionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *VerifyExecutionResponse) GetMeasurement() string {
+	if x != nil {
+		return x.Measurement
+	}
+	return ""
+}
+
+func (x *VerifyExecutionResponse) GetReportedTcb() uint64 {
+	if x != nil {
+		return x.ReportedTcb
+	}
+	return 0
+}
+
+// SealedSecret is a secret encrypted to the TEE secret key with
+// X25519-HKDF-SHA256-AES256GCM, see docs/secrets.md
+type SealedSecret struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	EphemeralPublicKey []byte                 `protobuf:"bytes,1,opt,name=ephemeral_public_key,json=ephemeralPublicKey,proto3" json:"ephemeral_public_key,omitempty"` // Sender X25519 public key
+	Nonce              []byte                 `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`                                                       // AES-256-GCM nonce
+	Ciphertext         []byte                 `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`                                             // AES-256-GCM ciphertext and tag
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SealedSecret) Reset() {
+	*x = SealedSecret{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealedSecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedSecret) ProtoMessage() {}
+
+func (x *SealedSecret) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedSecret.ProtoReflect.Descriptor instead.
+func (*SealedSecret) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SealedSecret) GetEphemeralPublicKey() []byte {
+	if x != nil {
+		return x.EphemeralPublicKey
+	}
+	return nil
+}
+
+func (x *SealedSecret) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *SealedSecret) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+// SecretInfo describes a stored secret, never its value
+type SecretInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Reference      *SecretReference       `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`                                 // Name and sealed hash
+	AllowedModules []string               `protobuf:"bytes,2,rep,name=allowed_modules,json=allowedModules,proto3" json:"allowed_modules,omitempty"` // Module hashes that may use it
+	CreatedAt      int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`               // Unix timestamp of the upload
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SecretInfo) Reset() {
+	*x = SecretInfo{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretInfo) ProtoMessage() {}
+
+func (x *SecretInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretInfo.ProtoReflect.Descriptor instead.
+func (*SecretInfo) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SecretInfo) GetReference() *SecretReference {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+func (x *SecretInfo) GetAllowedModules() []string {
+	if x != nil {
+		return x.AllowedModules
+	}
+	return nil
+}
+
+func (x *SecretInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// GetSecretKeyRequest asks for the key secrets are sealed to
+type GetSecretKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nonce         []byte                 `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"` // Client nonce committed into the report data
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretKeyRequest) Reset() {
+	*x = GetSecretKeyRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretKeyRequest) ProtoMessage() {}
+
+func (x *GetSecretKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretKeyRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetSecretKeyRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+// GetSecretKeyResponse holds the TEE secret key with evidence binding it
+type GetSecretKeyResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey           []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                                                              // X25519 public key
+	Algorithm           string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                                                                               // Sealing algorithm
+	Attestation         string                 `protobuf:"bytes,3,opt,name=attestation,proto3" json:"attestation,omitempty"`                                                                           // TEE evidence over report_data
+	AttestationProvider AttestationProvider    `protobuf:"varint,4,opt,name=attestation_provider,json=attestationProvider,proto3,enum=wasm.AttestationProvider" json:"attestation_provider,omitempty"` // Producer of attestation
+	ReportData          string                 `protobuf:"bytes,5,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`                                                           // Key report data (hex), see docs/secrets.md
+	Persistent          bool                   `protobuf:"varint,6,opt,name=persistent,proto3" json:"persistent,omitempty"`                                                                            // Key is derived from the platform sealing key
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetSecretKeyResponse) Reset() {
+	*x = GetSecretKeyResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretKeyResponse) ProtoMessage() {}
+
+func (x *GetSecretKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretKeyResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetSecretKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GetSecretKeyResponse) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *GetSecretKeyResponse) GetAttestation() string {
+	if x != nil {
+		return x.Attestation
+	}
+	return ""
+}
+
+func (x *GetSecretKeyResponse) GetAttestationProvider() AttestationProvider {
+	if x != nil {
+		return x.AttestationProvider
+	}
+	return AttestationProvider_ATTESTATION_PROVIDER_UNSPECIFIED
+}
+
+func (x *GetSecretKeyResponse) GetReportData() string {
+	if x != nil {
+		return x.ReportData
+	}
+	return ""
+}
+
+func (x *GetSecretKeyResponse) GetPersistent() bool {
+	if x != nil {
+		return x.Persistent
+	}
+	return false
+}
+
+// PutSecretRequest stores a sealed secret, replacing any under the same name
+type PutSecretRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                           // Secret name
+	Sealed         *SealedSecret          `protobuf:"bytes,2,opt,name=sealed,proto3" json:"sealed,omitempty"`                                       // Value sealed to the TEE secret key
+	AllowedModules []string               `protobuf:"bytes,3,rep,name=allowed_modules,json=allowedModules,proto3" json:"allowed_modules,omitempty"` // Module hashes that may use it
+	OwnerToken     []byte                 `protobuf:"bytes,4,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"`             // Owner proof, required to replace it
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PutSecretRequest) Reset() {
+	*x = PutSecretRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutSecretRequest) ProtoMessage() {}
+
+func (x *PutSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutSecretRequest.ProtoReflect.Descriptor instead.
+func (*PutSecretRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PutSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PutSecretRequest) GetSealed() *SealedSecret {
+	if x != nil {
+		return x.Sealed
+	}
+	return nil
+}
+
+func (x *PutSecretRequest) GetAllowedModules() []string {
+	if x != nil {
+		return x.AllowedModules
+	}
+	return nil
+}
+
+func (x *PutSecretRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// PutSecretResponse describes the stored secret
+type PutSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        *SecretInfo            `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"` // Stored secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutSecretResponse) Reset() {
+	*x = PutSecretResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutSecretResponse) ProtoMessage() {}
+
+func (x *PutSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutSecretResponse.ProtoReflect.Descriptor instead.
+func (*PutSecretResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PutSecretResponse) GetSecret() *SecretInfo {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+// ListSecretsRequest lists all stored secrets
+type ListSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretsRequest) Reset() {
+	*x = ListSecretsRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretsRequest) ProtoMessage() {}
+
+func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{29}
+}
+
+// ListSecretsResponse contains all stored secrets ordered by name
+type ListSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*SecretInfo          `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"` // Stored secrets
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretsResponse) Reset() {
+	*x = ListSecretsResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretsResponse) ProtoMessage() {}
+
+func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListSecretsResponse) GetSecrets() []*SecretInfo {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+// DeleteSecretRequest removes a stored secret
+type DeleteSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                               // Secret name
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token the secret was stored with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretRequest) Reset() {
+	*x = DeleteSecretRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretRequest) ProtoMessage() {}
+
+func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteSecretRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// DeleteSecretResponse is returned once the secret has been removed
+type DeleteSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretResponse) Reset() {
+	*x = DeleteSecretResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretResponse) ProtoMessage() {}
+
+func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{32}
+}
+
+// GetAttestedKeyRequest asks for the key execution receipts are signed with
+type GetAttestedKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttestedKeyRequest) Reset() {
+	*x = GetAttestedKeyRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttestedKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttestedKeyRequest) ProtoMessage() {}
+
+func (x *GetAttestedKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttestedKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetAttestedKeyRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{33}
+}
+
+// GetAttestedKeyResponse holds the receipt signing key with evidence binding
+// it. Verifying this evidence once lets clients check each execution by its
+// receipt_signature, see docs/canonical-encoding.md
+type GetAttestedKeyResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey           []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                                                              // Compressed secp256k1 public key
+	Algorithm           string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                                                                               // Signature algorithm
+	Attestation         string                 `protobuf:"bytes,3,opt,name=attestation,proto3" json:"attestation,omitempty"`                                                                           // TEE evidence over report_data
+	AttestationProvider AttestationProvider    `protobuf:"varint,4,opt,name=attestation_provider,json=attestationProvider,proto3,enum=wasm.AttestationProvider" json:"attestation_provider,omitempty"` // Producer of attestation
+	ReportData          string                 `protobuf:"bytes,5,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`                                                           // Key report data (hex)
+	CreatedAt           int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                                             // Unix timestamp of the key generation
+	Address             string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`                                                                                   // Ethereum address of the key, signer of EvmResult
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetAttestedKeyResponse) Reset() {
+	*x = GetAttestedKeyResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttestedKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttestedKeyResponse) ProtoMessage() {}
+
+func (x *GetAttestedKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttestedKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetAttestedKeyResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetAttestedKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GetAttestedKeyResponse) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *GetAttestedKeyResponse) GetAttestation() string {
+	if x != nil {
+		return x.Attestation
+	}
+	return ""
+}
+
+func (x *GetAttestedKeyResponse) GetAttestationProvider() AttestationProvider {
+	if x != nil {
+		return x.AttestationProvider
+	}
+	return AttestationProvider_ATTESTATION_PROVIDER_UNSPECIFIED
+}
+
+func (x *GetAttestedKeyResponse) GetReportData() string {
+	if x != nil {
+		return x.ReportData
+	}
+	return ""
+}
+
+func (x *GetAttestedKeyResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *GetAttestedKeyResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// ExecutionJob is an execution submitted with SubmitExecution. Finished
+// jobs are kept for the retention period of the server
+type ExecutionJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                       // Job identifier
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`        // Request id of the execution
+	State         ExecutionState         `protobuf:"varint,3,opt,name=state,proto3,enum=wasm.ExecutionState" json:"state,omitempty"`       // Lifecycle state
+	SubmittedAt   int64                  `protobuf:"varint,4,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"` // Unix timestamp of the submission
+	StartedAt     int64                  `protobuf:"varint,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`       // Unix timestamp execution started
+	FinishedAt    int64                  `protobuf:"varint,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`    // Unix timestamp execution finished
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // Unix timestamp the job is dropped
+	Result        *WASMVMExecutionResult `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`                               // Result, once succeeded
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`                                 // Failure or cancellation reason
+	ErrorCode     int32                  `protobuf:"varint,10,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`      // gRPC status code of error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionJob) Reset() {
+	*x = ExecutionJob{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionJob) ProtoMessage() {}
+
+func (x *ExecutionJob) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionJob.ProtoReflect.Descriptor instead.
+func (*ExecutionJob) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExecutionJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExecutionJob) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ExecutionJob) GetState() ExecutionState {
+	if x != nil {
+		return x.State
+	}
+	return ExecutionState_EXECUTION_STATE_UNSPECIFIED
+}
+
+func (x *ExecutionJob) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *ExecutionJob) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *ExecutionJob) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *ExecutionJob) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ExecutionJob) GetResult() *WASMVMExecutionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ExecutionJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExecutionJob) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+// SubmitExecutionRequest queues an execution and returns without waiting
+type SubmitExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Execution     *WASMVMExecution       `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`                     // Execution parameters
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner proof of the job, empty = generated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitExecutionRequest) Reset() {
+	*x = SubmitExecutionRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitExecutionRequest) ProtoMessage() {}
+
+func (x *SubmitExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitExecutionRequest.ProtoReflect.Descriptor instead.
+func (*SubmitExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SubmitExecutionRequest) GetExecution() *WASMVMExecution {
+	if x != nil {
+		return x.Execution
+	}
+	return nil
+}
+
+func (x *SubmitExecutionRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// SubmitExecutionResponse holds the queued job
+type SubmitExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ExecutionJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`                                 // Job to poll with GetExecution
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token required to get, cancel or list it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitExecutionResponse) Reset() {
+	*x = SubmitExecutionResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitExecutionResponse) ProtoMessage() {}
+
+func (x *SubmitExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitExecutionResponse.ProtoReflect.Descriptor instead.
+func (*SubmitExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubmitExecutionResponse) GetJob() *ExecutionJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *SubmitExecutionResponse) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// GetExecutionRequest looks up a submitted execution
+type GetExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Job identifier
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token the job was submitted with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionRequest) Reset() {
+	*x = GetExecutionRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionRequest) ProtoMessage() {}
+
+func (x *GetExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetExecutionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetExecutionRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// GetExecutionResponse holds the job with its result once finished
+type GetExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ExecutionJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"` // Job
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionResponse) Reset() {
+	*x = GetExecutionResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionResponse) ProtoMessage() {}
+
+func (x *GetExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionResponse.ProtoReflect.Descriptor instead.
+func (*GetExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetExecutionResponse) GetJob() *ExecutionJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// CancelExecutionRequest cancels a queued or running execution
+type CancelExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Job identifier
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token the job was submitted with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelExecutionRequest) Reset() {
+	*x = CancelExecutionRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExecutionRequest) ProtoMessage() {}
+
+func (x *CancelExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExecutionRequest.ProtoReflect.Descriptor instead.
+func (*CancelExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CancelExecutionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelExecutionRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// CancelExecutionResponse holds the job after cancellation. Finished jobs
+// are returned unchanged
+type CancelExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ExecutionJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"` // Job
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelExecutionResponse) Reset() {
+	*x = CancelExecutionResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExecutionResponse) ProtoMessage() {}
+
+func (x *CancelExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExecutionResponse.ProtoReflect.Descriptor instead.
+func (*CancelExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CancelExecutionResponse) GetJob() *ExecutionJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// ListExecutionsRequest lists the executions submitted with an owner token
+type ListExecutionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         ExecutionState         `protobuf:"varint,1,opt,name=state,proto3,enum=wasm.ExecutionState" json:"state,omitempty"`   // Only jobs in this state, unspecified for all
+	OwnerToken    []byte                 `protobuf:"bytes,2,opt,name=owner_token,json=ownerToken,proto3" json:"owner_token,omitempty"` // Owner token the jobs were submitted with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsRequest) Reset() {
+	*x = ListExecutionsRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsRequest) ProtoMessage() {}
+
+func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListExecutionsRequest) GetState() ExecutionState {
+	if x != nil {
+		return x.State
+	}
+	return ExecutionState_EXECUTION_STATE_UNSPECIFIED
+}
+
+func (x *ListExecutionsRequest) GetOwnerToken() []byte {
+	if x != nil {
+		return x.OwnerToken
+	}
+	return nil
+}
+
+// ListExecutionsResponse contains the jobs in submission order, without
+// their results
+type ListExecutionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*ExecutionJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"` // Jobs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsResponse) Reset() {
+	*x = ListExecutionsResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsResponse) ProtoMessage() {}
+
+func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListExecutionsResponse) GetJobs() []*ExecutionJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// BatchInputs is the input set of one item of a batch execution
+type BatchInputs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inputs        []*WasmValue           `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"` // Input parameters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchInputs) Reset() {
+	*x = BatchInputs{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchInputs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchInputs) ProtoMessage() {}
+
+func (x *BatchInputs) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchInputs.ProtoReflect.Descriptor instead.
+func (*BatchInputs) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BatchInputs) GetInputs() []*WasmValue {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+// ExecuteBatchRequest calls one function of one module on many input sets.
+// The execution holds the module, function, limits and nonce shared by all
+// items, its inputs are ignored
+type ExecuteBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Execution     *WASMVMExecution       `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`                           // Shared execution parameters
+	Items         []*BatchInputs         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`                                   // Input set of each item, in order
+	StopOnError   bool                   `protobuf:"varint,3,opt,name=stop_on_error,json=stopOnError,proto3" json:"stop_on_error,omitempty"` // Skip the remaining items after a failure
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteBatchRequest) Reset() {
+	*x = ExecuteBatchRequest{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteBatchRequest) ProtoMessage() {}
+
+func (x *ExecuteBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteBatchRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteBatchRequest) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ExecuteBatchRequest) GetExecution() *WASMVMExecution {
+	if x != nil {
+		return x.Execution
+	}
+	return nil
+}
+
+func (x *ExecuteBatchRequest) GetItems() []*BatchInputs {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ExecuteBatchRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.StopOnError
+	}
+	return false
+}
+
+// BatchItemResult is the outcome of one item of a batch execution
+type BatchItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint64                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                          // Position of the item in the request
+	Result        *WASMVMExecutionResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`                         // Result, once succeeded
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                           // Failure reason, or why it was skipped
+	ErrorCode     int32                  `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // gRPC status code of error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *BatchItemResult) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetResult() *WASMVMExecutionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+// ExecuteBatchResponse holds the outcome of every item. The results of the
+// successful items share one attestation, each with its batch_proof
+type ExecuteBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Unique request identifier
+	Items         []*BatchItemResult     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`                          // Outcome of each item, in order
+	Succeeded     uint64                 `protobuf:"varint,3,opt,name=succeeded,proto3" json:"succeeded,omitempty"`                 // Number of successful items
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteBatchResponse) Reset() {
+	*x = ExecuteBatchResponse{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteBatchResponse) ProtoMessage() {}
+
+func (x *ExecuteBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteBatchResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteBatchResponse) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ExecuteBatchResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ExecuteBatchResponse) GetItems() []*BatchItemResult {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ExecuteBatchResponse) GetSucceeded() uint64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+// ModuleLoaded reports that the module was loaded, validated and, in AOT
+// mode, compiled
+type ModuleLoaded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleHash    string                 `protobuf:"bytes,1,opt,name=module_hash,json=moduleHash,proto3" json:"module_hash,omitempty"`                                   // SHA-256 of the bytecode (hex)
+	SizeBytes     uint64                 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                                     // Size of the bytecode
+	ExecutionMode ExecutionMode          `protobuf:"varint,3,opt,name=execution_mode,json=executionMode,proto3,enum=wasm.ExecutionMode" json:"execution_mode,omitempty"` // Interpreter or AOT-compiled execution
+	LoadMs        uint64                 `protobuf:"varint,4,opt,name=load_ms,json=loadMs,proto3" json:"load_ms,omitempty"`                                              // Time taken to load the module
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleLoaded) Reset() {
+	*x = ModuleLoaded{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleLoaded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleLoaded) ProtoMessage() {}
+
+func (x *ModuleLoaded) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleLoaded.ProtoReflect.Descriptor instead.
+func (*ModuleLoaded) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ModuleLoaded) GetModuleHash() string {
+	if x != nil {
+		return x.ModuleHash
+	}
+	return ""
+}
+
+func (x *ModuleLoaded) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ModuleLoaded) GetExecutionMode() ExecutionMode {
+	if x != nil {
+		return x.ExecutionMode
+	}
+	return ExecutionMode_EXECUTION_MODE_UNSPECIFIED
+}
+
+func (x *ModuleLoaded) GetLoadMs() uint64 {
+	if x != nil {
+		return x.LoadMs
+	}
+	return 0
+}
+
+// HttpCallStarted reports a fetch or http host call being sent
+type HttpCallStarted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`  // Position of the call in the execution
+	Call          string                 `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`     // Host function, fetch or http
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"` // Request method
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`       // Request URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpCallStarted) Reset() {
+	*x = HttpCallStarted{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpCallStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpCallStarted) ProtoMessage() {}
+
+func (x *HttpCallStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpCallStarted.ProtoReflect.Descriptor instead.
+func (*HttpCallStarted) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *HttpCallStarted) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *HttpCallStarted) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *HttpCallStarted) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HttpCallStarted) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// HttpCallFinished reports the outcome of a fetch or http host call
+type HttpCallFinished struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                             // Position of the call in the execution
+	Call          string                 `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`                                // Host function, fetch or http
+	Exchange      *HttpExchange          `protobuf:"bytes,3,opt,name=exchange,proto3" json:"exchange,omitempty"`                        // Exchange recorded, unset when none was sent
+	DurationMs    uint64                 `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // Time taken by the call
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpCallFinished) Reset() {
+	*x = HttpCallFinished{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpCallFinished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpCallFinished) ProtoMessage() {}
+
+func (x *HttpCallFinished) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpCallFinished.ProtoReflect.Descriptor instead.
+func (*HttpCallFinished) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *HttpCallFinished) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *HttpCallFinished) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *HttpCallFinished) GetExchange() *HttpExchange {
+	if x != nil {
+		return x.Exchange
+	}
+	return nil
+}
+
+func (x *HttpCallFinished) GetDurationMs() uint64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// GuestLog is a line the guest wrote with the log host function. Log lines
+// are not attested
+type GuestLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`      // Log line
+	Truncated     bool                   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // Whether the line was cut to the maximum length
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuestLog) Reset() {
+	*x = GuestLog{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuestLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestLog) ProtoMessage() {}
+
+func (x *GuestLog) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestLog.ProtoReflect.Descriptor instead.
+func (*GuestLog) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GuestLog) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GuestLog) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// GasCheckpoint reports the gas consumed by a running execution, taken when
+// the guest calls fetch, http or log at most once per second
+type GasCheckpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GasUsed       uint64                 `protobuf:"varint,1,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`       // Gas consumed so far
+	GasLimit      uint64                 `protobuf:"varint,2,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`    // Gas budget, 0 = unlimited
+	ElapsedMs     uint64                 `protobuf:"varint,3,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"` // Time since the function was called
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GasCheckpoint) Reset() {
+	*x = GasCheckpoint{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GasCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GasCheckpoint) ProtoMessage() {}
+
+func (x *GasCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GasCheckpoint.ProtoReflect.Descriptor instead.
+func (*GasCheckpoint) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GasCheckpoint) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *GasCheckpoint) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *GasCheckpoint) GetElapsedMs() uint64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+// ExecutionEvent is a progress event of ExecuteStream. The stream ends with
+// the attested result, or with the gRPC status of the failure
+type ExecutionEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Sequence    uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`                          // Position of the event in the stream
+	TimestampMs int64                  `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // Unix time of the event in milliseconds
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ExecutionEvent_ModuleLoaded
+	//	*ExecutionEvent_HttpCallStarted
+	//	*ExecutionEvent_HttpCallFinished
+	//	*ExecutionEvent_Log
+	//	*ExecutionEvent_GasCheckpoint
+	//	*ExecutionEvent_Result
+	Event         isExecutionEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionEvent) Reset() {
+	*x = ExecutionEvent{}
+	mi := &file_wasm_wasm_server_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionEvent) ProtoMessage() {}
+
+func (x *ExecutionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_wasm_wasm_server_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionEvent.ProtoReflect.Descriptor instead.
+func (*ExecutionEvent) Descriptor() ([]byte, []int) {
+	return file_wasm_wasm_server_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ExecutionEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ExecutionEvent) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *ExecutionEvent) GetEvent() isExecutionEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ExecutionEvent) GetModuleLoaded() *ModuleLoaded {
+	if x != nil {
+		if x, ok := x.Event.(*ExecutionEvent_ModuleLoaded); ok {
+			return x.ModuleLoaded
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionEvent) GetHttpCallStarted() *HttpCallStarted {
+	if x != nil {
+		if x, ok := x.Event.(*ExecutionEvent_HttpCallStarted); ok {
+			return x.HttpCallStarted
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionEvent) GetHttpCallFinished() *HttpCallFinished {
+	if x != nil {
+		if x, ok := x.Event.(*ExecutionEvent_HttpCallFinished); ok {
+			return x.HttpCallFinished
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionEvent) GetLog() *GuestLog {
+	if x != nil {
+		if x, ok := x.Event.(*ExecutionEvent_Log); ok {
+			return x.Log
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionEvent) GetGasCheckpoint() *GasCheckpoint {
+	if x != nil {
+		if x, ok := x.Event.(*ExecutionEvent_GasCheckpoint); ok {
+			return x.GasCheckpoint
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionEvent) GetResult() *WASMVMExecutionResult {
+	if x != nil {
+		if x, ok := x.Event.(*ExecutionEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isExecutionEvent_Event interface {
+	isExecutionEvent_Event()
+}
+
+type ExecutionEvent_ModuleLoaded struct {
+	ModuleLoaded *ModuleLoaded `protobuf:"bytes,3,opt,name=module_loaded,json=moduleLoaded,proto3,oneof"` // Module ready to execute
+}
+
+type ExecutionEvent_HttpCallStarted struct {
+	HttpCallStarted *HttpCallStarted `protobuf:"bytes,4,opt,name=http_call_started,json=httpCallStarted,proto3,oneof"` // Host HTTP call sent
+}
+
+type ExecutionEvent_HttpCallFinished struct {
+	HttpCallFinished *HttpCallFinished `protobuf:"bytes,5,opt,name=http_call_finished,json=httpCallFinished,proto3,oneof"` // Host HTTP call done
+}
+
+type ExecutionEvent_Log struct {
+	Log *GuestLog `protobuf:"bytes,6,opt,name=log,proto3,oneof"` // Guest log line
+}
+
+type ExecutionEvent_GasCheckpoint struct {
+	GasCheckpoint *GasCheckpoint `protobuf:"bytes,7,opt,name=gas_checkpoint,json=gasCheckpoint,proto3,oneof"` // Gas used so far
+}
+
+type ExecutionEvent_Result struct {
+	Result *WASMVMExecutionResult `protobuf:"bytes,8,opt,name=result,proto3,oneof"` // Final attested result
+}
+
+func (*ExecutionEvent_ModuleLoaded) isExecutionEvent_Event() {}
+
+func (*ExecutionEvent_HttpCallStarted) isExecutionEvent_Event() {}
+
+func (*ExecutionEvent_HttpCallFinished) isExecutionEvent_Event() {}
+
+func (*ExecutionEvent_Log) isExecutionEvent_Event() {}
+
+func (*ExecutionEvent_GasCheckpoint) isExecutionEvent_Event() {}
+
+func (*ExecutionEvent_Result) isExecutionEvent_Event() {}
+
+var File_wasm_wasm_server_proto protoreflect.FileDescriptor
+
+const file_wasm_wasm_server_proto_rawDesc = "" +
+	"\n" +
+	"\x16wasm/wasm_server.proto\x12\x04wasm\x1a\x1cgoogle/api/annotations.proto\x1a\x15wasm/wasm_input.proto\"\xcc\x03\n" +
+	"\x0fWASMVMExecution\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bbytecode\x18\x03 \x01(\tR\bbytecode\x12\x17\n" +
+	"\afn_name\x18\x04 \x01(\tR\x06fnName\x12'\n" +
+	"\x06inputs\x18\x05 \x03(\v2\x0f.wasm.WasmValueR\x06inputs\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x120\n" +
+	"\x14is_force_interpreter\x18\a \x01(\bR\x12isForceInterpreter\x12\x1b\n" +
+	"\tgas_limit\x18\b \x01(\x04R\bgasLimit\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\t \x01(\x04R\ttimeoutMs\x12(\n" +
+	"\x10max_memory_pages\x18\n" +
+	" \x01(\rR\x0emaxMemoryPages\x12\x1f\n" +
+	"\vmodule_hash\x18\v \x01(\tR\n" +
+	"moduleHash\x12\x14\n" +
+	"\x05nonce\x18\f \x01(\fR\x05nonce\x125\n" +
+	"\n" +
+	"evm_output\x18\r \x01(\v2\x16.wasm.EvmOutputOptionsR\tevmOutput\"\xb1\x01\n" +
+	"\x10EvmOutputOptions\x12!\n" +
+	"\foutput_types\x18\x01 \x03(\tR\voutputTypes\x120\n" +
+	"\x06scheme\x18\x02 \x01(\x0e2\x18.wasm.EvmSignatureSchemeR\x06scheme\x12\x19\n" +
+	"\bchain_id\x18\x03 \x01(\x04R\achainId\x12-\n" +
+	"\x12verifying_contract\x18\x04 \x01(\tR\x11verifyingContract\"\xcb\x02\n" +
+	"\tEvmResult\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12!\n" +
+	"\foutput_types\x18\x02 \x03(\tR\voutputTypes\x12\x18\n" +
+	"\aoutputs\x18\x03 \x01(\tR\aoutputs\x12\x18\n" +
+	"\aencoded\x18\x04 \x01(\tR\aencoded\x120\n" +
+	"\x06scheme\x18\x05 \x01(\x0e2\x18.wasm.EvmSignatureSchemeR\x06scheme\x12\x19\n" +
+	"\bchain_id\x18\x06 \x01(\x04R\achainId\x12-\n" +
+	"\x12verifying_contract\x18\a \x01(\tR\x11verifyingContract\x12\x16\n" +
+	"\x06digest\x18\b \x01(\tR\x06digest\x12\x1c\n" +
+	"\tsignature\x18\t \x01(\tR\tsignature\x12\x16\n" +
+	"\x06signer\x18\n" +
+	" \x01(\tR\x06signer\"w\n" +
+	"\x0fExecutionLimits\x12\x1b\n" +
+	"\tgas_limit\x18\x01 \x01(\x04R\bgasLimit\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x02 \x01(\x04R\ttimeoutMs\x12(\n" +
+	"\x10max_memory_pages\x18\x03 \x01(\rR\x0emaxMemoryPages\"\x9e\x06\n" +
+	"\x15WASMVMExecutionResult\x12'\n" +
+	"\x06inputs\x18\x01 \x03(\v2\x0f.wasm.WasmValueR\x06inputs\x124\n" +
+	"\routput_values\x18\x03 \x03(\v2\x0f.wasm.WasmValueR\foutputValues\x12 \n" +
+	"\vattestation\x18\x05 \x01(\tR\vattestation\x12\x1f\n" +
+	"\vreport_data\x18\x06 \x01(\tR\n" +
+	"reportData\x12\x19\n" +
+	"\bgas_used\x18\a \x01(\x04R\agasUsed\x12-\n" +
+	"\x06limits\x18\b \x01(\v2\x15.wasm.ExecutionLimitsR\x06limits\x12:\n" +
+	"\x0eexecution_mode\x18\t \x01(\x0e2\x13.wasm.ExecutionModeR\rexecutionMode\x12P\n" +
+	"\x16report_data_components\x18\n" +
+	" \x01(\v2\x1a.wasm.ReportDataComponentsR\x14reportDataComponents\x12L\n" +
+	"\x14attestation_provider\x18\v \x01(\x0e2\x19.wasm.AttestationProviderR\x13attestationProvider\x12;\n" +
+	"\x0fhttp_transcript\x18\f \x03(\v2\x12.wasm.HttpExchangeR\x0ehttpTranscript\x12B\n" +
+	"\x11secret_references\x18\r \x03(\v2\x15.wasm.SecretReferenceR\x10secretReferences\x12+\n" +
+	"\x11receipt_signature\x18\x0e \x01(\tR\x10receiptSignature\x12,\n" +
+	"\x12receipt_public_key\x18\x0f \x01(\tR\x10receiptPublicKey\x12.\n" +
+	"\n" +
+	"evm_result\x18\x10 \x01(\v2\x0f.wasm.EvmResultR\tevmResult\x121\n" +
+	"\vbatch_proof\x18\x11 \x01(\v2\x10.wasm.BatchProofR\n" +
+	"batchProof\"t\n" +
+	"\n" +
+	"BatchProof\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\x12\x1d\n" +
+	"\n" +
+	"leaf_index\x18\x02 \x01(\x04R\tleafIndex\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x04R\tbatchSize\x12\x14\n" +
+	"\x05proof\x18\x04 \x03(\tR\x05proof\"\xb5\x02\n" +
+	"\fHttpExchange\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vstatus_code\x18\x03 \x01(\x05R\n" +
+	"statusCode\x129\n" +
+	"\aheaders\x18\x04 \x03(\v2\x1f.wasm.HttpExchange.HeadersEntryR\aheaders\x12\x1b\n" +
+	"\tbody_hash\x18\x05 \x01(\tR\bbodyHash\x120\n" +
+	"\x14tls_cert_fingerprint\x18\x06 \x01(\tR\x12tlsCertFingerprint\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"F\n" +
+	"\x0fSecretReference\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsealed_hash\x18\x02 \x01(\tR\n" +
+	"sealedHash\"\xa5\x02\n" +
+	"\x14ReportDataComponents\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12\x1f\n" +
+	"\vmodule_hash\x18\x02 \x01(\tR\n" +
+	"moduleHash\x12#\n" +
+	"\rfunction_hash\x18\x03 \x01(\tR\ffunctionHash\x12\x1f\n" +
+	"\vinputs_hash\x18\x04 \x01(\tR\n" +
+	"inputsHash\x12!\n" +
+	"\foutputs_hash\x18\x05 \x01(\tR\voutputsHash\x12\x1d\n" +
+	"\n" +
+	"nonce_hash\x18\x06 \x01(\tR\tnonceHash\x12'\n" +
+	"\x0ftranscript_root\x18\a \x01(\tR\x0etranscriptRoot\x12!\n" +
+	"\fsecrets_root\x18\b \x01(\tR\vsecretsRoot\"M\n" +
+	"\x16WASMVMExecutionRequest\x123\n" +
+	"\texecution\x18\x01 \x01(\v2\x15.wasm.WASMVMExecutionR\texecution\"m\n" +
+	"\x17WASMVMExecutionResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x123\n" +
+	"\x06result\x18\x02 \x01(\v2\x1b.wasm.WASMVMExecutionResultR\x06result\"S\n" +
+	"\n" +
+	"WasmModule\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x04R\x04size\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"R\n" +
+	"\x13UploadModuleRequest\x12\x1a\n" +
+	"\bbytecode\x18\x01 \x01(\tR\bbytecode\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"a\n" +
+	"\x14UploadModuleResponse\x12(\n" +
+	"\x06module\x18\x01 \x01(\v2\x10.wasm.WasmModuleR\x06module\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"Q\n" +
+	"\x10GetModuleRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12)\n" +
+	"\x10include_bytecode\x18\x02 \x01(\bR\x0fincludeBytecode\"Y\n" +
+	"\x11GetModuleResponse\x12(\n" +
+	"\x06module\x18\x01 \x01(\v2\x10.wasm.WasmModuleR\x06module\x12\x1a\n" +
+	"\bbytecode\x18\x02 \x01(\tR\bbytecode\"\x14\n" +
+	"\x12ListModulesRequest\"A\n" +
+	"\x13ListModulesResponse\x12*\n" +
+	"\amodules\x18\x01 \x03(\v2\x10.wasm.WasmModuleR\amodules\"J\n" +
+	"\x13DeleteModuleRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"0\n" +
+	"\x14DeleteModuleResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\bR\aremoved\"x\n" +
+	"\x12VerificationPolicy\x12 \n" +
+	"\vmeasurement\x18\x01 \x01(\tR\vmeasurement\x12\x1f\n" +
+	"\vminimum_tcb\x18\x02 \x01(\x04R\n" +
+	"minimumTcb\x12\x1f\n" +
+	"\vallow_debug\x18\x03 \x01(\bR\n" +
+	"allowDebug\"\xcf\x01\n" +
+	"\x16VerifyExecutionRequest\x123\n" +
+	"\x06result\x18\x01 \x01(\v2\x1b.wasm.WASMVMExecutionResultR\x06result\x120\n" +
+	"\x06policy\x18\x02 \x01(\v2\x18.wasm.VerificationPolicyR\x06policy\x12\x1f\n" +
+	"\vmodule_hash\x18\x03 \x01(\tR\n" +
+	"moduleHash\x12\x17\n" +
+	"\afn_name\x18\x04 \x01(\tR\x06fnName\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\fR\x05nonce\"\x90\x01\n" +
+	"\x17VerifyExecutionResponse\x12\x1a\n" +
+	"\bverified\x18\x01 \x01(\bR\bverified\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12 \n" +
+	"\vmeasurement\x18\x03 \x01(\tR\vmeasurement\x12!\n" +
+	"\freported_tcb\x18\x04 \x01(\x04R\vreportedTcb\"v\n" +
+	"\fSealedSecret\x120\n" +
+	"\x14ephemeral_public_key\x18\x01 \x01(\fR\x12ephemeralPublicKey\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\fR\x05nonce\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x03 \x01(\fR\n" +
+	"ciphertext\"\x89\x01\n" +
+	"\n" +
+	"SecretInfo\x123\n" +
+	"\treference\x18\x01 \x01(\v2\x15.wasm.SecretReferenceR\treference\x12'\n" +
+	"\x0fallowed_modules\x18\x02 \x03(\tR\x0eallowedModules\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"+\n" +
+	"\x13GetSecretKeyRequest\x12\x14\n" +
+	"\x05nonce\x18\x01 \x01(\fR\x05nonce\"\x84\x02\n" +
+	"\x14GetSecretKeyResponse\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12 \n" +
+	"\vattestation\x18\x03 \x01(\tR\vattestation\x12L\n" +
+	"\x14attestation_provider\x18\x04 \x01(\x0e2\x19.wasm.AttestationProviderR\x13attestationProvider\x12\x1f\n" +
+	"\vreport_data\x18\x05 \x01(\tR\n" +
+	"reportData\x12\x1e\n" +
+	"\n" +
+	"persistent\x18\x06 \x01(\bR\n" +
+	"persistent\"\x9c\x01\n" +
+	"\x10PutSecretRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x06sealed\x18\x02 \x01(\v2\x12.wasm.SealedSecretR\x06sealed\x12'\n" +
+	"\x0fallowed_modules\x18\x03 \x03(\tR\x0eallowedModules\x12\x1f\n" +
+	"\vowner_token\x18\x04 \x01(\fR\n" +
+	"ownerToken\"=\n" +
+	"\x11PutSecretResponse\x12(\n" +
+	"\x06secret\x18\x01 \x01(\v2\x10.wasm.SecretInfoR\x06secret\"\x14\n" +
+	"\x12ListSecretsRequest\"A\n" +
+	"\x13ListSecretsResponse\x12*\n" +
+	"\asecrets\x18\x01 \x03(\v2\x10.wasm.SecretInfoR\asecrets\"J\n" +
+	"\x13DeleteSecretRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"\x16\n" +
+	"\x14DeleteSecretResponse\"\x17\n" +
+	"\x15GetAttestedKeyRequest\"\x9f\x02\n" +
+	"\x16GetAttestedKeyResponse\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12 \n" +
+	"\vattestation\x18\x03 \x01(\tR\vattestation\x12L\n" +
+	"\x14attestation_provider\x18\x04 \x01(\x0e2\x19.wasm.AttestationProviderR\x13attestationProvider\x12\x1f\n" +
+	"\vreport_data\x18\x05 \x01(\tR\n" +
+	"reportData\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\"\xd5\x02\n" +
+	"\fExecutionJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12*\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x14.wasm.ExecutionStateR\x05state\x12!\n" +
+	"\fsubmitted_at\x18\x04 \x01(\x03R\vsubmittedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\x03R\n" +
+	"finishedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x123\n" +
+	"\x06result\x18\b \x01(\v2\x1b.wasm.WASMVMExecutionResultR\x06result\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\n" +
+	" \x01(\x05R\terrorCode\"n\n" +
+	"\x16SubmitExecutionRequest\x123\n" +
+	"\texecution\x18\x01 \x01(\v2\x15.wasm.WASMVMExecutionR\texecution\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"`\n" +
+	"\x17SubmitExecutionResponse\x12$\n" +
+	"\x03job\x18\x01 \x01(\v2\x12.wasm.ExecutionJobR\x03job\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"F\n" +
+	"\x13GetExecutionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"<\n" +
+	"\x14GetExecutionResponse\x12$\n" +
+	"\x03job\x18\x01 \x01(\v2\x12.wasm.ExecutionJobR\x03job\"I\n" +
+	"\x16CancelExecutionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"?\n" +
+	"\x17CancelExecutionResponse\x12$\n" +
+	"\x03job\x18\x01 \x01(\v2\x12.wasm.ExecutionJobR\x03job\"d\n" +
+	"\x15ListExecutionsRequest\x12*\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x14.wasm.ExecutionStateR\x05state\x12\x1f\n" +
+	"\vowner_token\x18\x02 \x01(\fR\n" +
+	"ownerToken\"@\n" +
+	"\x16ListExecutionsResponse\x12&\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x12.wasm.ExecutionJobR\x04jobs\"6\n" +
+	"\vBatchInputs\x12'\n" +
+	"\x06inputs\x18\x01 \x03(\v2\x0f.wasm.WasmValueR\x06inputs\"\x97\x01\n" +
+	"\x13ExecuteBatchRequest\x123\n" +
+	"\texecution\x18\x01 \x01(\v2\x15.wasm.WASMVMExecutionR\texecution\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.wasm.BatchInputsR\x05items\x12\"\n" +
+	"\rstop_on_error\x18\x03 \x01(\bR\vstopOnError\"\x91\x01\n" +
+	"\x0fBatchItemResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x04R\x05index\x123\n" +
+	"\x06result\x18\x02 \x01(\v2\x1b.wasm.WASMVMExecutionResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\x05R\terrorCode\"\x80\x01\n" +
+	"\x14ExecuteBatchResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12+\n" +
+	"\x05items\x18\x02 \x03(\v2\x15.wasm.BatchItemResultR\x05items\x12\x1c\n" +
+	"\tsucceeded\x18\x03 \x01(\x04R\tsucceeded\"\xa3\x01\n" +
+	"\fModuleLoaded\x12\x1f\n" +
+	"\vmodule_hash\x18\x01 \x01(\tR\n" +
+	"moduleHash\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x04R\tsizeBytes\x12:\n" +
+	"\x0eexecution_mode\x18\x03 \x01(\x0e2\x13.wasm.ExecutionModeR\rexecutionMode\x12\x17\n" +
+	"\aload_ms\x18\x04 \x01(\x04R\x06loadMs\"e\n" +
+	"\x0fHttpCallStarted\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x12\n" +
+	"\x04call\x18\x02 \x01(\tR\x04call\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\"\x8d\x01\n" +
+	"\x10HttpCallFinished\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x12\n" +
+	"\x04call\x18\x02 \x01(\tR\x04call\x12.\n" +
+	"\bexchange\x18\x03 \x01(\v2\x12.wasm.HttpExchangeR\bexchange\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x04R\n" +
+	"durationMs\"B\n" +
+	"\bGuestLog\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"f\n" +
+	"\rGasCheckpoint\x12\x19\n" +
+	"\bgas_used\x18\x01 \x01(\x04R\agasUsed\x12\x1b\n" +
+	"\tgas_limit\x18\x02 \x01(\x04R\bgasLimit\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x03 \x01(\x04R\telapsedMs\"\xb9\x03\n" +
+	"\x0eExecutionEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12!\n" +
+	"\ftimestamp_ms\x18\x02 \x01(\x03R\vtimestampMs\x129\n" +
+	"\rmodule_loaded\x18\x03 \x01(\v2\x12.wasm.ModuleLoadedH\x00R\fmoduleLoaded\x12C\n" +
+	"\x11http_call_started\x18\x04 \x01(\v2\x15.wasm.HttpCallStartedH\x00R\x0fhttpCallStarted\x12F\n" +
+	"\x12http_call_finished\x18\x05 \x01(\v2\x16.wasm.HttpCallFinishedH\x00R\x10httpCallFinished\x12\"\n" +
+	"\x03log\x18\x06 \x01(\v2\x0e.wasm.GuestLogH\x00R\x03log\x12<\n" +
+	"\x0egas_checkpoint\x18\a \x01(\v2\x13.wasm.GasCheckpointH\x00R\rgasCheckpoint\x125\n" +
+	"\x06result\x18\b \x01(\v2\x1b.wasm.WASMVMExecutionResultH\x00R\x06resultB\a\n" +
+	"\x05event*|\n" +
+	"\x12EvmSignatureScheme\x12$\n" +
+	" EVM_SIGNATURE_SCHEME_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bEVM_SIGNATURE_SCHEME_EIP191\x10\x01\x12\x1f\n" +
+	"\x1bEVM_SIGNATURE_SCHEME_EIP712\x10\x02*g\n" +
+	"\rExecutionMode\x12\x1e\n" +
+	"\x1aEXECUTION_MODE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aEXECUTION_MODE_INTERPRETER\x10\x01\x12\x16\n" +
+	"\x12EXECUTION_MODE_AOT\x10\x02*\x9a\x01\n" +
+	"\x13AttestationProvider\x12$\n" +
+	" ATTESTATION_PROVIDER_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cATTESTATION_PROVIDER_SEV_SNP\x10\x01\x12\x1c\n" +
+	"\x18ATTESTATION_PROVIDER_TDX\x10\x02\x12\x1d\n" +
+	"\x19ATTESTATION_PROVIDER_MOCK\x10\x03*\xc4\x01\n" +
+	"\x0eExecutionState\x12\x1f\n" +
+	"\x1bEXECUTION_STATE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16EXECUTION_STATE_QUEUED\x10\x01\x12\x1b\n" +
+	"\x17EXECUTION_STATE_RUNNING\x10\x02\x12\x1d\n" +
+	"\x19EXECUTION_STATE_SUCCEEDED\x10\x03\x12\x1a\n" +
+	"\x16EXECUTION_STATE_FAILED\x10\x04\x12\x1d\n" +
+	"\x19EXECUTION_STATE_CANCELLED\x10\x052\xfb\r\n" +
+	"\x10WASMVMTeeService\x12c\n" +
+	"\aExecute\x12\x1c.wasm.WASMVMExecutionRequest\x1a\x1d.wasm.WASMVMExecutionResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/dtvm/execute\x12i\n" +
+	"\rExecuteStream\x12\x1c.wasm.WASMVMExecutionRequest\x1a\x14.wasm.ExecutionEvent\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/dtvm/execute-stream0\x01\x12h\n" +
+	"\fExecuteBatch\x12\x19.wasm.ExecuteBatchRequest\x1a\x1a.wasm.ExecuteBatchResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/dtvm/execute-batch\x12j\n" +
+	"\x0fVerifyExecution\x12\x1c.wasm.VerifyExecutionRequest\x1a\x1d.wasm.VerifyExecutionResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/dtvm/verify\x12b\n" +
+	"\fUploadModule\x12\x19.wasm.UploadModuleRequest\x1a\x1a.wasm.UploadModuleResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/dtvm/modules\x12]\n" +
+	"\tGetModule\x12\x16.wasm.GetModuleRequest\x1a\x17.wasm.GetModuleResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/dtvm/modules/{hash}\x12\\\n" +
+	"\vListModules\x12\x18.wasm.ListModulesRequest\x1a\x19.wasm.ListModulesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/dtvm/modules\x12i\n" +
+	"\fDeleteModule\x12\x19.wasm.DeleteModuleRequest\x1a\x1a.wasm.DeleteModuleResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01**\x17/v1/dtvm/modules/{hash}\x12f\n" +
+	"\fGetSecretKey\x12\x19.wasm.GetSecretKeyRequest\x1a\x1a.wasm.GetSecretKeyResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/dtvm/secrets/key\x12Y\n" +
+	"\tPutSecret\x12\x16.wasm.PutSecretRequest\x1a\x17.wasm.PutSecretResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/dtvm/secrets\x12\\\n" +
+	"\vListSecrets\x12\x18.wasm.ListSecretsRequest\x1a\x19.wasm.ListSecretsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/dtvm/secrets\x12i\n" +
+	"\fDeleteSecret\x12\x19.wasm.DeleteSecretRequest\x1a\x1a.wasm.DeleteSecretResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01**\x17/v1/dtvm/secrets/{name}\x12j\n" +
+	"\x0eGetAttestedKey\x12\x1b.wasm.GetAttestedKeyRequest\x1a\x1c.wasm.GetAttestedKeyResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/dtvm/attested-key\x12n\n" +
+	"\x0fSubmitExecution\x12\x1c.wasm.SubmitExecutionRequest\x1a\x1d.wasm.SubmitExecutionResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/dtvm/executions\x12g\n" +
+	"\fGetExecution\x12\x19.wasm.GetExecutionRequest\x1a\x1a.wasm.GetExecutionResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/dtvm/executions/{id}\x12z\n" +
+	"\x0fCancelExecution\x12\x1c.wasm.CancelExecutionRequest\x1a\x1d.wasm.CancelExecutionResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/dtvm/executions/{id}/cancel\x12h\n" +
+	"\x0eListExecutions\x12\x1b.wasm.ListExecutionsRequest\x1a\x1c.wasm.ListExecutionsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/dtvm/executionsB/Z-github.com/IntelliXLabs/wasmvm-tee/wasm/typesb\x06proto3"
+
+var (
 	file_wasm_wasm_server_proto_rawDescOnce sync.Once
 	file_wasm_wasm_server_proto_rawDescData []byte
 )
@@ -326,27 +4143,159 @@ func file_wasm_wasm_server_proto_rawDescGZIP() []byte {
 	return file_wasm_wasm_server_proto_rawDescData
 }
 
-var file_wasm_wasm_server_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_wasm_wasm_server_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_wasm_wasm_server_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_wasm_wasm_server_proto_goTypes = []any{
-	(*WASMVMExecution)(nil),         // 0: wasm.WASMVMExecution
-	(*WASMVMExecutionResult)(nil),   // 1: wasm.WASMVMExecutionResult
-	(*WASMVMExecutionRequest)(nil),  // 2: wasm.WASMVMExecutionRequest
-	(*WASMVMExecutionResponse)(nil), // 3: wasm.WASMVMExecutionResponse
-	(*WasmValue)(nil),               // 4: wasm.WasmValue
+	(EvmSignatureScheme)(0),         // 0: wasm.EvmSignatureScheme
+	(ExecutionMode)(0),              // 1: wasm.ExecutionMode
+	(AttestationProvider)(0),        // 2: wasm.AttestationProvider
+	(ExecutionState)(0),             // 3: wasm.ExecutionState
+	(*WASMVMExecution)(nil),         // 4: wasm.WASMVMExecution
+	(*EvmOutputOptions)(nil),        // 5: wasm.EvmOutputOptions
+	(*EvmResult)(nil),               // 6: wasm.EvmResult
+	(*ExecutionLimits)(nil),         // 7: wasm.ExecutionLimits
+	(*WASMVMExecutionResult)(nil),   // 8: wasm.WASMVMExecutionResult
+	(*BatchProof)(nil),              // 9: wasm.BatchProof
+	(*HttpExchange)(nil),            // 10: wasm.HttpExchange
+	(*SecretReference)(nil),         // 11: wasm.SecretReference
+	(*ReportDataComponents)(nil),    // 12: wasm.ReportDataComponents
+	(*WASMVMExecutionRequest)(nil),  // 13: wasm.WASMVMExecutionRequest
+	(*WASMVMExecutionResponse)(nil), // 14: wasm.WASMVMExecutionResponse
+	(*WasmModule)(nil),              // 15: wasm.WasmModule
+	(*UploadModuleRequest)(nil),     // 16: wasm.UploadModuleRequest
+	(*UploadModuleResponse)(nil),    // 17: wasm.UploadModuleResponse
+	(*GetModuleRequest)(nil),        // 18: wasm.GetModuleRequest
+	(*GetModuleResponse)(nil),       // 19: wasm.GetModuleResponse
+	(*ListModulesRequest)(nil),      // 20: wasm.ListModulesRequest
+	(*ListModulesResponse)(nil),     // 21: wasm.ListModulesResponse
+	(*DeleteModuleRequest)(nil),     // 22: wasm.DeleteModuleRequest
+	(*DeleteModuleResponse)(nil),    // 23: wasm.DeleteModuleResponse
+	(*VerificationPolicy)(nil),      // 24: wasm.VerificationPolicy
+	(*VerifyExecutionRequest)(nil),  // 25: wasm.VerifyExecutionRequest
+	(*VerifyExecutionResponse)(nil), // 26: wasm.VerifyExecutionResponse
+	(*SealedSecret)(nil),            // 27: wasm.SealedSecret
+	(*SecretInfo)(nil),              // 28: wasm.SecretInfo
+	(*GetSecretKeyRequest)(nil),     // 29: wasm.GetSecretKeyRequest
+	(*GetSecretKeyResponse)(nil),    // 30: wasm.GetSecretKeyResponse
+	(*PutSecretRequest)(nil),        // 31: wasm.PutSecretRequest
+	(*PutSecretResponse)(nil),       // 32: wasm.PutSecretResponse
+	(*ListSecretsRequest)(nil),      // 33: wasm.ListSecretsRequest
+	(*ListSecretsResponse)(nil),     // 34: wasm.ListSecretsResponse
+	(*DeleteSecretRequest)(nil),     // 35: wasm.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),    // 36: wasm.DeleteSecretResponse
+	(*GetAttestedKeyRequest)(nil),   // 37: wasm.GetAttestedKeyRequest
+	(*GetAttestedKeyResponse)(nil),  // 38: wasm.GetAttestedKeyResponse
+	(*ExecutionJob)(nil),            // 39: wasm.ExecutionJob
+	(*SubmitExecutionRequest)(nil),  // 40: wasm.SubmitExecutionRequest
+	(*SubmitExecutionResponse)(nil), // 41: wasm.SubmitExecutionResponse
+	(*GetExecutionRequest)(nil),     // 42: wasm.GetExecutionRequest
+	(*GetExecutionResponse)(nil),    // 43: wasm.GetExecutionResponse
+	(*CancelExecutionRequest)(nil),  // 44: wasm.CancelExecutionRequest
+	(*CancelExecutionResponse)(nil), // 45: wasm.CancelExecutionResponse
+	(*ListExecutionsRequest)(nil),   // 46: wasm.ListExecutionsRequest
+	(*ListExecutionsResponse)(nil),  // 47: wasm.ListExecutionsResponse
+	(*BatchInputs)(nil),             // 48: wasm.BatchInputs
+	(*ExecuteBatchRequest)(nil),     // 49: wasm.ExecuteBatchRequest
+	(*BatchItemResult)(nil),         // 50: wasm.BatchItemResult
+	(*ExecuteBatchResponse)(nil),    // 51: wasm.ExecuteBatchResponse
+	(*ModuleLoaded)(nil),            // 52: wasm.ModuleLoaded
+	(*HttpCallStarted)(nil),         // 53: wasm.HttpCallStarted
+	(*HttpCallFinished)(nil),        // 54: wasm.HttpCallFinished
+	(*GuestLog)(nil),                // 55: wasm.GuestLog
+	(*GasCheckpoint)(nil),           // 56: wasm.GasCheckpoint
+	(*ExecutionEvent)(nil),          // 57: wasm.ExecutionEvent
+	nil,                             // 58: wasm.HttpExchange.HeadersEntry
+	(*WasmValue)(nil),               // 59: wasm.WasmValue
 }
 var file_wasm_wasm_server_proto_depIdxs = []int32{
-	4, // 0: wasm.WASMVMExecution.inputs:type_name -> wasm.WasmValue
-	4, // 1: wasm.WASMVMExecutionResult.inputs:type_name -> wasm.WasmValue
-	4, // 2: wasm.WASMVMExecutionResult.output_values:type_name -> wasm.WasmValue
-	0, // 3: wasm.WASMVMExecutionRequest.execution:type_name -> wasm.WASMVMExecution
-	1, // 4: wasm.WASMVMExecutionResponse.result:type_name -> wasm.WASMVMExecutionResult
-	2, // 5: wasm.WASMVMTeeService.Execute:input_type -> wasm.WASMVMExecutionRequest
-	3, // 6: wasm.WASMVMTeeService.Execute:output_type -> wasm.WASMVMExecutionResponse
-	6, // [6:7] is the sub-list for method output_type
-	5, // [5:6] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	59, // 0: wasm.WASMVMExecution.inputs:type_name -> wasm.WasmValue
+	5,  // 1: wasm.WASMVMExecution.evm_output:type_name -> wasm.EvmOutputOptions
+	0,  // 2: wasm.EvmOutputOptions.scheme:type_name -> wasm.EvmSignatureScheme
+	0,  // 3: wasm.EvmResult.scheme:type_name -> wasm.EvmSignatureScheme
+	59, // 4: wasm.WASMVMExecutionResult.inputs:type_name -> wasm.WasmValue
+	59, // 5: wasm.WASMVMExecutionResult.output_values:type_name -> wasm.WasmValue
+	7,  // 6: wasm.WASMVMExecutionResult.limits:type_name -> wasm.ExecutionLimits
+	1,  // 7: wasm.WASMVMExecutionResult.execution_mode:type_name -> wasm.ExecutionMode
+	12, // 8: wasm.WASMVMExecutionResult.report_data_components:type_name -> wasm.ReportDataComponents
+	2,  // 9: wasm.WASMVMExecutionResult.attestation_provider:type_name -> wasm.AttestationProvider
+	10, // 10: wasm.WASMVMExecutionResult.http_transcript:type_name -> wasm.HttpExchange
+	11, // 11: wasm.WASMVMExecutionResult.secret_references:type_name -> wasm.SecretReference
+	6,  // 12: wasm.WASMVMExecutionResult.evm_result:type_name -> wasm.EvmResult
+	9,  // 13: wasm.WASMVMExecutionResult.batch_proof:type_name -> wasm.BatchProof
+	58, // 14: wasm.HttpExchange.headers:type_name -> wasm.HttpExchange.HeadersEntry
+	4,  // 15: wasm.WASMVMExecutionRequest.execution:type_name -> wasm.WASMVMExecution
+	8,  // 16: wasm.WASMVMExecutionResponse.result:type_name -> wasm.WASMVMExecutionResult
+	15, // 17: wasm.UploadModuleResponse.module:type_name -> wasm.WasmModule
+	15, // 18: wasm.GetModuleResponse.module:type_name -> wasm.WasmModule
+	15, // 19: wasm.ListModulesResponse.modules:type_name -> wasm.WasmModule
+	8,  // 20: wasm.VerifyExecutionRequest.result:type_name -> wasm.WASMVMExecutionResult
+	24, // 21: wasm.VerifyExecutionRequest.policy:type_name -> wasm.VerificationPolicy
+	11, // 22: wasm.SecretInfo.reference:type_name -> wasm.SecretReference
+	2,  // 23: wasm.GetSecretKeyResponse.attestation_provider:type_name -> wasm.AttestationProvider
+	27, // 24: wasm.PutSecretRequest.sealed:type_name -> wasm.SealedSecret
+	28, // 25: wasm.PutSecretResponse.secret:type_name -> wasm.SecretInfo
+	28, // 26: wasm.ListSecretsResponse.secrets:type_name -> wasm.SecretInfo
+	2,  // 27: wasm.GetAttestedKeyResponse.attestation_provider:type_name -> wasm.AttestationProvider
+	3,  // 28: wasm.ExecutionJob.state:type_name -> wasm.ExecutionState
+	8,  // 29: wasm.ExecutionJob.result:type_name -> wasm.WASMVMExecutionResult
+	4,  // 30: wasm.SubmitExecutionRequest.execution:type_name -> wasm.WASMVMExecution
+	39, // 31: wasm.SubmitExecutionResponse.job:type_name -> wasm.ExecutionJob
+	39, // 32: wasm.GetExecutionResponse.job:type_name -> wasm.ExecutionJob
+	39, // 33: wasm.CancelExecutionResponse.job:type_name -> wasm.ExecutionJob
+	3,  // 34: wasm.ListExecutionsRequest.state:type_name -> wasm.ExecutionState
+	39, // 35: wasm.ListExecutionsResponse.jobs:type_name -> wasm.ExecutionJob
+	59, // 36: wasm.BatchInputs.inputs:type_name -> wasm.WasmValue
+	4,  // 37: wasm.ExecuteBatchRequest.execution:type_name -> wasm.WASMVMExecution
+	48, // 38: wasm.ExecuteBatchRequest.items:type_name -> wasm.BatchInputs
+	8,  // 39: wasm.BatchItemResult.result:type_name -> wasm.WASMVMExecutionResult
+	50, // 40: wasm.ExecuteBatchResponse.items:type_name -> wasm.BatchItemResult
+	1,  // 41: wasm.ModuleLoaded.execution_mode:type_name -> wasm.ExecutionMode
+	10, // 42: wasm.HttpCallFinished.exchange:type_name -> wasm.HttpExchange
+	52, // 43: wasm.ExecutionEvent.module_loaded:type_name -> wasm.ModuleLoaded
+	53, // 44: wasm.ExecutionEvent.http_call_started:type_name -> wasm.HttpCallStarted
+	54, // 45: wasm.ExecutionEvent.http_call_finished:type_name -> wasm.HttpCallFinished
+	55, // 46: wasm.ExecutionEvent.log:type_name -> wasm.GuestLog
+	56, // 47: wasm.ExecutionEvent.gas_checkpoint:type_name -> wasm.GasCheckpoint
+	8,  // 48: wasm.ExecutionEvent.result:type_name -> wasm.WASMVMExecutionResult
+	13, // 49: wasm.WASMVMTeeService.Execute:input_type -> wasm.WASMVMExecutionRequest
+	13, // 50: wasm.WASMVMTeeService.ExecuteStream:input_type -> wasm.WASMVMExecutionRequest
+	49, // 51: wasm.WASMVMTeeService.ExecuteBatch:input_type -> wasm.ExecuteBatchRequest
+	25, // 52: wasm.WASMVMTeeService.VerifyExecution:input_type -> wasm.VerifyExecutionRequest
+	16, // 53: wasm.WASMVMTeeService.UploadModule:input_type -> wasm.UploadModuleRequest
+	18, // 54: wasm.WASMVMTeeService.GetModule:input_type -> wasm.GetModuleRequest
+	20, // 55: wasm.WASMVMTeeService.ListModules:input_type -> wasm.ListModulesRequest
+	22, // 56: wasm.WASMVMTeeService.DeleteModule:input_type -> wasm.DeleteModuleRequest
+	29, // 57: wasm.WASMVMTeeService.GetSecretKey:input_type -> wasm.GetSecretKeyRequest
+	31, // 58: wasm.WASMVMTeeService.PutSecret:input_type -> wasm.PutSecretRequest
+	33, // 59: wasm.WASMVMTeeService.ListSecrets:input_type -> wasm.ListSecretsRequest
+	35, // 60: wasm.WASMVMTeeService.DeleteSecret:input_type -> wasm.DeleteSecretRequest
+	37, // 61: wasm.WASMVMTeeService.GetAttestedKey:input_type -> wasm.GetAttestedKeyRequest
+	40, // 62: wasm.WASMVMTeeService.SubmitExecution:input_type -> wasm.SubmitExecutionRequest
+	42, // 63: wasm.WASMVMTeeService.GetExecution:input_type -> wasm.GetExecutionRequest
+	44, // 64: wasm.WASMVMTeeService.CancelExecution:input_type -> wasm.CancelExecutionRequest
+	46, // 65: wasm.WASMVMTeeService.ListExecutions:input_type -> wasm.ListExecutionsRequest
+	14, // 66: wasm.WASMVMTeeService.Execute:output_type -> wasm.WASMVMExecutionResponse
+	57, // 67: wasm.WASMVMTeeService.ExecuteStream:output_type -> wasm.ExecutionEvent
+	51, // 68: wasm.WASMVMTeeService.ExecuteBatch:output_type -> wasm.ExecuteBatchResponse
+	26, // 69: wasm.WASMVMTeeService.VerifyExecution:output_type -> wasm.VerifyExecutionResponse
+	17, // 70: wasm.WASMVMTeeService.UploadModule:output_type -> wasm.UploadModuleResponse
+	19, // 71: wasm.WASMVMTeeService.GetModule:output_type -> wasm.GetModuleResponse
+	21, // 72: wasm.WASMVMTeeService.ListModules:output_type -> wasm.ListModulesResponse
+	23, // 73: wasm.WASMVMTeeService.DeleteModule:output_type -> wasm.DeleteModuleResponse
+	30, // 74: wasm.WASMVMTeeService.GetSecretKey:output_type -> wasm.GetSecretKeyResponse
+	32, // 75: wasm.WASMVMTeeService.PutSecret:output_type -> wasm.PutSecretResponse
+	34, // 76: wasm.WASMVMTeeService.ListSecrets:output_type -> wasm.ListSecretsResponse
+	36, // 77: wasm.WASMVMTeeService.DeleteSecret:output_type -> wasm.DeleteSecretResponse
+	38, // 78: wasm.WASMVMTeeService.GetAttestedKey:output_type -> wasm.GetAttestedKeyResponse
+	41, // 79: wasm.WASMVMTeeService.SubmitExecution:output_type -> wasm.SubmitExecutionResponse
+	43, // 80: wasm.WASMVMTeeService.GetExecution:output_type -> wasm.GetExecutionResponse
+	45, // 81: wasm.WASMVMTeeService.CancelExecution:output_type -> wasm.CancelExecutionResponse
+	47, // 82: wasm.WASMVMTeeService.ListExecutions:output_type -> wasm.ListExecutionsResponse
+	66, // [66:83] is the sub-list for method output_type
+	49, // [49:66] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_wasm_wasm_server_proto_init() }
@@ -355,18 +4304,27 @@ func file_wasm_wasm_server_proto_init() {
 		return
 	}
 	file_wasm_wasm_input_proto_init()
+	file_wasm_wasm_server_proto_msgTypes[53].OneofWrappers = []any{
+		(*ExecutionEvent_ModuleLoaded)(nil),
+		(*ExecutionEvent_HttpCallStarted)(nil),
+		(*ExecutionEvent_HttpCallFinished)(nil),
+		(*ExecutionEvent_Log)(nil),
+		(*ExecutionEvent_GasCheckpoint)(nil),
+		(*ExecutionEvent_Result)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wasm_wasm_server_proto_rawDesc), len(file_wasm_wasm_server_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      4,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_wasm_wasm_server_proto_goTypes,
 		DependencyIndexes: file_wasm_wasm_server_proto_depIdxs,
+		EnumInfos:         file_wasm_wasm_server_proto_enumTypes,
 		MessageInfos:      file_wasm_wasm_server_proto_msgTypes,
 	}.Build()
 	File_wasm_wasm_server_proto = out.File
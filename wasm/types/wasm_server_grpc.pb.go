@@ -19,7 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WASMVMTeeService_Execute_FullMethodName = "/wasm.WASMVMTeeService/Execute"
+	WASMVMTeeService_Execute_FullMethodName         = "/wasm.WASMVMTeeService/Execute"
+	WASMVMTeeService_ExecuteStream_FullMethodName   = "/wasm.WASMVMTeeService/ExecuteStream"
+	WASMVMTeeService_ExecuteBatch_FullMethodName    = "/wasm.WASMVMTeeService/ExecuteBatch"
+	WASMVMTeeService_VerifyExecution_FullMethodName = "/wasm.WASMVMTeeService/VerifyExecution"
+	WASMVMTeeService_UploadModule_FullMethodName    = "/wasm.WASMVMTeeService/UploadModule"
+	WASMVMTeeService_GetModule_FullMethodName       = "/wasm.WASMVMTeeService/GetModule"
+	WASMVMTeeService_ListModules_FullMethodName     = "/wasm.WASMVMTeeService/ListModules"
+	WASMVMTeeService_DeleteModule_FullMethodName    = "/wasm.WASMVMTeeService/DeleteModule"
+	WASMVMTeeService_GetSecretKey_FullMethodName    = "/wasm.WASMVMTeeService/GetSecretKey"
+	WASMVMTeeService_PutSecret_FullMethodName       = "/wasm.WASMVMTeeService/PutSecret"
+	WASMVMTeeService_ListSecrets_FullMethodName     = "/wasm.WASMVMTeeService/ListSecrets"
+	WASMVMTeeService_DeleteSecret_FullMethodName    = "/wasm.WASMVMTeeService/DeleteSecret"
+	WASMVMTeeService_GetAttestedKey_FullMethodName  = "/wasm.WASMVMTeeService/GetAttestedKey"
+	WASMVMTeeService_SubmitExecution_FullMethodName = "/wasm.WASMVMTeeService/SubmitExecution"
+	WASMVMTeeService_GetExecution_FullMethodName    = "/wasm.WASMVMTeeService/GetExecution"
+	WASMVMTeeService_CancelExecution_FullMethodName = "/wasm.WASMVMTeeService/CancelExecution"
+	WASMVMTeeService_ListExecutions_FullMethodName  = "/wasm.WASMVMTeeService/ListExecutions"
 )
 
 // WASMVMTeeServiceClient is the client API for WASMVMTeeService service.
@@ -27,6 +43,22 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WASMVMTeeServiceClient interface {
 	Execute(ctx context.Context, in *WASMVMExecutionRequest, opts ...grpc.CallOption) (*WASMVMExecutionResponse, error)
+	ExecuteStream(ctx context.Context, in *WASMVMExecutionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutionEvent], error)
+	ExecuteBatch(ctx context.Context, in *ExecuteBatchRequest, opts ...grpc.CallOption) (*ExecuteBatchResponse, error)
+	VerifyExecution(ctx context.Context, in *VerifyExecutionRequest, opts ...grpc.CallOption) (*VerifyExecutionResponse, error)
+	UploadModule(ctx context.Context, in *UploadModuleRequest, opts ...grpc.CallOption) (*UploadModuleResponse, error)
+	GetModule(ctx context.Context, in *GetModuleRequest, opts ...grpc.CallOption) (*GetModuleResponse, error)
+	ListModules(ctx context.Context, in *ListModulesRequest, opts ...grpc.CallOption) (*ListModulesResponse, error)
+	DeleteModule(ctx context.Context, in *DeleteModuleRequest, opts ...grpc.CallOption) (*DeleteModuleResponse, error)
+	GetSecretKey(ctx context.Context, in *GetSecretKeyRequest, opts ...grpc.CallOption) (*GetSecretKeyResponse, error)
+	PutSecret(ctx context.Context, in *PutSecretRequest, opts ...grpc.CallOption) (*PutSecretResponse, error)
+	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
+	GetAttestedKey(ctx context.Context, in *GetAttestedKeyRequest, opts ...grpc.CallOption) (*GetAttestedKeyResponse, error)
+	SubmitExecution(ctx context.Context, in *SubmitExecutionRequest, opts ...grpc.CallOption) (*SubmitExecutionResponse, error)
+	GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error)
+	CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error)
+	ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error)
 }
 
 type wASMVMTeeServiceClient struct {
@@ -47,11 +79,196 @@ func (c *wASMVMTeeServiceClient) Execute(ctx context.Context, in *WASMVMExecutio
 	return out, nil
 }
 
+func (c *wASMVMTeeServiceClient) ExecuteStream(ctx context.Context, in *WASMVMExecutionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WASMVMTeeService_ServiceDesc.Streams[0], WASMVMTeeService_ExecuteStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WASMVMExecutionRequest, ExecutionEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WASMVMTeeService_ExecuteStreamClient = grpc.ServerStreamingClient[ExecutionEvent]
+
+func (c *wASMVMTeeServiceClient) ExecuteBatch(ctx context.Context, in *ExecuteBatchRequest, opts ...grpc.CallOption) (*ExecuteBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteBatchResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_ExecuteBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) VerifyExecution(ctx context.Context, in *VerifyExecutionRequest, opts ...grpc.CallOption) (*VerifyExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyExecutionResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_VerifyExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) UploadModule(ctx context.Context, in *UploadModuleRequest, opts ...grpc.CallOption) (*UploadModuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadModuleResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_UploadModule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) GetModule(ctx context.Context, in *GetModuleRequest, opts ...grpc.CallOption) (*GetModuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetModuleResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_GetModule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) ListModules(ctx context.Context, in *ListModulesRequest, opts ...grpc.CallOption) (*ListModulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModulesResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_ListModules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) DeleteModule(ctx context.Context, in *DeleteModuleRequest, opts ...grpc.CallOption) (*DeleteModuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteModuleResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_DeleteModule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) GetSecretKey(ctx context.Context, in *GetSecretKeyRequest, opts ...grpc.CallOption) (*GetSecretKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretKeyResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_GetSecretKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) PutSecret(ctx context.Context, in *PutSecretRequest, opts ...grpc.CallOption) (*PutSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutSecretResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_PutSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretsResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_ListSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSecretResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_DeleteSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) GetAttestedKey(ctx context.Context, in *GetAttestedKeyRequest, opts ...grpc.CallOption) (*GetAttestedKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttestedKeyResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_GetAttestedKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) SubmitExecution(ctx context.Context, in *SubmitExecutionRequest, opts ...grpc.CallOption) (*SubmitExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitExecutionResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_SubmitExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExecutionResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_GetExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelExecutionResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_CancelExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wASMVMTeeServiceClient) ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExecutionsResponse)
+	err := c.cc.Invoke(ctx, WASMVMTeeService_ListExecutions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WASMVMTeeServiceServer is the server API for WASMVMTeeService service.
 // All implementations must embed UnimplementedWASMVMTeeServiceServer
 // for forward compatibility.
 type WASMVMTeeServiceServer interface {
 	Execute(context.Context, *WASMVMExecutionRequest) (*WASMVMExecutionResponse, error)
+	ExecuteStream(*WASMVMExecutionRequest, grpc.ServerStreamingServer[ExecutionEvent]) error
+	ExecuteBatch(context.Context, *ExecuteBatchRequest) (*ExecuteBatchResponse, error)
+	VerifyExecution(context.Context, *VerifyExecutionRequest) (*VerifyExecutionResponse, error)
+	UploadModule(context.Context, *UploadModuleRequest) (*UploadModuleResponse, error)
+	GetModule(context.Context, *GetModuleRequest) (*GetModuleResponse, error)
+	ListModules(context.Context, *ListModulesRequest) (*ListModulesResponse, error)
+	DeleteModule(context.Context, *DeleteModuleRequest) (*DeleteModuleResponse, error)
+	GetSecretKey(context.Context, *GetSecretKeyRequest) (*GetSecretKeyResponse, error)
+	PutSecret(context.Context, *PutSecretRequest) (*PutSecretResponse, error)
+	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
+	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
+	GetAttestedKey(context.Context, *GetAttestedKeyRequest) (*GetAttestedKeyResponse, error)
+	SubmitExecution(context.Context, *SubmitExecutionRequest) (*SubmitExecutionResponse, error)
+	GetExecution(context.Context, *GetExecutionRequest) (*GetExecutionResponse, error)
+	CancelExecution(context.Context, *CancelExecutionRequest) (*CancelExecutionResponse, error)
+	ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error)
 	mustEmbedUnimplementedWASMVMTeeServiceServer()
 }
 
@@ -65,6 +282,54 @@ type UnimplementedWASMVMTeeServiceServer struct{}
 func (UnimplementedWASMVMTeeServiceServer) Execute(context.Context, *WASMVMExecutionRequest) (*WASMVMExecutionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedWASMVMTeeServiceServer) ExecuteStream(*WASMVMExecutionRequest, grpc.ServerStreamingServer[ExecutionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecuteStream not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) ExecuteBatch(context.Context, *ExecuteBatchRequest) (*ExecuteBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteBatch not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) VerifyExecution(context.Context, *VerifyExecutionRequest) (*VerifyExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyExecution not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) UploadModule(context.Context, *UploadModuleRequest) (*UploadModuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadModule not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) GetModule(context.Context, *GetModuleRequest) (*GetModuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModule not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) ListModules(context.Context, *ListModulesRequest) (*ListModulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModules not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) DeleteModule(context.Context, *DeleteModuleRequest) (*DeleteModuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteModule not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) GetSecretKey(context.Context, *GetSecretKeyRequest) (*GetSecretKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretKey not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) PutSecret(context.Context, *PutSecretRequest) (*PutSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutSecret not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) GetAttestedKey(context.Context, *GetAttestedKeyRequest) (*GetAttestedKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttestedKey not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) SubmitExecution(context.Context, *SubmitExecutionRequest) (*SubmitExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitExecution not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) GetExecution(context.Context, *GetExecutionRequest) (*GetExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecution not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) CancelExecution(context.Context, *CancelExecutionRequest) (*CancelExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExecution not implemented")
+}
+func (UnimplementedWASMVMTeeServiceServer) ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExecutions not implemented")
+}
 func (UnimplementedWASMVMTeeServiceServer) mustEmbedUnimplementedWASMVMTeeServiceServer() {}
 func (UnimplementedWASMVMTeeServiceServer) testEmbeddedByValue()                          {}
 
@@ -104,6 +369,287 @@ func _WASMVMTeeService_Execute_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WASMVMTeeService_ExecuteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WASMVMExecutionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WASMVMTeeServiceServer).ExecuteStream(m, &grpc.GenericServerStream[WASMVMExecutionRequest, ExecutionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WASMVMTeeService_ExecuteStreamServer = grpc.ServerStreamingServer[ExecutionEvent]
+
+func _WASMVMTeeService_ExecuteBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).ExecuteBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_ExecuteBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).ExecuteBatch(ctx, req.(*ExecuteBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_VerifyExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).VerifyExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_VerifyExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).VerifyExecution(ctx, req.(*VerifyExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_UploadModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadModuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).UploadModule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_UploadModule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).UploadModule(ctx, req.(*UploadModuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_GetModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetModuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).GetModule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_GetModule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).GetModule(ctx, req.(*GetModuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_ListModules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).ListModules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_ListModules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).ListModules(ctx, req.(*ListModulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_DeleteModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteModuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).DeleteModule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_DeleteModule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).DeleteModule(ctx, req.(*DeleteModuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_GetSecretKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).GetSecretKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_GetSecretKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).GetSecretKey(ctx, req.(*GetSecretKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_PutSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).PutSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_PutSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).PutSecret(ctx, req.(*PutSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).ListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_ListSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).ListSecrets(ctx, req.(*ListSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_DeleteSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).DeleteSecret(ctx, req.(*DeleteSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_GetAttestedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttestedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).GetAttestedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_GetAttestedKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).GetAttestedKey(ctx, req.(*GetAttestedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_SubmitExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).SubmitExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_SubmitExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).SubmitExecution(ctx, req.(*SubmitExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_GetExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).GetExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_GetExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).GetExecution(ctx, req.(*GetExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_CancelExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).CancelExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_CancelExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).CancelExecution(ctx, req.(*CancelExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WASMVMTeeService_ListExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WASMVMTeeServiceServer).ListExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WASMVMTeeService_ListExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WASMVMTeeServiceServer).ListExecutions(ctx, req.(*ListExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WASMVMTeeService_ServiceDesc is the grpc.ServiceDesc for WASMVMTeeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +661,73 @@ var WASMVMTeeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Execute",
 			Handler:    _WASMVMTeeService_Execute_Handler,
 		},
+		{
+			MethodName: "ExecuteBatch",
+			Handler:    _WASMVMTeeService_ExecuteBatch_Handler,
+		},
+		{
+			MethodName: "VerifyExecution",
+			Handler:    _WASMVMTeeService_VerifyExecution_Handler,
+		},
+		{
+			MethodName: "UploadModule",
+			Handler:    _WASMVMTeeService_UploadModule_Handler,
+		},
+		{
+			MethodName: "GetModule",
+			Handler:    _WASMVMTeeService_GetModule_Handler,
+		},
+		{
+			MethodName: "ListModules",
+			Handler:    _WASMVMTeeService_ListModules_Handler,
+		},
+		{
+			MethodName: "DeleteModule",
+			Handler:    _WASMVMTeeService_DeleteModule_Handler,
+		},
+		{
+			MethodName: "GetSecretKey",
+			Handler:    _WASMVMTeeService_GetSecretKey_Handler,
+		},
+		{
+			MethodName: "PutSecret",
+			Handler:    _WASMVMTeeService_PutSecret_Handler,
+		},
+		{
+			MethodName: "ListSecrets",
+			Handler:    _WASMVMTeeService_ListSecrets_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _WASMVMTeeService_DeleteSecret_Handler,
+		},
+		{
+			MethodName: "GetAttestedKey",
+			Handler:    _WASMVMTeeService_GetAttestedKey_Handler,
+		},
+		{
+			MethodName: "SubmitExecution",
+			Handler:    _WASMVMTeeService_SubmitExecution_Handler,
+		},
+		{
+			MethodName: "GetExecution",
+			Handler:    _WASMVMTeeService_GetExecution_Handler,
+		},
+		{
+			MethodName: "CancelExecution",
+			Handler:    _WASMVMTeeService_CancelExecution_Handler,
+		},
+		{
+			MethodName: "ListExecutions",
+			Handler:    _WASMVMTeeService_ListExecutions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteStream",
+			Handler:       _WASMVMTeeService_ExecuteStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "wasm/wasm_server.proto",
 }
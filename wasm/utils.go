@@ -1,12 +1,8 @@
 package wasm
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
 	"fmt"
 
-	"google.golang.org/protobuf/proto"
-
 	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
 )
 
@@ -36,43 +32,6 @@ const (
 	I64Array
 )
 
-// calculateStandardHash provides a standardized way to hash protobuf messages
-// External systems can use the same method for verification
-func (s *Server) calculateStandardHash(messages ...proto.Message) ([32]byte, error) {
-	var allData []byte
-
-	for i, msg := range messages {
-		// Add message index for ordering
-		indexBytes := make([]byte, 4)
-		binary.BigEndian.PutUint32(indexBytes, uint32(i))
-		allData = append(allData, indexBytes...)
-
-		// Serialize message
-		data, err := proto.Marshal(msg)
-		if err != nil {
-			return [32]byte{}, fmt.Errorf("failed to marshal message %d: %v", i, err)
-		}
-
-		// Add length prefix for clear separation
-		lengthBytes := make([]byte, 4)
-		binary.BigEndian.PutUint32(lengthBytes, uint32(len(data)))
-		allData = append(allData, lengthBytes...)
-		allData = append(allData, data...)
-	}
-
-	return sha256.Sum256(allData), nil
-}
-
-// calculateOutputHash wraps output values for hash calculation
-func (s *Server) calculateOutputHash(outputs []*types.WasmValue) ([32]byte, error) {
-	messages := make([]proto.Message, len(outputs))
-	for i, v := range outputs {
-		messages[i] = v
-	}
-
-	return s.calculateStandardHash(messages...)
-}
-
 func ConvertWasmValuesToInterface(input []*types.WasmValue) ([]interface{}, error) {
 	if input == nil {
 		return nil, fmt.Errorf("input WasmValue is nil")
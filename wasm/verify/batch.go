@@ -0,0 +1,52 @@
+package verify
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// BatchInclusion checks that the report data of an execution is included in the batch of proof
+// It returns the batch report data the attestation of the result must carry, the attested root is
+// in its second half. Execution applies it to every result carrying a batch proof.
+func BatchInclusion(reportData [64]byte, proof *types.BatchProof) ([64]byte, error) {
+	if proof == nil {
+		return [64]byte{}, fmt.Errorf("%w: batch proof is nil", ErrMalformed)
+	}
+
+	root, err := decodeHash(proof.Root)
+	if err != nil {
+		return [64]byte{}, fmt.Errorf("%w: invalid batch root: %v", ErrMalformed, err)
+	}
+	siblings := make([][32]byte, len(proof.Proof))
+	for i, sibling := range proof.Proof {
+		if siblings[i], err = decodeHash(sibling); err != nil {
+			return [64]byte{}, fmt.Errorf("%w: invalid proof hash %d: %v", ErrMalformed, i, err)
+		}
+	}
+
+	actual, err := reportdata.InclusionRoot(reportdata.BatchLeaf(reportData), proof.LeafIndex, proof.BatchSize, siblings)
+	if err != nil {
+		return [64]byte{}, fmt.Errorf("%w: %v", ErrInclusionProof, err)
+	}
+	if actual != root {
+		return [64]byte{}, fmt.Errorf("%w: proof leads to %x, expected %x", ErrInclusionProof, actual, root)
+	}
+
+	return reportdata.BatchReportData(root, proof.BatchSize), nil
+}
+
+// decodeHash decodes a hex encoded 32 byte hash
+func decodeHash(s string) ([32]byte, error) {
+	decoded, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if len(decoded) != 32 {
+		return [32]byte{}, fmt.Errorf("expected 32 bytes, got %d", len(decoded))
+	}
+	return [32]byte(decoded), nil
+}
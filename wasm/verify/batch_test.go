@@ -0,0 +1,121 @@
+package verify
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// testBatch places result second in a batch of three and attests the batch root
+func testBatch(t *testing.T, result *types.WASMVMExecutionResult) *Bundle {
+	t.Helper()
+
+	reportData, err := hex.DecodeString(result.ReportData)
+	if err != nil || len(reportData) != 64 {
+		t.Fatalf("Invalid report data %q", result.ReportData)
+	}
+	leaves := [][32]byte{
+		reportdata.BatchLeaf([64]byte{1}),
+		reportdata.BatchLeaf([64]byte(reportData)),
+		reportdata.BatchLeaf([64]byte{3}),
+	}
+	root := reportdata.MerkleRoot(leaves)
+	proof, err := reportdata.InclusionProof(leaves, 1)
+	if err != nil {
+		t.Fatalf("Failed to build inclusion proof: %v", err)
+	}
+
+	result.BatchProof = &types.BatchProof{Root: hex.EncodeToString(root[:]), LeafIndex: 1, BatchSize: 3}
+	for _, sibling := range proof {
+		result.BatchProof.Proof = append(result.BatchProof.Proof, hex.EncodeToString(sibling[:]))
+	}
+
+	attestation, bundle := testAttestation(t, reportdata.BatchReportData(root, 3), false)
+	result.Attestation = attestation
+	return bundle
+}
+
+// TestBatchExecution - Verifies batched results through their inclusion proof and rejects forged proofs
+func TestBatchExecution(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(result *types.WASMVMExecutionResult)
+		expected error
+	}{
+		{name: "valid"},
+		{
+			name: "tampered_output",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.OutputValues[0] = &types.WasmValue{Value: &types.WasmValue_StringValue{StringValue: "forged"}}
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "wrong_index",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.BatchProof.LeafIndex = 0
+			},
+			expected: ErrInclusionProof,
+		},
+		{
+			name: "wrong_size",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				// The proof still reaches the root, the size is bound by the batch report data
+				result.BatchProof.BatchSize = 4
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "tampered_proof",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.BatchProof.Proof[0] = hex.EncodeToString(make([]byte, 32))
+			},
+			expected: ErrInclusionProof,
+		},
+		{
+			name: "truncated_proof",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.BatchProof.Proof = result.BatchProof.Proof[:1]
+			},
+			expected: ErrInclusionProof,
+		},
+		{
+			name: "other_root",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				// A self-consistent proof of a batch that was never attested
+				leaf, _ := hex.DecodeString(result.ReportData)
+				root := reportdata.BatchLeaf([64]byte(leaf))
+				result.BatchProof = &types.BatchProof{Root: hex.EncodeToString(root[:]), BatchSize: 1}
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "malformed_root",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.BatchProof.Root = "zz"
+			},
+			expected: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := testExecution(t, false)
+			bundle := testBatch(t, result)
+			if tt.mutate != nil {
+				tt.mutate(result)
+			}
+
+			_, err := Execution(result, Options{Bundle: bundle, Nonce: []byte("nonce")})
+			if tt.expected == nil && err != nil {
+				t.Fatalf("Expected the batched result to verify, got %v", err)
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package verify
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/evm"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// EvmResult verifies the EVM encoding of a result and its signature by address
+// address is the receipt key address of a GetAttestedKey response verified with AttestedKey.
+// The outputs are encoded again from the result values and the report data recomputed, so
+// a result whose values were altered after signing is rejected.
+func EvmResult(result *types.WASMVMExecutionResult, address string, opts Options) error {
+	if result == nil || result.EvmResult == nil {
+		return fmt.Errorf("%w: result carries no EVM result", ErrMalformed)
+	}
+	e := result.EvmResult
+
+	reportData, err := resultReportData(result, opts)
+	if err != nil {
+		return err
+	}
+	outputs, err := evm.EncodeOutputs(e.OutputTypes, result.OutputValues)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	signed := evm.Result{
+		RequestID:    e.RequestId,
+		ModuleHash:   [32]byte(reportData[32:]),
+		ReportDigest: [32]byte(reportData[:32]),
+		Outputs:      outputs,
+	}
+	if !strings.EqualFold(e.Encoded, "0x"+hex.EncodeToString(signed.Encode())) {
+		return fmt.Errorf("%w: EVM encoding does not match the result", ErrReportDataMismatch)
+	}
+
+	digest := signed.EIP191Digest()
+	switch e.Scheme {
+	case types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_UNSPECIFIED, types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP191:
+	case types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP712:
+		contract, err := evm.ParseAddress(e.VerifyingContract)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		digest = signed.EIP712Digest(evm.Domain{ChainID: e.ChainId, VerifyingContract: contract})
+	default:
+		return fmt.Errorf("%w: unknown EVM signature scheme %v", ErrMalformed, e.Scheme)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(e.Signature, "0x"))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	signer, err := evm.RecoverAddress(digest, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrReceiptSignature, err)
+	}
+	if !strings.EqualFold(signer, address) {
+		return fmt.Errorf("%w: signed by %s", ErrReceiptSignature, signer)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package verify
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/evm"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// testEvmResult signs the outputs of result for EVM contracts like the server's receipt key
+func testEvmResult(t *testing.T, key *secp256k1.PrivateKey, result *types.WASMVMExecutionResult, domain *evm.Domain) {
+	t.Helper()
+
+	reportData, _ := hex.DecodeString(result.ReportData)
+	outputs, err := evm.EncodeOutputs([]string{"string"}, result.OutputValues)
+	if err != nil {
+		t.Fatalf("Failed to encode outputs: %v", err)
+	}
+	signed := evm.Result{
+		RequestID:    "req-1",
+		ModuleHash:   [32]byte(reportData[32:]),
+		ReportDigest: [32]byte(reportData[:32]),
+		Outputs:      outputs,
+	}
+
+	e := &types.EvmResult{RequestId: "req-1", OutputTypes: []string{"string"}, Encoded: "0x" + hex.EncodeToString(signed.Encode())}
+	digest := signed.EIP191Digest()
+	if domain != nil {
+		digest = signed.EIP712Digest(*domain)
+		e.Scheme = types.EvmSignatureScheme_EVM_SIGNATURE_SCHEME_EIP712
+		e.ChainId = domain.ChainID
+		e.VerifyingContract = "0x" + hex.EncodeToString(domain.VerifyingContract[:])
+	}
+	e.Signature = "0x" + hex.EncodeToString(evm.Sign(key, digest))
+	result.EvmResult = e
+}
+
+// TestEvmResult - Verifies EVM results under both schemes and rejects altered ones
+func TestEvmResult(t *testing.T) {
+	key, _ := secp256k1.GeneratePrivateKey()
+	address, _ := evm.Address(key.PubKey().SerializeCompressed())
+	domain := &evm.Domain{ChainID: 1, VerifyingContract: [20]byte{0xcc}}
+
+	tests := []struct {
+		name     string
+		domain   *evm.Domain
+		mutate   func(result *types.WASMVMExecutionResult)
+		expected error
+	}{
+		{name: "eip191"},
+		{name: "eip712", domain: domain},
+		{
+			name: "tampered_output",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.OutputValues[0] = &types.WasmValue{Value: &types.WasmValue_StringValue{StringValue: "forged"}}
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "other_request",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.EvmResult.RequestId = "req-2"
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name:   "other_chain",
+			domain: domain,
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.EvmResult.ChainId = 5
+			},
+			expected: ErrReceiptSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := testExecution(t, false)
+			testEvmResult(t, key, result, tt.domain)
+			if tt.mutate != nil {
+				tt.mutate(result)
+			}
+
+			err := EvmResult(result, address, Options{})
+			if tt.expected == nil && err != nil {
+				t.Fatalf("Expected the EVM result to verify, got %v", err)
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	spb "github.com/google/go-sev-guest/proto/sevsnp"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// AttestedKey verifies the receipt key returned by GetAttestedKey
+// Once the key is verified, results signed with it can be checked with Receipt.
+func AttestedKey(key *types.GetAttestedKeyResponse, opts Options) (*spb.Attestation, error) {
+	if key == nil {
+		return nil, fmt.Errorf("%w: key is nil", ErrMalformed)
+	}
+	switch key.AttestationProvider {
+	case types.AttestationProvider_ATTESTATION_PROVIDER_UNSPECIFIED, types.AttestationProvider_ATTESTATION_PROVIDER_SEV_SNP:
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedProvider, key.AttestationProvider)
+	}
+	if _, err := secp256k1.ParsePubKey(key.PublicKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	reportData := reportdata.KeyReportData(reportdata.KeyPurposeReceipts, key.PublicKey, nil)
+	if key.ReportData != "" && !strings.EqualFold(key.ReportData, hex.EncodeToString(reportData[:])) {
+		return nil, fmt.Errorf("%w: report_data does not commit to the public key", ErrReportDataMismatch)
+	}
+
+	return Attestation(key.Attestation, reportData, opts)
+}
+
+// Receipt verifies an execution result by its receipt signature rather than its own attestation
+// publicKey is the compressed key of a GetAttestedKey response verified with AttestedKey.
+// The report data is recomputed from the result as in Execution, so altered values are rejected.
+func Receipt(result *types.WASMVMExecutionResult, publicKey []byte, opts Options) error {
+	if result == nil {
+		return fmt.Errorf("%w: result is nil", ErrMalformed)
+	}
+	key, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	reportData, err := resultReportData(result, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.ReceiptPublicKey != "" {
+		resultKey, err := hex.DecodeString(result.ReceiptPublicKey)
+		if err != nil || !bytes.Equal(resultKey, publicKey) {
+			return fmt.Errorf("%w: signed by another key", ErrReceiptSignature)
+		}
+	}
+
+	signature, err := hex.DecodeString(result.ReceiptSignature)
+	if err != nil || len(signature) != 64 {
+		return fmt.Errorf("%w: signature must be 64 hex encoded bytes", ErrMalformed)
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return fmt.Errorf("%w: signature out of range", ErrReceiptSignature)
+	}
+
+	digest := reportdata.ReceiptDigest(reportData)
+	if !ecdsa.NewSignature(&r, &s).Verify(digest[:], key) {
+		return ErrReceiptSignature
+	}
+
+	return nil
+}
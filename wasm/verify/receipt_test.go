@@ -0,0 +1,110 @@
+package verify
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// testReceipt signs the report data of result like the server's receipt key
+func testReceipt(t *testing.T, key *secp256k1.PrivateKey, result *types.WASMVMExecutionResult) {
+	t.Helper()
+
+	reportData, err := hex.DecodeString(result.ReportData)
+	if err != nil || len(reportData) != 64 {
+		t.Fatalf("Invalid report data %q", result.ReportData)
+	}
+	digest := reportdata.ReceiptDigest([64]byte(reportData))
+	result.ReceiptSignature = hex.EncodeToString(ecdsa.SignCompact(key, digest[:], true)[1:])
+	result.ReceiptPublicKey = hex.EncodeToString(key.PubKey().SerializeCompressed())
+}
+
+// TestReceipt - Verifies the attested receipt key and results checked by their receipt signature
+func TestReceipt(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	publicKey := key.PubKey().SerializeCompressed()
+
+	keyReportData := reportdata.KeyReportData(reportdata.KeyPurposeReceipts, publicKey, nil)
+	attestation, bundle := testAttestation(t, keyReportData, false)
+	attested := &types.GetAttestedKeyResponse{
+		PublicKey:   publicKey,
+		Attestation: attestation,
+		ReportData:  hex.EncodeToString(keyReportData[:]),
+	}
+	if _, err := AttestedKey(attested, Options{Bundle: bundle}); err != nil {
+		t.Fatalf("Expected the key attestation to verify, got %v", err)
+	}
+
+	// The attestation only vouches for the key it was made for
+	otherKey, _ := secp256k1.GeneratePrivateKey()
+	swapped := &types.GetAttestedKeyResponse{PublicKey: otherKey.PubKey().SerializeCompressed(), Attestation: attestation}
+	if _, err := AttestedKey(swapped, Options{Bundle: bundle}); !errors.Is(err, ErrReportDataMismatch) {
+		t.Errorf("Expected %v, got %v", ErrReportDataMismatch, err)
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(result *types.WASMVMExecutionResult)
+		expected error
+	}{
+		{name: "valid"},
+		{
+			name: "tampered_output",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.OutputValues[0] = &types.WasmValue{Value: &types.WasmValue_StringValue{StringValue: "forged"}}
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "other_key",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				testReceipt(t, otherKey, result)
+			},
+			expected: ErrReceiptSignature,
+		},
+		{
+			name: "forged_signature",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				testReceipt(t, otherKey, result)
+				result.ReceiptPublicKey = hex.EncodeToString(publicKey)
+			},
+			expected: ErrReceiptSignature,
+		},
+		{
+			name: "missing_signature",
+			mutate: func(result *types.WASMVMExecutionResult) {
+				result.ReceiptSignature = ""
+			},
+			expected: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := testExecution(t, false)
+			testReceipt(t, key, result)
+			// Receipts stand on their own, the result carries no attestation of its own
+			result.Attestation = ""
+			if tt.mutate != nil {
+				tt.mutate(result)
+			}
+
+			err := Receipt(result, publicKey, Options{Nonce: []byte("nonce")})
+			if tt.expected == nil && err != nil {
+				t.Fatalf("Expected the receipt to verify, got %v", err)
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
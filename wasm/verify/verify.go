@@ -0,0 +1,287 @@
+// Package verify checks the attestations produced by the WASMVM TEE server.
+//
+// Verification validates the VCEK/ASK/ARK certificate chain against an offline bundle,
+// checks the SEV-SNP report signature, recomputes the report data from the execution
+// result and enforces a platform policy. Like reportdata it does not depend on the
+// WasmEdge runtime, so clients can import it directly.
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-sev-guest/abi"
+	"github.com/google/go-sev-guest/kds"
+	spb "github.com/google/go-sev-guest/proto/sevsnp"
+	"github.com/google/go-sev-guest/validate"
+	sevverify "github.com/google/go-sev-guest/verify"
+	"github.com/google/go-sev-guest/verify/trust"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+var (
+	// ErrMalformed is returned when the attestation or the execution result cannot be parsed
+	ErrMalformed = errors.New("malformed attestation")
+	// ErrCertificateChain is returned when the certificate chain or the report signature is invalid
+	ErrCertificateChain = errors.New("attestation signature verification failed")
+	// ErrReportDataMismatch is returned when REPORT_DATA does not commit to the execution result
+	ErrReportDataMismatch = errors.New("report data mismatch")
+	// ErrPolicy is returned when the report violates the platform policy
+	ErrPolicy = errors.New("attestation policy violation")
+	// ErrUnsupportedProvider is returned for evidence that is not an SEV-SNP attestation report
+	ErrUnsupportedProvider = errors.New("unsupported attestation provider")
+	// ErrReceiptSignature is returned when a receipt signature was not made by the attested key
+	ErrReceiptSignature = errors.New("invalid receipt signature")
+	// ErrInclusionProof is returned when a batched result is not included in the attested batch root
+	ErrInclusionProof = errors.New("invalid batch inclusion proof")
+)
+
+// Bundle holds the AMD certificates used to verify attestations without contacting the AMD KDS
+type Bundle struct {
+	// ProductLine is the AMD product line the certificates belong to, e.g. Milan or Genoa
+	ProductLine string
+	// ASK is the DER encoded AMD signing key certificate
+	ASK []byte
+	// ARK is the DER encoded AMD root key certificate
+	ARK []byte
+	// VCEK is the DER encoded chip endorsement key certificate, used when the attestation does not carry one
+	VCEK []byte
+}
+
+// NewBundle creates a bundle from a product line and the PEM encoded ASK and ARK,
+// in the order served by the AMD KDS cert_chain endpoint
+func NewBundle(productLine string, certChainPEM []byte) (*Bundle, error) {
+	line := kds.ProductLineOfProductName(productLine)
+	if line == "Unknown" {
+		return nil, fmt.Errorf("unknown AMD product line %q", productLine)
+	}
+
+	ask, ark, err := kds.ParseProductCertChain(certChainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate chain: %v", err)
+	}
+	if _, err := trust.ParseCert(ask); err != nil {
+		return nil, fmt.Errorf("failed to parse ASK certificate: %v", err)
+	}
+	if _, err := trust.ParseCert(ark); err != nil {
+		return nil, fmt.Errorf("failed to parse ARK certificate: %v", err)
+	}
+
+	return &Bundle{ProductLine: line, ASK: ask, ARK: ark}, nil
+}
+
+// verifyOptions returns go-sev-guest options that never fetch certificates over the network
+// A nil bundle trusts the chain carried by the attestation if it matches the AMD roots embedded in go-sev-guest
+func (b *Bundle) verifyOptions() (*sevverify.Options, error) {
+	options := &sevverify.Options{DisableCertFetching: true}
+	if b == nil {
+		return options, nil
+	}
+
+	root := trust.AMDRootCertsProduct(b.ProductLine)
+	if err := root.Decode(b.ASK, b.ARK); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle: %v", err)
+	}
+	options.TrustedRoots = map[string][]*trust.AMDRootCerts{b.ProductLine: {root}}
+
+	return options, nil
+}
+
+// fillChain completes the attestation's certificate chain from the bundle
+func (b *Bundle) fillChain(attestation *spb.Attestation) {
+	if b == nil {
+		return
+	}
+	if attestation.CertificateChain == nil {
+		attestation.CertificateChain = &spb.CertificateChain{}
+	}
+
+	chain := attestation.CertificateChain
+	if len(chain.VcekCert) == 0 {
+		chain.VcekCert = b.VCEK
+	}
+	if len(chain.AskCert) == 0 {
+		chain.AskCert = b.ASK
+	}
+	if len(chain.ArkCert) == 0 {
+		chain.ArkCert = b.ARK
+	}
+}
+
+// Policy is the platform policy enforced on the attestation report
+type Policy struct {
+	// Measurement is the expected 48 byte launch measurement, not checked when empty
+	Measurement []byte
+	// MinimumTCB is the component-wise minimum of the reported TCB
+	MinimumTCB kds.TCBParts
+	// AllowDebug accepts guests launched with the debug policy bit set
+	AllowDebug bool
+}
+
+func (p Policy) validateOptions() (*validate.Options, error) {
+	options := &validate.Options{
+		// SMT is allowed since most hosts enable it, migration agents never are
+		GuestPolicy: abi.SnpPolicy{SMT: true, Debug: p.AllowDebug},
+		MinimumTCB:  p.MinimumTCB,
+	}
+
+	if len(p.Measurement) > 0 {
+		if len(p.Measurement) != abi.MeasurementSize {
+			return nil, fmt.Errorf("measurement must be %d bytes, got %d", abi.MeasurementSize, len(p.Measurement))
+		}
+		options.Measurement = p.Measurement
+	}
+
+	return options, nil
+}
+
+// Options configures verification
+type Options struct {
+	// Bundle supplies the AMD certificates, nil relies on the chain carried by the attestation
+	Bundle *Bundle
+	// Policy is enforced on the attestation report
+	Policy Policy
+
+	// ModuleHash is the expected hex encoded SHA-256 of the bytecode, not checked when empty
+	ModuleHash string
+	// FnName is the expected function name, not checked when empty
+	FnName string
+	// Nonce is the nonce sent with the execution, not checked when empty
+	Nonce []byte
+}
+
+// Attestation verifies a JSON encoded SEV-SNP attestation and checks that it carries reportData
+// The certificate chain and signature are checked before the report contents are trusted
+func Attestation(attestationJSON string, reportData [64]byte, opts Options) (*spb.Attestation, error) {
+	attestation := &spb.Attestation{}
+	if err := protojson.Unmarshal([]byte(attestationJSON), attestation); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if attestation.Report == nil {
+		return nil, fmt.Errorf("%w: report is missing", ErrMalformed)
+	}
+
+	opts.Bundle.fillChain(attestation)
+	verifyOptions, err := opts.Bundle.verifyOptions()
+	if err != nil {
+		return nil, err
+	}
+	if err := sevverify.SnpAttestation(attestation, verifyOptions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCertificateChain, err)
+	}
+
+	if !bytes.Equal(attestation.Report.ReportData, reportData[:]) {
+		return nil, fmt.Errorf("%w: report carries %x, expected %x", ErrReportDataMismatch, attestation.Report.ReportData, reportData)
+	}
+
+	validateOptions, err := opts.Policy.validateOptions()
+	if err != nil {
+		return nil, err
+	}
+	if err := validate.SnpAttestation(attestation, validateOptions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPolicy, err)
+	}
+
+	return attestation, nil
+}
+
+// Execution verifies an execution result returned by the server
+// The inputs and outputs hashes and the transcript and secrets roots are recomputed from the result rather than taken from it,
+// so a result whose values were altered after attestation is rejected
+// Results of a batch are checked against the attested batch root through their inclusion proof
+func Execution(result *types.WASMVMExecutionResult, opts Options) (*spb.Attestation, error) {
+	if result == nil {
+		return nil, fmt.Errorf("%w: result is nil", ErrMalformed)
+	}
+
+	// Results produced before providers were reported carry no provider and are SEV-SNP
+	switch result.AttestationProvider {
+	case types.AttestationProvider_ATTESTATION_PROVIDER_UNSPECIFIED, types.AttestationProvider_ATTESTATION_PROVIDER_SEV_SNP:
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedProvider, result.AttestationProvider)
+	}
+
+	reportData, err := resultReportData(result, opts)
+	if err != nil {
+		return nil, err
+	}
+	if result.BatchProof != nil {
+		if reportData, err = BatchInclusion(reportData, result.BatchProof); err != nil {
+			return nil, err
+		}
+	}
+
+	return Attestation(result.Attestation, reportData, opts)
+}
+
+// resultReportData recomputes the report data of a result from its values
+func resultReportData(result *types.WASMVMExecutionResult, opts Options) ([64]byte, error) {
+	components, err := reportdata.ParseComponents(result.ReportDataComponents)
+	if err != nil {
+		return [64]byte{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if err := checkComponents(result, components, opts); err != nil {
+		return [64]byte{}, err
+	}
+
+	reportData := components.ReportData()
+	if result.ReportData != "" && !strings.EqualFold(result.ReportData, hex.EncodeToString(reportData[:])) {
+		return [64]byte{}, fmt.Errorf("%w: report_data does not match its components", ErrReportDataMismatch)
+	}
+
+	return reportData, nil
+}
+
+// checkComponents compares the report data components with the result and the caller's expectations
+func checkComponents(result *types.WASMVMExecutionResult, components reportdata.Components, opts Options) error {
+	inputsHash, err := reportdata.InputsHash(result.Inputs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if inputsHash != components.InputsHash {
+		return fmt.Errorf("%w: inputs hash", ErrReportDataMismatch)
+	}
+
+	outputsHash, err := reportdata.OutputsHash(result.OutputValues, result.GasUsed, result.Limits)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if outputsHash != components.OutputsHash {
+		return fmt.Errorf("%w: outputs hash", ErrReportDataMismatch)
+	}
+
+	transcriptRoot, err := reportdata.TranscriptRoot(result.HttpTranscript)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if transcriptRoot != components.TranscriptRoot {
+		return fmt.Errorf("%w: transcript root", ErrReportDataMismatch)
+	}
+
+	secretsRoot, err := reportdata.SecretsRoot(result.SecretReferences)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if secretsRoot != components.SecretsRoot {
+		return fmt.Errorf("%w: secrets root", ErrReportDataMismatch)
+	}
+
+	if opts.ModuleHash != "" && !strings.EqualFold(opts.ModuleHash, hex.EncodeToString(components.ModuleHash[:])) {
+		return fmt.Errorf("%w: module hash", ErrReportDataMismatch)
+	}
+	if opts.FnName != "" && sha256.Sum256([]byte(opts.FnName)) != components.FunctionHash {
+		return fmt.Errorf("%w: function hash", ErrReportDataMismatch)
+	}
+	if len(opts.Nonce) > 0 && sha256.Sum256(opts.Nonce) != components.NonceHash {
+		return fmt.Errorf("%w: nonce hash", ErrReportDataMismatch)
+	}
+
+	return nil
+}
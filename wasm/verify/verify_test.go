@@ -0,0 +1,269 @@
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-sev-guest/abi"
+	"github.com/google/go-sev-guest/kds"
+	spb "github.com/google/go-sev-guest/proto/sevsnp"
+	test "github.com/google/go-sev-guest/testing"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/reportdata"
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// testExecution builds an execution result attested by a test-only AMD certificate chain
+// The returned bundle holds the ASK and ARK of that chain, the VCEK travels in the attestation
+func testExecution(t *testing.T, debug bool) (*types.WASMVMExecutionResult, *Bundle) {
+	t.Helper()
+
+	bodyHash := sha256.Sum256([]byte(`{"price":"42"}`))
+	result := &types.WASMVMExecutionResult{
+		Inputs:       []*types.WasmValue{{Value: &types.WasmValue_StringValue{StringValue: "WasmEdge"}}},
+		OutputValues: []*types.WasmValue{{Value: &types.WasmValue_StringValue{StringValue: "hello WasmEdge"}}},
+		GasUsed:      1234,
+		Limits:       &types.ExecutionLimits{GasLimit: 1000000, TimeoutMs: 5000, MaxMemoryPages: 4096},
+		HttpTranscript: []*types.HttpExchange{{
+			Method:     "GET",
+			Url:        "https://api.example.com/price",
+			StatusCode: 200,
+			Headers:    map[string]string{"content-type": "application/json"},
+			BodyHash:   hex.EncodeToString(bodyHash[:]),
+		}},
+		SecretReferences: []*types.SecretReference{{
+			Name:       "api_key",
+			SealedHash: hex.EncodeToString(bodyHash[:]),
+		}},
+	}
+
+	var err error
+	components := reportdata.Components{
+		ModuleHash:   sha256.Sum256([]byte("\x00asm\x01\x00\x00\x00")),
+		FunctionHash: sha256.Sum256([]byte("say")),
+		NonceHash:    sha256.Sum256([]byte("nonce")),
+	}
+	if components.InputsHash, err = reportdata.InputsHash(result.Inputs); err != nil {
+		t.Fatalf("Failed to hash inputs: %v", err)
+	}
+	if components.OutputsHash, err = reportdata.OutputsHash(result.OutputValues, result.GasUsed, result.Limits); err != nil {
+		t.Fatalf("Failed to hash outputs: %v", err)
+	}
+	if components.TranscriptRoot, err = reportdata.TranscriptRoot(result.HttpTranscript); err != nil {
+		t.Fatalf("Failed to hash transcript: %v", err)
+	}
+	if components.SecretsRoot, err = reportdata.SecretsRoot(result.SecretReferences); err != nil {
+		t.Fatalf("Failed to hash secret references: %v", err)
+	}
+	reportData := components.ReportData()
+
+	attestation, bundle := testAttestation(t, reportData, debug)
+	result.Attestation = attestation
+	result.ReportData = hex.EncodeToString(reportData[:])
+	result.ReportDataComponents = components.Proto()
+
+	return result, bundle
+}
+
+// testAttestation builds a JSON attestation carrying reportData, signed by a test-only AMD certificate chain
+func testAttestation(t *testing.T, reportData [64]byte, debug bool) (string, *Bundle) {
+	t.Helper()
+
+	signer, err := test.DefaultTestOnlyCertChain("Milan-B0", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create test certificate chain: %v", err)
+	}
+
+	raw := test.CreateRawReport(&test.TestReportOptions{ReportData: reportData[:]})
+	report := raw[:abi.ReportSize]
+	binary.LittleEndian.PutUint64(report[0x08:0x10], abi.SnpPolicyToBytes(abi.SnpPolicy{Debug: debug}))
+	r, s, err := signer.Sign(abi.SignedComponent(report))
+	if err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+	if err := abi.SetSignature(r, s, report); err != nil {
+		t.Fatalf("Failed to set signature: %v", err)
+	}
+
+	reportProto, err := abi.ReportToProto(report)
+	if err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	attestation, err := protojson.Marshal(&spb.Attestation{
+		Report:           reportProto,
+		CertificateChain: &spb.CertificateChain{VcekCert: signer.Vcek.Raw},
+		Product:          &spb.SevProduct{Name: spb.SevProduct_SEV_PRODUCT_MILAN},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal attestation: %v", err)
+	}
+
+	certChain := append(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signer.Ask.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signer.Ark.Raw})...,
+	)
+	bundle, err := NewBundle("Milan", certChain)
+	if err != nil {
+		t.Fatalf("Failed to create bundle: %v", err)
+	}
+
+	return string(attestation), bundle
+}
+
+// TestExecution - Verifies a well-formed execution result against an offline bundle
+func TestExecution(t *testing.T) {
+	result, bundle := testExecution(t, false)
+
+	attestation, err := Execution(result, Options{
+		Bundle:     bundle,
+		ModuleHash: result.ReportDataComponents.ModuleHash,
+		FnName:     "say",
+		Nonce:      []byte("nonce"),
+	})
+	if err != nil {
+		t.Fatalf("Expected verification to succeed, got %v", err)
+	}
+	if len(attestation.Report.Measurement) != abi.MeasurementSize {
+		t.Errorf("Unexpected measurement length %d", len(attestation.Report.Measurement))
+	}
+}
+
+// TestExecutionRejected - Checks that tampering and policy violations are reported with the right error
+func TestExecutionRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		debug    bool
+		mutate   func(result *types.WASMVMExecutionResult, opts *Options)
+		expected error
+	}{
+		{
+			name: "tampered_output",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.OutputValues[0] = &types.WasmValue{Value: &types.WasmValue_StringValue{StringValue: "forged"}}
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "tampered_gas_used",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.GasUsed++
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "tampered_transcript",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.HttpTranscript[0].Url = "https://attacker.example.com/price"
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "dropped_transcript",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.HttpTranscript = nil
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "tampered_secret_reference",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.SecretReferences[0].Name = "other_key"
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "wrong_nonce",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				opts.Nonce = []byte("replayed")
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "wrong_function",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				opts.FnName = "other"
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "forged_components",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.ReportDataComponents.NonceHash = hex.EncodeToString(make([]byte, sha256.Size))
+				result.ReportData = ""
+			},
+			expected: ErrReportDataMismatch,
+		},
+		{
+			name: "not_signed_by_amd",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				opts.Bundle = nil
+			},
+			expected: ErrCertificateChain,
+		},
+		{
+			name: "tampered_report",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				attestation := &spb.Attestation{}
+				protojson.Unmarshal([]byte(result.Attestation), attestation)
+				attestation.Report.GuestSvn++
+				tampered, _ := protojson.Marshal(attestation)
+				result.Attestation = string(tampered)
+			},
+			expected: ErrCertificateChain,
+		},
+		{
+			name:     "debug_guest",
+			debug:    true,
+			mutate:   func(result *types.WASMVMExecutionResult, opts *Options) {},
+			expected: ErrPolicy,
+		},
+		{
+			name: "measurement",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				opts.Policy.Measurement = make([]byte, abi.MeasurementSize)
+				opts.Policy.Measurement[0] = 1
+			},
+			expected: ErrPolicy,
+		},
+		{
+			name: "minimum_tcb",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				opts.Policy.MinimumTCB = kds.TCBParts{SnpSpl: 1}
+			},
+			expected: ErrPolicy,
+		},
+		{
+			name: "mock_provider",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.AttestationProvider = types.AttestationProvider_ATTESTATION_PROVIDER_MOCK
+			},
+			expected: ErrUnsupportedProvider,
+		},
+		{
+			name: "malformed_attestation",
+			mutate: func(result *types.WASMVMExecutionResult, opts *Options) {
+				result.Attestation = "{"
+			},
+			expected: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, bundle := testExecution(t, tt.debug)
+			opts := Options{Bundle: bundle}
+			tt.mutate(result, &opts)
+
+			_, err := Execution(result, opts)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
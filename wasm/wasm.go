@@ -1,32 +1,194 @@
 package wasm
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"io"
+	"log"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/second-state/WasmEdge-go/wasmedge"
-	bindgen "github.com/second-state/wasmedge-bindgen/host/go"
+
+	"github.com/IntelliXLabs/wasmvm-tee/wasm/types"
+)
+
+// WasmEdge error codes (see WasmEdge's enum_errcode) that are mapped to package errors
+const (
+	wasmEdgeErrCostLimitExceeded = 0x03
+)
+
+var (
+	// ErrGasLimitExceeded is returned when the guest exhausts its gas budget
+	ErrGasLimitExceeded = errors.New("gas limit exceeded")
+	// ErrMemoryLimitExceeded is returned when the guest fails after its linear memory reached the page cap
+	ErrMemoryLimitExceeded = errors.New("memory limit exceeded")
 )
 
+// host holds the state shared by the host functions of a single execution
 type host struct {
-	fetchResult []byte
+	ctx     context.Context
+	stopped atomic.Bool
+	// httpOptions configures the outbound requests of fetch and http
+	httpOptions httpOptions
+
+	// results holds host call responses by handle until the guest frees them
+	mu         sync.Mutex
+	results    map[int32][]byte
+	lastHandle int32
+
+	// transcript records the outbound HTTP requests in the order they were made
+	transcript []*types.HttpExchange
+
+	// secrets opens the secrets of get_secret and header placeholders, secretRefs records those used
+	secrets    SecretSource
+	secretRefs map[string]*types.SecretReference
+
+	// replay serves fetch and http from a bundle instead of the network, recording captures them
+	replay    *ReplayBundle
+	replayPos int
+	replayErr error
+	recording *ReplayBundle
+
+	// events receives the progress of the execution, calls counts the HTTP calls for their events
+	events func(*types.ExecutionEvent)
+	calls  uint32
+
+	// stat meters the gas of the gas checkpoints, it is only read while the guest is within a host call
+	stat           *wasmedge.Statistics
+	gasBefore      uint
+	gasLimit       uint64
+	started        time.Time
+	lastCheckpoint time.Time
+}
+
+// record appends an exchange to the HTTP transcript, nil exchanges are ignored
+func (h *host) record(exchange *types.HttpExchange) {
+	if exchange == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.transcript = append(h.transcript, exchange)
+}
+
+// begin resets the state of h for the next execution, the host functions stay registered
+func (h *host) begin(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.ctx = ctx
+	h.stopped.Store(false)
+	h.results, h.lastHandle = nil, 0
+	h.transcript = nil
+	h.secretRefs = nil
+	h.replayPos, h.replayErr = 0, nil
+	h.recording = nil
+	h.calls = 0
+}
+
+// ExecuteOptions bounds the resources a single guest execution may consume
+// The guest's shadow stack lives in linear memory, so MaxMemoryPages bounds stack growth as well
+type ExecuteOptions struct {
+	GasLimit         uint64        // Maximum gas (summed instruction cost), 0 means unlimited
+	MaxMemoryPages   uint32        // Maximum linear memory size in 64 KiB pages, 0 means WasmEdge's default
+	ForceInterpreter bool          // Interpret the module even when an AOT cache is available
+	AOTCache         *AOTCache     // Compiles and caches the module ahead of time, nil means interpreter mode
+	Egress           *EgressPolicy // Restricts the destinations of fetch and http, nil allows public destinations only
+	Replay           *ReplayBundle // Serves fetch and http from a recorded bundle instead of the network, library only
+	Record           bool          // Captures fetch and http calls into ExecuteResult.Replay, library only
+	HttpLimits       *HttpLimits   // Bounds fetch and http bodies and headers, nil means DefaultHttpLimits
+	TLS              *TLSConfig    // TLS material and pins of fetch and http, scope it with TLSConfig.ForModule
+	Secrets          SecretSource  // Secrets of get_secret and http header placeholders, see SecretVault.ForModule
+	// Events receives the HTTP calls, log lines and gas checkpoints of the execution, it must be safe for concurrent use
+	Events func(*types.ExecutionEvent)
+}
+
+// ExecuteResult contains the guest return values together with execution statistics
+type ExecuteResult struct {
+	Values  []any
+	GasUsed uint64
+	AOT     bool // Whether the AOT-compiled artifact was executed
+	// Transcript lists the requests made through fetch and http, in order
+	Transcript []*types.HttpExchange
+	// Replay holds the captured fetch and http calls when ExecuteOptions.Record is set
+	Replay *ReplayBundle
+	// SecretReferences identifies the secrets the guest used, ordered by name
+	SecretReferences []*types.SecretReference
 }
 
 // ExecuteWasm executes WebAssembly code and returns proto Value structures
 func ExecuteWasm(wasmCode []byte, fnName string, params []any) ([]any, error) {
+	return ExecuteWasmContext(context.Background(), wasmCode, fnName, params)
+}
+
+// ExecuteWasmContext is ExecuteWasm terminating the guest when ctx is cancelled or its deadline expires
+func ExecuteWasmContext(ctx context.Context, wasmCode []byte, fnName string, params []any) ([]any, error) {
+	result, err := ExecuteWasmWithOptions(ctx, wasmCode, fnName, params, ExecuteOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Values, nil
+}
+
+// ExecuteWasmWithOptions executes WebAssembly code within the limits given by opts
+// Gas is metered through WasmEdge's cost measuring statistics, every instruction costs one unit
+// The guest runs asynchronously and is terminated as soon as ctx is cancelled or its deadline expires
+func ExecuteWasmWithOptions(ctx context.Context, wasmCode []byte, fnName string, params []any, opts ExecuteOptions) (*ExecuteResult, error) {
+	module, err := LoadModule(ctx, wasmCode, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer module.Release()
+
+	return module.Execute(ctx, fnName, params)
+}
+
+// LoadedModule is a module loaded, validated and, with an AOT cache, compiled once for several executions
+// Every execution instantiates the module afresh, so guest memory and globals never carry over from one
+// execution to the next. A LoadedModule is not safe for concurrent use.
+type LoadedModule struct {
+	opts ExecuteOptions
+	conf *wasmedge.Configure
+	vm   *wasmedge.VM
+	obj  *wasmedge.Module
+	stat *wasmedge.Statistics
+	h    *host
+	aot  bool
+}
+
+// LoadModule prepares wasmCode for executions within the limits given by opts
+// With opts.Replay every execution is served from the start of the bundle.
+// ctx is checked between compilation, loading and validation, which WasmEdge cannot interrupt themselves.
+func LoadModule(ctx context.Context, wasmCode []byte, opts ExecuteOptions) (*LoadedModule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("execution terminated: %w", err)
+	}
 	wasmedge.SetLogErrorLevel()
 
 	conf := wasmedge.NewConfigure(wasmedge.WASI)
-	defer conf.Release()
+	conf.SetStatisticsCostMeasuring(true)
+	conf.SetForceInterpreter(opts.ForceInterpreter)
+	if opts.MaxMemoryPages > 0 {
+		conf.SetMaxMemoryPage(uint(opts.MaxMemoryPages))
+	}
 
 	vm := wasmedge.NewVMWithConfig(conf)
-	defer vm.Release()
-
 	obj := wasmedge.NewModule("env")
-	defer obj.Release()
+	m := &LoadedModule{opts: opts, conf: conf, vm: vm, obj: obj, stat: vm.GetStatistics()}
+
+	egress := opts.Egress
+	if egress == nil {
+		egress = defaultEgressPolicy
+	}
+	h := &host{replay: opts.Replay, secrets: opts.Secrets, events: opts.Events, stat: m.stat, gasLimit: opts.GasLimit}
+	h.httpOptions = httpOptions{egress: egress, limits: opts.HttpLimits.withDefaults(), tls: opts.TLS, secrets: h.openSecret}
+	m.h = h
 
-	h := host{}
 	// Add host functions into the module instance
 	funcFetchType := wasmedge.NewFunctionType(
 		[]*wasmedge.ValType{
@@ -52,75 +214,297 @@ func ExecuteWasm(wasmCode []byte, fnName string, params []any) ([]any, error) {
 	hostHttp := wasmedge.NewFunction(funcHttpType, h.http, nil, 0)
 	obj.AddFunction("http", hostHttp)
 
-	funcWriteType := wasmedge.NewFunctionType(
+	// Result access functions for the handles returned by fetch and http
+	funcResultLenType := wasmedge.NewFunctionType(
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		},
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		})
+	hostResultLen := wasmedge.NewFunction(funcResultLenType, h.resultLen, nil, 0)
+	obj.AddFunction("result_len", hostResultLen)
+
+	funcReadResultType := wasmedge.NewFunctionType(
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+		},
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		})
+	hostReadResult := wasmedge.NewFunction(funcReadResultType, h.readResult, nil, 0)
+	obj.AddFunction("read_result", hostReadResult)
+
+	funcReadResultAtType := wasmedge.NewFunctionType(
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+		},
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		})
+	hostReadResultAt := wasmedge.NewFunction(funcReadResultAtType, h.readResultAt, nil, 0)
+	obj.AddFunction("read_result_at", hostReadResultAt)
+
+	funcFreeResultType := wasmedge.NewFunctionType(
 		[]*wasmedge.ValType{
 			wasmedge.NewValTypeI32(),
 		},
-		[]*wasmedge.ValType{})
-	hostWrite := wasmedge.NewFunction(funcWriteType, h.writeMem, nil, 0)
-	obj.AddFunction("write_mem", hostWrite)
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		})
+	hostFreeResult := wasmedge.NewFunction(funcFreeResultType, h.freeResult, nil, 0)
+	obj.AddFunction("free_result", hostFreeResult)
+
+	// Secret access, the value is returned under a result handle
+	funcGetSecretType := wasmedge.NewFunctionType(
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+		},
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		})
+	hostGetSecret := wasmedge.NewFunction(funcGetSecretType, h.getSecret, nil, 0)
+	obj.AddFunction("get_secret", hostGetSecret)
+
+	// Log lines for the execution events
+	funcLogType := wasmedge.NewFunctionType(
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+			wasmedge.NewValTypeI32(),
+		},
+		[]*wasmedge.ValType{
+			wasmedge.NewValTypeI32(),
+		})
+	hostLog := wasmedge.NewFunction(funcLogType, h.log, nil, 0)
+	obj.AddFunction("log", hostLog)
 
 	vm.RegisterModule(obj)
 
-	vm.LoadWasmBuffer(wasmCode)
-	vm.Validate()
-	vm.Instantiate()
+	if opts.AOTCache != nil && !opts.ForceInterpreter {
+		// Fall back to the interpreter when the module cannot be compiled
+		if path, err := opts.AOTCache.Compile(ctx, wasmCode); ctx.Err() != nil {
+			m.Release()
+			return nil, fmt.Errorf("execution terminated: %w", ctx.Err())
+		} else if err != nil {
+			log.Printf("AOT compilation failed, using interpreter: %v", err)
+		} else if err := vm.LoadWasmFile(path); err != nil {
+			log.Printf("Failed to load AOT artifact %s, using interpreter: %v", path, err)
+		} else {
+			m.aot = true
+		}
+	}
+	if !m.aot {
+		if err := vm.LoadWasmBuffer(wasmCode); err != nil {
+			m.Release()
+			return nil, fmt.Errorf("failed to load WASM module: %v", err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		m.Release()
+		return nil, fmt.Errorf("execution terminated: %w", err)
+	}
+	if err := vm.Validate(); err != nil {
+		m.Release()
+		return nil, fmt.Errorf("failed to validate WASM module: %v", err)
+	}
+
+	return m, nil
+}
+
+// AOT reports whether the module executes as AOT-compiled native code
+func (m *LoadedModule) AOT() bool {
+	return m.aot
+}
+
+// Release frees the WasmEdge resources of the module
+func (m *LoadedModule) Release() {
+	m.obj.Release()
+	m.vm.Release()
+	m.conf.Release()
+}
+
+// Execute instantiates the module and calls fnName with params
+// Gas is metered through WasmEdge's cost measuring statistics, every instruction costs one unit
+// The guest runs asynchronously and is terminated as soon as ctx is cancelled or its deadline expires
+func (m *LoadedModule) Execute(ctx context.Context, fnName string, params []any) (*ExecuteResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("execution terminated: %w", err)
+	}
+
+	h, stat, opts := m.h, m.stat, m.opts
+	h.begin(ctx)
+	if opts.Record {
+		h.recording = &ReplayBundle{}
+	}
+
+	// Statistics accumulate over the executions of the module, the gas limit applies to this one
+	gasBefore := stat.GetTotalCost()
+	costLimit := ^uint(0)
+	if opts.GasLimit > 0 {
+		costLimit = gasBefore + uint(opts.GasLimit)
+	}
+	stat.SetCostLimit(costLimit)
+	h.gasBefore, h.started, h.lastCheckpoint = gasBefore, time.Now(), time.Now()
+
+	if err := m.vm.Instantiate(); err != nil {
+		return nil, fmt.Errorf("failed to instantiate WASM module: %v", err)
+	}
+
+	// The guest runs on WasmEdge's own thread and is stopped through the VM's stop token when ctx ends
+	type executeOutcome struct {
+		results []any
+		err     error
+	}
+	done := make(chan executeOutcome, 1)
+	go func() {
+		results, _, err := (&guest{ctx: ctx, vm: m.vm}).Execute(fnName, params...)
+		done <- executeOutcome{results: results, err: err}
+	}()
+
+	var outcome executeOutcome
+	select {
+	case outcome = <-done:
+	case <-ctx.Done():
+		// Host functions refuse further calls while the guest unwinds
+		h.stopped.Store(true)
+		outcome = <-done
+	}
 
-	bg := bindgen.New(vm)
-	bg.Instantiate()
-	// Execute WASM function
-	results, _, err := bg.Execute(fnName, params...)
+	results, err := outcome.results, outcome.err
+	gasUsed := uint64(stat.GetTotalCost() - gasBefore)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("execution terminated: %w", ctxErr)
+	}
 	if err != nil {
+		if h.replayErr != nil {
+			return nil, h.replayErr
+		}
+		var res *wasmedge.Result
+		if errors.As(err, &res) && res.GetCode() == wasmEdgeErrCostLimitExceeded {
+			return nil, fmt.Errorf("%w: used %d of %d", ErrGasLimitExceeded, gasUsed, opts.GasLimit)
+		}
+		if pages, ok := memoryLimitReached(m.vm, opts.MaxMemoryPages); ok {
+			return nil, fmt.Errorf("%w: %d of %d pages in use: %v", ErrMemoryLimitExceeded, pages, opts.MaxMemoryPages, err)
+		}
 		return nil, fmt.Errorf("failed to execute WASM function: %v", err)
 	}
 
-	return results, nil
+	return &ExecuteResult{
+		Values:           results,
+		GasUsed:          gasUsed,
+		AOT:              m.aot,
+		Transcript:       h.transcript,
+		Replay:           h.recording,
+		SecretReferences: h.secretReferences(),
+	}, nil
+}
+
+// memoryLimitReached reports whether the guest's linear memory has grown up to maxPages
+// WasmEdge fails memory.grow past the cap instead of trapping, so a guest failing at the cap
+// is attributed to the memory limit
+func memoryLimitReached(vm *wasmedge.VM, maxPages uint32) (uint, bool) {
+	if maxPages == 0 {
+		return 0, false
+	}
+
+	module := vm.GetActiveModule()
+	if module == nil {
+		return 0, false
+	}
+
+	mem := module.FindMemory("memory")
+	if mem == nil {
+		return 0, false
+	}
+
+	pages := mem.GetPageSize()
+	return pages, pages >= uint(maxPages)
 }
 
-// do the http fetch
-func fetch(url string) []byte {
-	resp, err := http.Get(string(url))
+// do the http fetch, requests rejected by policy fail like any other request
+// The body is returned raw so it cannot carry a truncation flag, bodies over the limit fail instead
+// The returned exchange is nil when no request was sent
+func fetch(ctx context.Context, opts httpOptions, url string) ([]byte, *types.HttpExchange) {
+	policy, limits := opts.egress, opts.limits
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil
+		return nil, nil
+	}
+	if err := policy.CheckRequest(req.Method, req.URL); err != nil {
+		log.Printf("fetch rejected: %v", err)
+		return nil, nil
+	}
+	if err := opts.tls.requireHTTPS(nil, req.URL); err != nil {
+		log.Printf("fetch rejected: %v", err)
+		return nil, nil
+	}
+
+	// fetch cannot name TLS material, only the configured host pins apply
+	tlsClientConfig, err := opts.tls.clientConfig(nil)
+	if err != nil {
+		return nil, nil
+	}
+	resp, err := opts.tls.restrictRedirects(policy.Client(0, tlsClientConfig), nil).Do(req)
+	if err != nil {
+		if egressViolation(err) != nil {
+			log.Printf("fetch rejected: %v", err)
+			return nil, nil
+		}
+		return nil, failedHttpExchange(req, err)
 	}
 	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+
+	if count := headerCount(resp.Header); count > limits.MaxHeaders {
+		return nil, failedHttpExchange(resp.Request, fmt.Errorf("%d response headers exceed %d", count, limits.MaxHeaders))
+	}
+	body, truncated, err := readLimitedBody(resp.Body, limits.MaxResponseBodyBytes)
 	if err != nil {
-		return nil
+		return nil, failedHttpExchange(resp.Request, err)
+	}
+	if truncated {
+		return nil, failedHttpExchange(resp.Request, fmt.Errorf("response body exceeds %d bytes", limits.MaxResponseBodyBytes))
 	}
 
-	return body
+	return body, newHttpExchange(resp, body)
 }
 
-// Host function for fetching
+// Host function for fetching: fetch(url_ptr, url_len) -> handle
+// The response body is kept under the returned handle, see result_len, read_result and free_result
 func (h *host) fetch(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
-	// get url from memory
-	pointer := params[0].(int32)
-	size := params[1].(int32)
-	mem := callframe.GetMemoryByIndex(0)
-	data, _ := mem.GetData(uint(pointer), uint(size))
-	url := make([]byte, size)
+	if h.stopped.Load() {
+		return nil, wasmedge.Result_Terminate
+	}
 
-	copy(url, data)
+	// get url from memory
+	url, err := readGuestMemory(callframe, uint32(params[0].(int32)), uint32(params[1].(int32)))
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
 
-	respBody := fetch(string(url))
+	respBody, exchange, err := h.roundTrip(ReplayCallFetch, string(url), func() ([]byte, *types.HttpExchange) {
+		return fetch(h.ctx, h.httpOptions, string(url))
+	})
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
+	h.record(exchange)
 
 	if respBody == nil {
 		return nil, wasmedge.Result_Fail
 	}
 
 	// store the source code
-	h.fetchResult = respBody
-
-	return []any{any(len(respBody))}, wasmedge.Result_Success
-}
-
-// Host function for writting memory
-func (h *host) writeMem(_ any, callframe *wasmedge.CallingFrame, params []any) ([]any, wasmedge.Result) {
-	// write source code to memory
-	pointer := params[0].(int32)
-	mem := callframe.GetMemoryByIndex(0)
-	mem.SetData(h.fetchResult, uint(pointer), uint(len(h.fetchResult)))
+	handle, err := h.storeResult(respBody)
+	if err != nil {
+		return nil, wasmedge.Result_Fail
+	}
 
-	return nil, wasmedge.Result_Success
+	return []any{handle}, wasmedge.Result_Success
 }
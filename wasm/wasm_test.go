@@ -1,10 +1,15 @@
 package wasm
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"math"
 	"os"
+	"path/filepath"
 	reflect "reflect"
 	"testing"
+	"time"
 )
 
 var wasmFilePath = "../wasm/rust_host_func/target/wasm32-wasip1/release/rust_host_func.wasm"
@@ -151,3 +156,137 @@ func TestExecuteWasmAllFunctions(t *testing.T) {
 
 	t.Logf("🎉 All WASM function tests completed successfully!")
 }
+
+// TestExecuteWasmGasLimit - Verifies gas metering and enforcement of the gas limit
+func TestExecuteWasmGasLimit(t *testing.T) {
+	wasmBytes, err := os.ReadFile(wasmFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read WASM file %s: %v", wasmFilePath, err)
+	}
+
+	t.Run("within_limit", func(t *testing.T) {
+		result, err := ExecuteWasmWithOptions(context.Background(), wasmBytes, "say", []any{"WasmEdge"}, ExecuteOptions{GasLimit: 10_000_000})
+		if err != nil {
+			t.Fatalf("Failed to execute 'say' function: %v", err)
+		}
+
+		if result.GasUsed == 0 {
+			t.Fatalf("Expected gas used to be greater than 0")
+		}
+
+		t.Logf("✓ say executed within gas limit. Gas used: %d", result.GasUsed)
+	})
+
+	t.Run("limit_exceeded", func(t *testing.T) {
+		_, err := ExecuteWasmWithOptions(context.Background(), wasmBytes, "say", []any{"WasmEdge"}, ExecuteOptions{GasLimit: 10})
+		if !errors.Is(err, ErrGasLimitExceeded) {
+			t.Fatalf("Expected ErrGasLimitExceeded, got %v", err)
+		}
+
+		t.Logf("✓ execution stopped after exceeding gas limit: %v", err)
+	})
+}
+
+// TestExecuteWasmContextCancelled - Verifies that a cancelled context terminates the execution
+func TestExecuteWasmContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ExecuteWasmWithOptions(ctx, []byte{}, "say", []any{"WasmEdge"}, ExecuteOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	t.Logf("✓ cancelled execution terminated: %v", err)
+}
+
+// TestExecuteWasmCancelRunning - Verifies that cancelling the context stops a guest stuck in a loop, interpreted or AOT-compiled
+func TestExecuteWasmCancelRunning(t *testing.T) {
+	wasmBytes, err := os.ReadFile(wasmFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read WASM file %s: %v", wasmFilePath, err)
+	}
+
+	cache, err := NewAOTCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create AOT cache: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts ExecuteOptions
+	}{
+		{"interpreter", ExecuteOptions{}},
+		{"aot", ExecuteOptions{AOTCache: cache}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module, err := LoadModule(context.Background(), wasmBytes, tt.opts)
+			if err != nil {
+				t.Fatalf("Failed to load module: %v", err)
+			}
+			defer module.Release()
+			if module.AOT() != (tt.opts.AOTCache != nil) {
+				t.Fatalf("Expected AOT %v, got %v", tt.opts.AOTCache != nil, module.AOT())
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(100*time.Millisecond, cancel)
+
+			started := time.Now()
+			_, err = module.Execute(ctx, "spin", []any{uint64(math.MaxUint64)})
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context.Canceled, got %v", err)
+			}
+			if elapsed := time.Since(started); elapsed > 5*time.Second {
+				t.Fatalf("Execution took %v to stop", elapsed)
+			}
+
+			t.Logf("✓ running execution stopped after %v: %v", time.Since(started), err)
+		})
+	}
+}
+
+// TestExecuteWasmAOT - Verifies AOT compilation, artifact caching and the interpreter override
+func TestExecuteWasmAOT(t *testing.T) {
+	wasmBytes, err := os.ReadFile(wasmFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read WASM file %s: %v", wasmFilePath, err)
+	}
+
+	cache, err := NewAOTCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create AOT cache: %v", err)
+	}
+
+	t.Run("aot", func(t *testing.T) {
+		result, err := ExecuteWasmWithOptions(context.Background(), wasmBytes, "say", []any{"WasmEdge"}, ExecuteOptions{AOTCache: cache})
+		if err != nil {
+			t.Fatalf("Failed to execute 'say' function: %v", err)
+		}
+
+		if !result.AOT {
+			t.Fatalf("Expected AOT execution")
+		}
+
+		if result.Values[0].(string) != "hello WasmEdge" {
+			t.Errorf("Unexpected output from 'say' function: %v", result.Values[0])
+		}
+
+		if _, err := os.Stat(filepath.Join(cache.dir, cache.CacheKey(wasmBytes)+".so")); err != nil {
+			t.Errorf("Expected compiled artifact in cache: %v", err)
+		}
+	})
+
+	t.Run("force_interpreter", func(t *testing.T) {
+		result, err := ExecuteWasmWithOptions(context.Background(), wasmBytes, "say", []any{"WasmEdge"}, ExecuteOptions{AOTCache: cache, ForceInterpreter: true})
+		if err != nil {
+			t.Fatalf("Failed to execute 'say' function: %v", err)
+		}
+
+		if result.AOT {
+			t.Fatalf("Expected interpreter execution")
+		}
+	})
+}